@@ -0,0 +1,228 @@
+// Copyright 2026 The xgen Authors. All rights reserved. Use of this source
+// code is governed by a BSD-style license that can be found in the LICENSE
+// file.
+//
+// Package xgen written in pure Go providing a set of functions that allow you
+// to parse XSD (XML schema files). This library needs Go version 1.10 or
+// later.
+
+package xgen
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/net/html/charset"
+)
+
+// Catalog maps the schemaLocations and the namespaces of the imported and
+// included schemas to local files or other URLs by the OASIS XML Catalogs,
+// which supports the uri, rewriteURI, system, rewriteSystem and nextCatalog
+// entries, the entries may be grouped by the group elements. The catalogs are
+// specified in https://www.oasis-open.org/committees/download.php/14809/xml-catalogs.html
+type Catalog struct {
+	uris           map[string]string
+	systems        map[string]string
+	rewriteURIs    []catalogRewrite
+	rewriteSystems []catalogRewrite
+	next           []*Catalog
+}
+
+// catalogRewrite is a rewriteURI or rewriteSystem entry, which replaces the
+// prefix of a matched identifier.
+type catalogRewrite struct {
+	prefix  string
+	replace string
+}
+
+// LoadCatalog loads the catalog files, the files are consulted in the given
+// order as if they were referenced by the nextCatalog entries. The catalogs
+// referenced by the nextCatalog entries are loaded along with the files.
+func LoadCatalog(files ...string) (*Catalog, error) {
+	c := &Catalog{}
+	loaded := map[string]*Catalog{}
+	for _, file := range files {
+		next, err := loadCatalogFile(file, loaded)
+		if err != nil {
+			return nil, err
+		}
+		c.next = append(c.next, next)
+	}
+	return c, nil
+}
+
+// loadCatalogFile loads the catalog file, the catalogs already loaded are
+// reused so that the cyclic nextCatalog entries are permitted.
+func loadCatalogFile(file string, loaded map[string]*Catalog) (*Catalog, error) {
+	file, err := filepath.Abs(file)
+	if err != nil {
+		return nil, err
+	}
+	if c, ok := loaded[file]; ok {
+		return c, nil
+	}
+	c := &Catalog{uris: map[string]string{}, systems: map[string]string{}}
+	loaded[file] = c
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	decoder := xml.NewDecoder(f)
+	decoder.CharsetReader = charset.NewReaderLabel
+	var nextFiles []string
+	bases := []string{filepath.ToSlash(file)}
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("catalog %s: %w", file, err)
+		}
+		if _, ok := token.(xml.EndElement); ok {
+			bases = bases[:len(bases)-1]
+			continue
+		}
+		ele, ok := token.(xml.StartElement)
+		if !ok {
+			continue
+		}
+		base, attrs := bases[len(bases)-1], map[string]string{}
+		for _, attr := range ele.Attr {
+			if attr.Name.Space == "http://www.w3.org/XML/1998/namespace" && attr.Name.Local == "base" {
+				base = resolveCatalogReference(base, attr.Value)
+				continue
+			}
+			attrs[attr.Name.Local] = attr.Value
+		}
+		bases = append(bases, base)
+		switch ele.Name.Local {
+		case "uri":
+			c.uris[attrs["name"]] = resolveCatalogReference(base, attrs["uri"])
+		case "rewriteURI":
+			c.rewriteURIs = append(c.rewriteURIs, catalogRewrite{prefix: attrs["uriStartString"], replace: resolveCatalogReference(base, attrs["rewritePrefix"])})
+		case "system":
+			c.systems[attrs["systemId"]] = resolveCatalogReference(base, attrs["uri"])
+		case "rewriteSystem":
+			c.rewriteSystems = append(c.rewriteSystems, catalogRewrite{prefix: attrs["systemIdStartString"], replace: resolveCatalogReference(base, attrs["rewritePrefix"])})
+		case "nextCatalog":
+			nextFiles = append(nextFiles, catalogFile(resolveCatalogReference(base, attrs["catalog"])))
+		}
+	}
+	for _, nextFile := range nextFiles {
+		next, err := loadCatalogFile(nextFile, loaded)
+		if err != nil {
+			return nil, err
+		}
+		c.next = append(c.next, next)
+	}
+	return c, nil
+}
+
+// ResolveURI returns the replacement of the URI reference by the uri and
+// rewriteURI entries, and reports whether the URI is matched.
+func (c *Catalog) ResolveURI(uri string) (string, bool) {
+	return c.resolve(uri, func(c *Catalog) (map[string]string, []catalogRewrite) {
+		return c.uris, c.rewriteURIs
+	}, map[*Catalog]bool{})
+}
+
+// ResolveSystem returns the replacement of the system identifier by the system
+// and rewriteSystem entries, and reports whether the identifier is matched.
+func (c *Catalog) ResolveSystem(systemID string) (string, bool) {
+	return c.resolve(systemID, func(c *Catalog) (map[string]string, []catalogRewrite) {
+		return c.systems, c.rewriteSystems
+	}, map[*Catalog]bool{})
+}
+
+// resolve looks up the identifier in the exact matched entries, then the
+// rewrite entries with the longest matched prefix, and the next catalogs at
+// last.
+func (c *Catalog) resolve(id string, entries func(c *Catalog) (map[string]string, []catalogRewrite), visited map[*Catalog]bool) (string, bool) {
+	if c == nil || id == "" || visited[c] {
+		return "", false
+	}
+	visited[c] = true
+	exact, rewrites := entries(c)
+	if replace, ok := exact[id]; ok {
+		return replace, true
+	}
+	var matched *catalogRewrite
+	for i, rewrite := range rewrites {
+		if strings.HasPrefix(id, rewrite.prefix) && (matched == nil || len(rewrite.prefix) > len(matched.prefix)) {
+			matched = &rewrites[i]
+		}
+	}
+	if matched != nil {
+		return matched.replace + strings.TrimPrefix(id, matched.prefix), true
+	}
+	for _, next := range c.next {
+		if replace, ok := next.resolve(id, entries, visited); ok {
+			return replace, true
+		}
+	}
+	return "", false
+}
+
+// resolveCatalogReference resolves the URI reference in a catalog against the
+// base URI, which is the slash-separated path of the catalog file or the
+// xml:base of the entry.
+func resolveCatalogReference(base, ref string) string {
+	if ref == "" || isValidURL(ref) || strings.HasPrefix(ref, "file:") || filepath.IsAbs(ref) {
+		return ref
+	}
+	if isValidURL(base) {
+		if b, err := url.Parse(base); err == nil {
+			if r, err := url.Parse(ref); err == nil {
+				return b.ResolveReference(r).String()
+			}
+		}
+		return ref
+	}
+	base = strings.TrimPrefix(base, "file://")
+	if !strings.HasSuffix(base, "/") {
+		base = filepath.ToSlash(filepath.Dir(filepath.FromSlash(base))) + "/"
+	}
+	resolved := filepath.ToSlash(filepath.Join(filepath.FromSlash(base), filepath.FromSlash(ref)))
+	if strings.HasSuffix(ref, "/") {
+		resolved += "/"
+	}
+	return resolved
+}
+
+// catalogFile returns the file path of the resolved URI in a catalog.
+func catalogFile(uri string) string {
+	if strings.HasPrefix(uri, "file:") {
+		if u, err := url.Parse(uri); err == nil {
+			return filepath.FromSlash(u.Path)
+		}
+	}
+	return filepath.FromSlash(uri)
+}
+
+// resolveLocation returns the location of the schema by given schemaLocation
+// and namespace, which is mapped by the catalog if it's matched. The
+// schemaLocation is looked up as a system identifier and a URI, the namespace
+// is looked up as a URI if the schemaLocation is not matched.
+func (opt *Options) resolveLocation(location, namespace string) string {
+	location = opt.absoluteLocation(location)
+	if opt.Catalog == nil {
+		return location
+	}
+	if resolved, ok := opt.Catalog.ResolveSystem(location); ok {
+		return resolved
+	}
+	if resolved, ok := opt.Catalog.ResolveURI(location); ok {
+		return resolved
+	}
+	if resolved, ok := opt.Catalog.ResolveURI(namespace); ok {
+		return resolved
+	}
+	return location
+}
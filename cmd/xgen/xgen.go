@@ -9,25 +9,109 @@
 //
 //    $ xgen [<flag> ...] <XSD file or directory> ...
 //        -i <path> Input file path or directory for the XML schema definition
-//        -o <path> Output file path or directory for the generated code
+//        -o <path> Output file path or directory for the generated code, - for the standard output
 //        -p        Specify the package name
 //        -l        Specify the language of generated code (Go/C/Java/Rust/TypeScript)
+//        -catalog <paths>     Comma-separated XML Catalog files resolving the schemaLocations and namespaces
+//        -prefix-namespaces   Generate MarshalXML methods writing the namespaces with prefixes
+//        -namespace-prefixes <prefix=namespace,...> Prefixes of the namespaces written by the MarshalXML methods
+//        -types <QName=type,...> Types of the generated code the XSD types are mapped to
+//        -strict              Fail on the malformed XML and the unsupported schema elements
+//        -diagnostics <format> Format of the diagnostics of the schemas (text/json)
+//        -j <n>               Number of the schema files parsed and generated at the same time
+//        -check               Check the generated code in the output is up to date, print the diff if it's not
+//        -watch               Regenerate the code whenever the schema files change until interrupted
+//        -exclude <patterns>  Comma-separated glob patterns of the schema files left out of the input directory
+//        -config <path>       Project config file declaring the targets of the generated code
+//        -target <names>      Comma-separated names of the targets in the project config to generate
+//        -fetch               Fetch the remote schemas referenced by the schema files
+//        -cache <path>        Cache directory of the remote schemas
+//        -offline             Use only the cached remote schemas
+//        -allow-host <hosts>  Comma-separated hosts the remote schemas may be fetched from
+//        -max-schema-size <n> Size limit of a remote schema in bytes
+//        -fetch-timeout <d>   Time limit of fetching a remote schema
 //        -h        Output this help and exit
 //        -v        Output version and exit
 //
+//    $ xgen vendor -i <path or URL> -o <path> [<fetch flag> ...]
+//
 // If the path specified by the -i flag is a directory, all files in the
 // directory will be processed as XML schema definition.
 //
+// The remote schemas referenced by the schemaLocation of import, include,
+// redefine and override elements are skipped unless the -fetch flag is given,
+// which fetches them over HTTP(S) and caches them, the -offline flag uses only
+// the cached ones. The vendor command copies the schemas with all schemas they reference into the
+// output directory, and rewrites the schemaLocations to the local copies.
+//
 // The default package name and output directory are "schema" and "xgen_out".
+// The code of a single XML schema definition file is written to the standard
+// output by "-o -".
+//
+// The hashes of the schema files and the config are recorded in the manifest
+// .xgen-manifest.json in the output directory when generating the code of a
+// directory, the schema files which have not changed along with the schemas
+// they reference are skipped by the next runs, remove the manifest to
+// generate the code of all schema files. The -check flag generates the
+// code in memory and compares it with the output instead, the unified diff of
+// the stale files is printed and the exit status is 1 if any file is stale.
+//
+// The -watch flag keeps xgen running after generating the code, the input
+// and the files the schema files depend on are polled every second, and the
+// code of the schema files whose dependencies have changed is regenerated.
+// The diagnostics and the time taken are printed on each run, and the
+// referenced schemas which have not changed are not parsed again.
+//
+// The targets of the generated code can be declared in a project config file
+// instead, which is read from xgen.yaml, xgen.yml or xgen.json in the working
+// directory if neither -config nor -i is given. Each target has its own input,
+// excluded files, language, output and options, and the relative paths are
+// resolved against the directory of the config file. The flags given on the
+// command line override the values of every target. The config is validated
+// against the schema xgen.schema.json, for example:
+//
+//    version: 1
+//    targets:
+//      - name: orders
+//        input: xsd/orders
+//        exclude: [legacy, "*.draft.xsd"]
+//        output: gen/orders
+//        lang: Go
+//        package: orders
+//        types:
+//          xs:decimal: {type: github.com/shopspring/decimal.Decimal}
+//        go:
+//          omitXMLName: true
 //
-// Currently support language is Go.
+// The -types flag and the types of a target map the XSD types to the types of
+// the generated code, which override the built-in types and the user-defined
+// simple types. The XSD types are given by the QNames in the form of
+// {namespace}local, or xs:local for the XSD namespace. A Go type may be
+// qualified by its import path, and a target may give the import of the type
+// in the other languages, which is the class in Java, the module in
+// TypeScript, the use path in Rust and the header in C.
+//
+// The code is generated in Go, C, Java, Rust or TypeScript, by the -l flag or
+// the lang of a target.
 
 package main
 
 import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
+	"io"
 	"os"
+	"os/signal"
+	"runtime/debug"
+	"sort"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/Arthur-Sk/xgen"
 )
@@ -35,12 +119,30 @@ import (
 // Config holds user-defined overrides and filters that are used when
 // generating source code from an XSD document.
 type Config struct {
-	I           string
-	O           string
-	Pkg         string
-	Lang        string
-	Version     string
-	OmitXMLName bool
+	I             string
+	O             string
+	Pkg           string
+	Lang          string
+	Name          string
+	Version       string
+	OmitXMLName   bool
+	Catalog       string
+	PrefixNS      bool
+	NSPrefixes    string
+	Diagnostics   string
+	Strict        bool
+	Exclude       string
+	Types         string
+	TypeMappings  map[xgen.QName]xgen.TypeMapping
+	Jobs          int
+	Check         bool
+	Watch         bool
+	Fetch         bool
+	CacheDir      string
+	Offline       bool
+	AllowHosts    string
+	MaxSchemaSize int64
+	FetchTimeout  time.Duration
 }
 
 // Cfg are the default config for xgen. The default package name and output
@@ -48,6 +150,7 @@ type Config struct {
 var Cfg = Config{
 	Pkg:     "schema",
 	Version: "0.1.0",
+	Jobs:    1,
 }
 
 // SupportLang defines supported language types.
@@ -60,73 +163,578 @@ var SupportLang = map[string]bool{
 }
 
 // parseFlags parse flags of program.
-func parseFlags() *Config {
+func parseFlags() []*Config {
 	iPtr := flag.String("i", "", "Input file path or directory for the XML schema definition")
-	oPtr := flag.String("o", "xgen_out", "Output file path or directory for the generated code")
+	oPtr := flag.String("o", "xgen_out", "Output file path or directory for the generated code, - for the standard output")
 	pkgPtr := flag.String("p", "", "Specify the package name")
 	langPtr := flag.String("l", "", "Specify the language of generated code")
 	omitXMLNamePtr := flag.Bool("omit-xmlname", false, "Omit generating XMLName fields in Go structs")
+	catalogPtr := flag.String("catalog", "", "Comma-separated XML Catalog files")
+	prefixNSPtr := flag.Bool("prefix-namespaces", false, "Generate MarshalXML methods writing the namespaces with prefixes")
+	strictPtr := flag.Bool("strict", false, "Fail on the malformed XML and the unsupported schema elements")
+	diagnosticsPtr := flag.String("diagnostics", "text", "Format of the diagnostics of the schemas (text/json)")
+	jobsPtr := flag.Int("j", 1, "Number of the schema files parsed and generated at the same time")
+	checkPtr := flag.Bool("check", false, "Check the generated code in the output is up to date, print the diff if it's not")
+	watchPtr := flag.Bool("watch", false, "Regenerate the code whenever the schema files change until interrupted")
+	excludePtr := flag.String("exclude", "", "Comma-separated glob patterns of the schema files left out of the input directory")
+	configPtr := flag.String("config", "", "Project config file declaring the targets of the generated code")
+	targetPtr := flag.String("target", "", "Comma-separated names of the targets in the project config to generate")
+	nsPrefixesPtr := flag.String("namespace-prefixes", "", "Comma-separated prefix=namespace pairs of the prefixes written by the MarshalXML methods")
+	typesPtr := flag.String("types", "", "Comma-separated QName=type pairs of the types of the generated code the XSD types are mapped to")
+	flag.BoolVar(&Cfg.Fetch, "fetch", false, "Fetch the remote schemas referenced by the schema files")
+	parseFetchFlags(flag.CommandLine)
 	verPtr := flag.Bool("v", false, "Show version and exit")
 	helpPtr := flag.Bool("h", false, "Show this help and exit")
 	flag.Parse()
 	if *helpPtr {
-		fmt.Printf("xgen version: %s\r\nCopyright (c) 2020 - 2025 Ri Xu https://xuri.me All rights reserved.\r\n\r\nUsage:\r\n$ xgen [<flag> ...] <XSD file or directory> ...\n  -i <path>\tInput file path or directory for the XML schema definition\r\n  -o <path>\tOutput file path or directory for the generated code\r\n  -p     \tSpecify the package name\r\n  -l      \tSpecify the language of generated code (Go/C/Java/Rust/TypeScript)\r\n  -omit-xmlname\tOmit generating XMLName fields in Go structs (default: false)\r\n  -h     \tOutput this help and exit\r\n  -v     \tOutput version and exit\r\n", Cfg.Version)
+		fmt.Printf("xgen version: %s\r\nCopyright (c) 2020 - 2025 Ri Xu https://xuri.me All rights reserved.\r\n\r\nUsage:\r\n$ xgen [<flag> ...] <XSD file or directory> ...\n  -i <path>\tInput file path or directory for the XML schema definition\r\n  -o <path>\tOutput file path or directory for the generated code, - for the standard output\r\n  -p     \tSpecify the package name\r\n  -l      \tSpecify the language of generated code (Go/C/Java/Rust/TypeScript)\r\n  -omit-xmlname\tOmit generating XMLName fields in Go structs (default: false)\r\n  -catalog <paths>\tComma-separated XML Catalog files resolving the schemaLocations and namespaces\r\n  -prefix-namespaces\tGenerate MarshalXML methods writing the namespaces with prefixes (default: false)\r\n  -namespace-prefixes <prefix=namespace,...>\tPrefixes of the namespaces written by the MarshalXML methods (default: the prefixes declared in the schema)\r\n  -types <QName=type,...>\tTypes of the generated code the XSD types are mapped to, by the QNames in the form of {namespace}local or xs:local, a Go type may be qualified by its import path such as xs:decimal=github.com/shopspring/decimal.Decimal\r\n  -strict\tFail on the malformed XML and the unsupported schema elements, which are reported as warnings otherwise (default: false)\r\n  -diagnostics <format>\tFormat of the diagnostics of the schemas (text/json) (default: text)\r\n  -j <n>\tNumber of the schema files parsed and generated at the same time, the output is the same as generating them one at a time (default: 1)\r\n  -check\tCheck the generated code in the output is up to date, print the unified diff of the stale files and exit with status 1 if it's not (default: false)\r\n  -watch\tRegenerate the code of the schema files whose dependencies change until interrupted, the files are polled every second (default: false)\r\n  -exclude <patterns>\tComma-separated glob patterns of the schema files left out of the input directory, matched against the paths relative to it\r\n  -config <path>\tProject config file declaring the targets of the generated code (default: xgen.yaml, xgen.yml or xgen.json in the working directory if -i is not given)\r\n  -target <names>\tComma-separated names of the targets in the project config to generate (default: all targets)\r\n  -fetch\tFetch the remote schemas referenced by the schema files, which are skipped otherwise (default: false)\r\n%s  -h     \tOutput this help and exit\r\n  -v     \tOutput version and exit\r\n\r\n$ xgen vendor -i <path or URL> -o <path> [<fetch flag> ...]\r\n  Copy the schemas with all schemas they reference into the output directory\r\n", Cfg.Version, fetchFlagsUsage)
 		os.Exit(0)
 	}
 	if *verPtr {
 		fmt.Printf("xgen version: %s\r\n", Cfg.Version)
 		os.Exit(0)
 	}
-	if *iPtr == "" {
+	Cfg.I = *iPtr
+	Cfg.Lang = *langPtr
+	if *oPtr != "" {
+		Cfg.O = *oPtr
+	}
+	if *pkgPtr != "" {
+		Cfg.Pkg = *pkgPtr
+	}
+	Cfg.OmitXMLName = *omitXMLNamePtr
+	Cfg.Catalog = *catalogPtr
+	Cfg.PrefixNS = *prefixNSPtr
+	Cfg.NSPrefixes = *nsPrefixesPtr
+	Cfg.Types = *typesPtr
+	if *diagnosticsPtr != "text" && *diagnosticsPtr != "json" {
+		fmt.Println("unsupport diagnostics format", *diagnosticsPtr)
+		os.Exit(1)
+	}
+	Cfg.Diagnostics = *diagnosticsPtr
+	Cfg.Strict = *strictPtr
+	Cfg.Exclude = *excludePtr
+	if *jobsPtr < 1 {
+		fmt.Println("the number of jobs must be at least 1")
+		os.Exit(1)
+	}
+	Cfg.Jobs = *jobsPtr
+	Cfg.Check = *checkPtr
+	Cfg.Watch = *watchPtr
+	if Cfg.Watch && Cfg.Check {
+		fmt.Println("the -watch and -check flags can not be used together")
+		os.Exit(1)
+	}
+	configFile := *configPtr
+	if configFile == "" && Cfg.I == "" {
+		for _, file := range xgen.ProjectFiles {
+			if _, err := os.Stat(file); err == nil {
+				configFile = file
+				break
+			}
+		}
+	}
+	if configFile != "" {
+		return projectConfigs(configFile, splitList(*targetPtr))
+	}
+	if *targetPtr != "" {
+		fmt.Println("must specify the project config file of the targets")
+		os.Exit(1)
+	}
+	if Cfg.I == "" {
 		fmt.Println("must specify input file path or directory for the XML schema definition")
 		os.Exit(1)
 	}
-	Cfg.I = *iPtr
-	if *langPtr == "" {
+	if Cfg.Lang == "" {
 		fmt.Println("must specify the language of generated code (Go/C/Java/Rust/TypeScript)")
 		os.Exit(1)
 	}
-	Cfg.Lang = *langPtr
-	if *oPtr != "" {
-		Cfg.O = *oPtr
-	}
 	if ok := SupportLang[Cfg.Lang]; !ok {
 		fmt.Println("unsupport language", Cfg.Lang)
 		os.Exit(1)
 	}
-	if *pkgPtr != "" {
-		Cfg.Pkg = *pkgPtr
-	}
-	Cfg.OmitXMLName = *omitXMLNamePtr
-	return &Cfg
+	return []*Config{&Cfg}
 }
 
-func main() {
-	cfg := parseFlags()
-	files, err := xgen.GetFileList(cfg.I)
+// projectConfigs returns the configs of the targets in the project config
+// file by given names, or of all the targets if no name is given. The flags
+// given on the command line override the values in the project config.
+func projectConfigs(file string, names []string) []*Config {
+	project, err := xgen.LoadProject(file)
 	if err != nil {
-		fmt.Println(err)
+		var diagnostics xgen.Diagnostics
+		if !errors.As(err, &diagnostics) {
+			fmt.Printf("config error: %s\r\n", err.Error())
+			os.Exit(1)
+		}
+		Cfg.reportDiagnostics(diagnostics)
 		os.Exit(1)
 	}
-	for _, file := range files {
-		if err = xgen.NewParser(&xgen.Options{
-			FilePath:            file,
-			InputDir:            cfg.I,
-			OutputDir:           cfg.O,
-			Lang:                cfg.Lang,
-			Package:             cfg.Pkg,
-			IncludeMap:          make(map[string]bool),
-			LocalNameNSMap:      make(map[string]string),
-			NSSchemaLocationMap: make(map[string]string),
-			ParseFileList:       make(map[string]bool),
-			ParseFileMap:        make(map[string][]interface{}),
-			ProtoTree:           make([]interface{}, 0),
-			RemoteSchema:        make(map[string][]byte),
-			OmitXMLName:         cfg.OmitXMLName,
-		}).Parse(); err != nil {
-			fmt.Printf("process error on %s: %s\r\n", file, err.Error())
+	set := map[string]bool{}
+	flag.Visit(func(f *flag.Flag) { set[f.Name] = true })
+	if fetch := project.Fetch; fetch != nil {
+		if !set["fetch"] {
+			Cfg.Fetch = fetch.Enabled
+		}
+		if !set["cache"] {
+			Cfg.CacheDir = fetch.Cache
+		}
+		if !set["offline"] {
+			Cfg.Offline = fetch.Offline
+		}
+		if !set["allow-host"] {
+			Cfg.AllowHosts = strings.Join(fetch.AllowHosts, ",")
+		}
+		if !set["max-schema-size"] && fetch.MaxSchemaSize > 0 {
+			Cfg.MaxSchemaSize = fetch.MaxSchemaSize
+		}
+		if !set["fetch-timeout"] && fetch.Timeout != "" {
+			if Cfg.FetchTimeout, err = time.ParseDuration(fetch.Timeout); err != nil {
+				fmt.Printf("config error: %s\r\n", err.Error())
+				os.Exit(1)
+			}
+		}
+	}
+	selected := map[string]bool{}
+	for _, name := range names {
+		selected[name] = true
+	}
+	var cfgs []*Config
+	for _, target := range project.Targets {
+		if len(names) > 0 && !selected[target.Name] {
+			continue
+		}
+		delete(selected, target.Name)
+		cfg := Cfg
+		cfg.Name = target.Name
+		if !set["i"] {
+			cfg.I = target.Input
+		}
+		if !set["o"] {
+			cfg.O = target.Output
+		}
+		if !set["l"] {
+			cfg.Lang = target.Lang
+		}
+		if !set["p"] && target.Package != "" {
+			cfg.Pkg = target.Package
+		}
+		if !set["exclude"] {
+			cfg.Exclude = strings.Join(target.Exclude, ",")
+		}
+		if !set["catalog"] {
+			cfg.Catalog = strings.Join(target.Catalog, ",")
+		}
+		if !set["strict"] {
+			cfg.Strict = target.Strict
+		}
+		if !set["j"] && target.Jobs > 0 {
+			cfg.Jobs = target.Jobs
+		}
+		if !set["omit-xmlname"] {
+			cfg.OmitXMLName = target.Go.OmitXMLName
+		}
+		if !set["prefix-namespaces"] {
+			cfg.PrefixNS = target.Go.PrefixNamespaces
+		}
+		if !set["namespace-prefixes"] {
+			pairs := make([]string, 0, len(target.Go.NamespacePrefixes))
+			for prefix, namespace := range target.Go.NamespacePrefixes {
+				pairs = append(pairs, prefix+"="+namespace)
+			}
+			sort.Strings(pairs)
+			cfg.NSPrefixes = strings.Join(pairs, ",")
+		}
+		if !set["types"] {
+			if cfg.TypeMappings, err = target.TypeMappings(); err != nil {
+				fmt.Printf("config error: %s\r\n", err.Error())
+				os.Exit(1)
+			}
+		}
+		if ok := SupportLang[cfg.Lang]; !ok {
+			fmt.Println("unsupport language", cfg.Lang)
 			os.Exit(1)
 		}
+		cfgs = append(cfgs, &cfg)
+	}
+	for _, name := range names {
+		if selected[name] {
+			fmt.Printf("no target %s in %s\r\n", name, file)
+			os.Exit(1)
+		}
+	}
+	return cfgs
+}
+
+// prefix returns the prefix of the messages of the target by given config,
+// which is empty if the target has no name.
+func (cfg *Config) prefix() string {
+	if cfg.Name == "" {
+		return ""
+	}
+	return cfg.Name + ": "
+}
+
+// typeMappings returns the type mappings by the -types flag, or by the
+// target in the project config if the flag is not given.
+func (cfg *Config) typeMappings() map[xgen.QName]xgen.TypeMapping {
+	if cfg.Types == "" {
+		return cfg.TypeMappings
+	}
+	mappings := make(map[xgen.QName]xgen.TypeMapping)
+	for _, pair := range splitList(cfg.Types) {
+		i := strings.LastIndex(pair, "=")
+		if i < 0 || pair[i+1:] == "" {
+			fmt.Printf("invalid type mapping %q, expected QName=type\r\n", pair)
+			os.Exit(1)
+		}
+		qname, err := xgen.ParseQName(pair[:i])
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		mappings[qname] = xgen.ParseTypeMapping(cfg.Lang, pair[i+1:])
+	}
+	return mappings
+}
+
+// splitList returns the items of the comma-separated list.
+func splitList(list string) (items []string) {
+	for _, item := range strings.Split(list, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return
+}
+
+// fetchFlagsUsage is the help of the flags of fetching remote schemas.
+const fetchFlagsUsage = "  -cache <path>\tCache directory of the remote schemas (default: xgen in the user cache directory)\r\n" +
+	"  -offline\tUse only the cached remote schemas (default: false)\r\n" +
+	"  -allow-host <hosts>\tComma-separated hosts the remote schemas may be fetched from, .example.org matches the subdomains (default: all hosts)\r\n" +
+	"  -max-schema-size <n>\tSize limit of a remote schema in bytes (default: 10485760)\r\n" +
+	"  -fetch-timeout <d>\tTime limit of fetching a remote schema (default: 30s)\r\n"
+
+// parseFetchFlags defines the flags of fetching remote schemas in the flag
+// set, which are stored in the config.
+func parseFetchFlags(flags *flag.FlagSet) {
+	flags.StringVar(&Cfg.CacheDir, "cache", "", "Cache directory of the remote schemas")
+	flags.BoolVar(&Cfg.Offline, "offline", false, "Use only the cached remote schemas")
+	flags.StringVar(&Cfg.AllowHosts, "allow-host", "", "Comma-separated hosts the remote schemas may be fetched from")
+	flags.Int64Var(&Cfg.MaxSchemaSize, "max-schema-size", xgen.DefaultMaxSchemaSize, "Size limit of a remote schema in bytes")
+	flags.DurationVar(&Cfg.FetchTimeout, "fetch-timeout", xgen.DefaultFetchTimeout, "Time limit of fetching a remote schema")
+}
+
+// fetcher returns the fetcher of remote schemas by given config, or nil if
+// neither fetching nor the offline mode is enabled.
+func (cfg *Config) fetcher() *xgen.SchemaFetcher {
+	if !cfg.Fetch && !cfg.Offline {
+		return nil
+	}
+	fetcher := &xgen.SchemaFetcher{
+		CacheDir: cfg.CacheDir,
+		Offline:  cfg.Offline,
+		MaxSize:  cfg.MaxSchemaSize,
+		Timeout:  cfg.FetchTimeout,
+	}
+	for _, host := range strings.Split(cfg.AllowHosts, ",") {
+		if host = strings.TrimSpace(host); host != "" {
+			fetcher.AllowedHosts = append(fetcher.AllowedHosts, host)
+		}
+	}
+	return fetcher
+}
+
+// configHash returns the hash of the config the code is generated by, along
+// with the catalog files and the build of xgen, so that the code generated by
+// another config or another version of xgen is regenerated as a whole.
+func (cfg *Config) configHash() string {
+	var types []string
+	for qname, mapping := range cfg.typeMappings() {
+		types = append(types, fmt.Sprintf("{%s}%s=%s %s", qname.Space, qname.Local, mapping.Type, mapping.Import))
+	}
+	sort.Strings(types)
+	h := sha256.New()
+	json.NewEncoder(h).Encode([]interface{}{cfg.Version, cfg.Lang, cfg.Pkg, cfg.OmitXMLName, cfg.PrefixNS, cfg.NSPrefixes, cfg.Strict, cfg.Catalog, types, buildVersion()})
+	for _, file := range strings.Split(cfg.Catalog, ",") {
+		if f, err := os.Open(file); err == nil {
+			io.Copy(h, f)
+			f.Close()
+		}
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// buildVersion returns the module version and the VCS revision xgen is built
+// from, the revision is marked as modified if it's built from a dirty tree.
+func buildVersion() []string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return nil
+	}
+	version := []string{info.Main.Version, info.Main.Sum}
+	for _, setting := range info.Settings {
+		if setting.Key == "vcs.revision" || setting.Key == "vcs.modified" {
+			version = append(version, setting.Key+"="+setting.Value)
+		}
+	}
+	return version
+}
+
+// diagnostics returns the diagnostics of the error returned by loading the
+// schemas.
+func (cfg *Config) diagnostics(err error) xgen.Diagnostics {
+	var diagnostics xgen.Diagnostics
+	if !errors.As(err, &diagnostics) {
+		diagnostics = xgen.Diagnostics{{Severity: xgen.SeverityError, Code: xgen.CodeInvalidSchema, File: cfg.I, Message: err.Error()}}
+	}
+	return diagnostics
+}
+
+// reportDiagnostics prints the diagnostics in the format by given config. The
+// JSON format is an array of the diagnostics, which is written to the standard
+// error if the generated code or the diff is written to the standard output. In the text
+// format, the errors are printed one per line, and the warnings are grouped
+// into a report per schema file on the standard error.
+func (cfg *Config) reportDiagnostics(diagnostics xgen.Diagnostics) {
+	if cfg.Diagnostics == "json" {
+		if diagnostics == nil {
+			diagnostics = xgen.Diagnostics{}
+		}
+		out := os.Stdout
+		if cfg.O == "-" || cfg.Check {
+			out = os.Stderr
+		}
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+		encoder.Encode(diagnostics)
+		return
+	}
+	var files []string
+	warnings := map[string][]xgen.Diagnostic{}
+	for _, diagnostic := range diagnostics {
+		if diagnostic.Severity == xgen.SeverityError {
+			fmt.Printf("process error on %s\r\n", diagnostic.Error())
+			continue
+		}
+		if _, ok := warnings[diagnostic.File]; !ok {
+			files = append(files, diagnostic.File)
+		}
+		warnings[diagnostic.File] = append(warnings[diagnostic.File], diagnostic)
+	}
+	for _, file := range files {
+		fmt.Fprintf(os.Stderr, "%d warnings in %s:\r\n", len(warnings[file]), file)
+		for _, warning := range warnings[file] {
+			fmt.Fprintf(os.Stderr, "  %d:%d %s: %s\r\n", warning.Line, warning.Column, warning.Path, warning.Message)
+		}
+	}
+}
+
+// reportGenerateError prints the error of generating the code of the schema
+// set, the diagnostics of which are reported with the warnings of the set.
+func (cfg *Config) reportGenerateError(set *xgen.SchemaSet, err error) {
+	var diagnostics xgen.Diagnostics
+	if !errors.As(err, &diagnostics) {
+		fmt.Fprintf(os.Stderr, "generate error: %s\r\n", err.Error())
+	}
+	cfg.reportDiagnostics(append(diagnostics, set.Diagnostics...))
+}
+
+// vendor runs the vendor command by given arguments.
+func vendor(args []string) {
+	flags := flag.NewFlagSet("vendor", flag.ExitOnError)
+	iPtr := flags.String("i", "", "Input file path, directory or URL of the XML schema definition")
+	oPtr := flags.String("o", "xsd_vendor", "Output directory for the copied schemas")
+	parseFetchFlags(flags)
+	flags.Parse(args)
+	Cfg.Fetch = true
+	if *iPtr == "" {
+		fmt.Println("must specify input file path, directory or URL of the XML schema definition")
+		os.Exit(1)
+	}
+	if err := xgen.Vendor(*iPtr, *oPtr, Cfg.fetcher()); err != nil {
+		fmt.Printf("vendor error on %s: %s\r\n", *iPtr, err.Error())
+		os.Exit(1)
 	}
 	fmt.Println("done")
 }
+
+// check generates the code of the schema set in memory, and compares it with
+// the files in the output. The unified diff of the stale files is printed, and
+// the exit status is 1 if any file is stale.
+func check(ctx context.Context, cfg *Config, set *xgen.SchemaSet) {
+	files := xgen.MapSink{}
+	if err := xgen.Generate(ctx, set, files); err != nil {
+		cfg.reportGenerateError(set, err)
+		os.Exit(1)
+	}
+	diff, err := xgen.DirSink(cfg.O).Diff(files)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "check error: %s\r\n", err.Error())
+		os.Exit(1)
+	}
+	cfg.reportDiagnostics(set.Diagnostics)
+	if diff != "" {
+		fmt.Print(diff)
+		fmt.Fprintf(os.Stderr, "the generated code in %s is out of date\r\n", cfg.O)
+		os.Exit(1)
+	}
+}
+
+// reportMu serializes the reports of the targets watched at the same time.
+var reportMu sync.Mutex
+
+// watch regenerates the code of the schema files whose dependencies change
+// until interrupted, the diagnostics and the time taken are printed on each
+// run, and the manifest is written after each successful run.
+func watch(ctx context.Context, cfg *Config, opt *xgen.Options) {
+	out := os.Stdout
+	if cfg.Diagnostics == "json" {
+		out = os.Stderr
+	}
+	watcher := &xgen.Watcher{Options: opt, Sink: xgen.DirSink(cfg.O), Report: func(run xgen.WatchRun) {
+		reportMu.Lock()
+		defer reportMu.Unlock()
+		var diagnostics xgen.Diagnostics
+		if run.Err != nil && !errors.As(run.Err, &diagnostics) {
+			fmt.Fprintf(os.Stderr, "generate error: %s\r\n", run.Err.Error())
+		}
+		cfg.reportDiagnostics(append(diagnostics, run.Diagnostics...))
+		if run.Err != nil {
+			fmt.Fprintf(out, "%sfailed in %s\r\n", cfg.prefix(), run.Duration.Round(time.Millisecond))
+			return
+		}
+		if opt.Manifest != nil {
+			if err := opt.Manifest.Write(cfg.O); err != nil {
+				fmt.Fprintf(os.Stderr, "manifest error: %s\r\n", err.Error())
+			}
+		}
+		fmt.Fprintf(out, "%sgenerated %d schemas in %s\r\n", cfg.prefix(), len(run.Schemas), run.Duration.Round(time.Millisecond))
+	}}
+	if err := watcher.Run(ctx); err != nil {
+		fmt.Fprintf(os.Stderr, "watch error: %s\r\n", err.Error())
+		os.Exit(1)
+	}
+}
+
+// options returns the options of loading the schemas by given config, the
+// manifest in the output is read if the input is a directory.
+func (cfg *Config) options() *xgen.Options {
+	fi, statErr := os.Stat(cfg.I)
+	if cfg.O == "-" {
+		if cfg.Check {
+			fmt.Fprintln(os.Stderr, "must specify the output file path or directory of the generated code to check")
+			os.Exit(1)
+		}
+		if cfg.Watch {
+			fmt.Fprintln(os.Stderr, "must specify the output file path or directory of the generated code to watch")
+			os.Exit(1)
+		}
+		if statErr == nil && fi.IsDir() {
+			fmt.Fprintln(os.Stderr, "must specify a single XML schema definition file to write the generated code to the standard output")
+			os.Exit(1)
+		}
+	}
+	fetcher := cfg.fetcher()
+	var catalog *xgen.Catalog
+	if cfg.Catalog != "" {
+		var err error
+		if catalog, err = xgen.LoadCatalog(strings.Split(cfg.Catalog, ",")...); err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+	}
+	nsPrefixes := make(map[string]string)
+	for _, pair := range strings.Split(cfg.NSPrefixes, ",") {
+		if pair = strings.TrimSpace(pair); pair == "" {
+			continue
+		}
+		prefix, namespace, ok := strings.Cut(pair, "=")
+		if !ok || prefix == "" || namespace == "" {
+			fmt.Printf("invalid namespace prefix %q, expected prefix=namespace\r\n", pair)
+			os.Exit(1)
+		}
+		nsPrefixes[namespace] = prefix
+	}
+	opt := &xgen.Options{
+		InputDir:          cfg.I,
+		Lang:              cfg.Lang,
+		Package:           cfg.Pkg,
+		Fetcher:           fetcher,
+		Catalog:           catalog,
+		Strict:            cfg.Strict,
+		Jobs:              cfg.Jobs,
+		Exclude:           splitList(cfg.Exclude),
+		OmitXMLName:       cfg.OmitXMLName,
+		PrefixNamespaces:  cfg.PrefixNS,
+		NamespacePrefixes: nsPrefixes,
+		TypeMappings:      cfg.typeMappings(),
+	}
+	if !cfg.Check && cfg.O != "-" && statErr == nil && fi.IsDir() {
+		var err error
+		if opt.Manifest, err = xgen.ReadManifest(cfg.O, cfg.configHash()); err != nil {
+			fmt.Fprintf(os.Stderr, "manifest error: %s\r\n", err.Error())
+			os.Exit(1)
+		}
+	}
+	return opt
+}
+
+// run generates the code by given config.
+func run(ctx context.Context, cfg *Config) {
+	opt := cfg.options()
+	set, err := xgen.Load(ctx, opt)
+	if err != nil {
+		cfg.reportDiagnostics(cfg.diagnostics(err))
+		os.Exit(1)
+	}
+	if cfg.Check {
+		check(ctx, cfg, set)
+		return
+	}
+	var sink xgen.Sink = xgen.DirSink(cfg.O)
+	if cfg.O == "-" {
+		sink = &xgen.WriterSink{W: os.Stdout}
+	}
+	if err = xgen.Generate(ctx, set, sink); err != nil {
+		cfg.reportGenerateError(set, err)
+		os.Exit(1)
+	}
+	if opt.Manifest != nil {
+		if err = opt.Manifest.Write(cfg.O); err != nil {
+			fmt.Fprintf(os.Stderr, "manifest error: %s\r\n", err.Error())
+			os.Exit(1)
+		}
+	}
+	cfg.reportDiagnostics(set.Diagnostics)
+	if cfg.O != "-" && cfg.Diagnostics != "json" {
+		fmt.Println(cfg.prefix() + "done")
+	}
+}
+
+func main() {
+	if len(os.Args) > 1 && os.Args[1] == "vendor" {
+		vendor(os.Args[2:])
+		return
+	}
+	cfgs := parseFlags()
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	if !Cfg.Watch {
+		for _, cfg := range cfgs {
+			run(ctx, cfg)
+		}
+		return
+	}
+	// The targets are watched at the same time until interrupted
+	var wg sync.WaitGroup
+	for _, cfg := range cfgs {
+		opt := cfg.options()
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			watch(ctx, cfg, opt)
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,437 @@
+// Copyright 2026 The xgen Authors. All rights reserved. Use of this source
+// code is governed by a BSD-style license that can be found in the LICENSE
+// file.
+//
+// xgen is a tool to automatically compiles XML schema files into the
+// multi-language type or class declarations code.
+
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestMain runs the command instead of the tests when the test binary is
+// executed by runXgen, so the exit status and the output of the command can
+// be tested as they are seen by the users.
+func TestMain(m *testing.M) {
+	if os.Getenv("XGEN_TEST_MAIN") == "1" {
+		main()
+		os.Exit(0)
+	}
+	os.Exit(m.Run())
+}
+
+// command returns the command running xgen by given arguments in the
+// directory.
+func command(t *testing.T, dir string, args ...string) *exec.Cmd {
+	t.Helper()
+	cmd := exec.Command(os.Args[0], args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "XGEN_TEST_MAIN=1")
+	return cmd
+}
+
+// runXgen runs the command by given arguments in the directory, and returns
+// its standard output, standard error and exit status.
+func runXgen(t *testing.T, dir string, args ...string) (string, string, int) {
+	t.Helper()
+	var stdout, stderr bytes.Buffer
+	cmd := command(t, dir, args...)
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	err := cmd.Run()
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return stdout.String(), stderr.String(), exitErr.ExitCode()
+	}
+	require.NoError(t, err)
+	return stdout.String(), stderr.String(), 0
+}
+
+// writeFiles writes the files by given paths relative to the directory.
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, data := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(data), 0o644))
+	}
+}
+
+const (
+	orderSchema = `<xs:schema xmlns:xs="http://www.w3.org/2001/XMLSchema">
+	<xs:include schemaLocation="common.xsd"/>
+	<xs:element name="order">
+		<xs:complexType>
+			<xs:sequence>
+				<xs:element name="code" type="Code"/>
+			</xs:sequence>
+		</xs:complexType>
+	</xs:element>
+</xs:schema>`
+	commonSchema = `<xs:schema xmlns:xs="http://www.w3.org/2001/XMLSchema">
+	<xs:simpleType name="Code">
+		<xs:restriction base="xs:string"/>
+	</xs:simpleType>
+</xs:schema>`
+)
+
+func TestStdout(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{"xsd/order.xsd": orderSchema, "xsd/common.xsd": commonSchema})
+	for name, test := range map[string]struct {
+		args           []string
+		code           int
+		stdout, stderr string
+	}{
+		"file": {
+			args:   []string{"-i", "xsd/order.xsd", "-o", "-", "-l", "Go"},
+			stdout: "package schema\n",
+		},
+		"directory": {
+			args:   []string{"-i", "xsd", "-o", "-", "-l", "Go"},
+			code:   1,
+			stderr: "must specify a single XML schema definition file to write the generated code to the standard output",
+		},
+		"check": {
+			args:   []string{"-i", "xsd/order.xsd", "-o", "-", "-l", "Go", "-check"},
+			code:   1,
+			stderr: "must specify the output file path or directory of the generated code to check",
+		},
+	} {
+		stdout, stderr, code := runXgen(t, dir, test.args...)
+		assert.Equal(t, test.code, code, name)
+		assert.Contains(t, stdout, test.stdout, name)
+		assert.Contains(t, stderr, test.stderr, name)
+		if test.code == 0 {
+			assert.Contains(t, stdout, "type Order struct", name)
+			assert.NotContains(t, stdout, "done", name)
+			assert.Empty(t, stderr, name)
+		}
+	}
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 1, "nothing is written but the standard output")
+}
+
+func TestCheck(t *testing.T) {
+	for name, test := range map[string]struct {
+		change         func(t *testing.T, dir string)
+		code           int
+		stdout, stderr string
+	}{
+		"up to date": {
+			change: func(t *testing.T, dir string) {},
+		},
+		"edited": {
+			change: func(t *testing.T, dir string) {
+				writeFiles(t, dir, map[string]string{"out/common.xsd.go": "// Code generated by xgen. DO NOT EDIT.\n\npackage schema\n"})
+			},
+			code:   1,
+			stdout: "--- out/common.xsd.go\n+++ out/common.xsd.go\n@@ -1,3 +1,6 @@\n // Code generated by xgen. DO NOT EDIT.\n \n package schema\n+\n+// Code ...\n+type Code string\n",
+			stderr: "the generated code in out is out of date",
+		},
+		"deleted": {
+			change: func(t *testing.T, dir string) {
+				require.NoError(t, os.Remove(filepath.Join(dir, "out", "order.xsd.go")))
+			},
+			code:   1,
+			stdout: "--- /dev/null\n+++ out/order.xsd.go\n@@ -0,0 +1,13 @@\n+// Code generated by xgen. DO NOT EDIT.\n",
+			stderr: "the generated code in out is out of date",
+		},
+		"schema changed": {
+			change: func(t *testing.T, dir string) {
+				writeFiles(t, dir, map[string]string{"xsd/common.xsd": strings.Replace(commonSchema, `"Code"`, `"Currency"`, 1)})
+			},
+			code:   1,
+			stdout: "-// Code ...\n-type Code string\n+// Currency ...\n+type Currency string\n",
+			stderr: "the generated code in out is out of date",
+		},
+	} {
+		dir := t.TempDir()
+		writeFiles(t, dir, map[string]string{"xsd/order.xsd": orderSchema, "xsd/common.xsd": commonSchema})
+		_, _, code := runXgen(t, dir, "-i", "xsd", "-o", "out", "-l", "Go")
+		require.Equal(t, 0, code, name)
+		test.change(t, dir)
+		stdout, stderr, code := runXgen(t, dir, "-i", "xsd", "-o", "out", "-l", "Go", "-check")
+		assert.Equal(t, test.code, code, name)
+		assert.Contains(t, stdout, test.stdout, name)
+		assert.Contains(t, stderr, test.stderr, name)
+		if test.code == 0 {
+			assert.Empty(t, stdout, name)
+			assert.Empty(t, stderr, name)
+		}
+	}
+}
+
+func TestManifest(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{"xsd/order.xsd": orderSchema, "xsd/common.xsd": commonSchema})
+	// generated returns the generated files rewritten since the previous run
+	// of the command.
+	past := time.Now().Add(-time.Hour).Truncate(time.Second)
+	generated := func(args ...string) []string {
+		_, stderr, code := runXgen(t, dir, append([]string{"-i", "xsd", "-o", "out", "-l", "Go"}, args...)...)
+		require.Equal(t, 0, code, stderr)
+		var files []string
+		for _, name := range []string{"order.xsd.go", "common.xsd.go"} {
+			path := filepath.Join(dir, "out", name)
+			info, err := os.Stat(path)
+			require.NoError(t, err, name)
+			if !info.ModTime().Equal(past) {
+				files = append(files, name)
+			}
+			require.NoError(t, os.Chtimes(path, past, past))
+		}
+		return files
+	}
+	for _, step := range []struct {
+		name     string
+		change   func()
+		args     []string
+		expected []string
+	}{
+		{name: "first run", change: func() {}, expected: []string{"order.xsd.go", "common.xsd.go"}},
+		{name: "unchanged", change: func() {}},
+		{
+			name: "included schema changed",
+			change: func() {
+				writeFiles(t, dir, map[string]string{"xsd/common.xsd": strings.Replace(commonSchema, "xs:string", "xs:token", 1)})
+			},
+			expected: []string{"order.xsd.go", "common.xsd.go"},
+		},
+		{
+			name: "output deleted",
+			change: func() {
+				require.NoError(t, os.Remove(filepath.Join(dir, "out", "order.xsd.go")))
+			},
+			expected: []string{"order.xsd.go"},
+		},
+		{
+			name: "output edited",
+			change: func() {
+				writeFiles(t, dir, map[string]string{"out/common.xsd.go": "package schema\n"})
+				require.NoError(t, os.Chtimes(filepath.Join(dir, "out", "common.xsd.go"), past, past))
+			},
+			expected: []string{"common.xsd.go"},
+		},
+		{name: "options changed", change: func() {}, args: []string{"-p", "orders"}, expected: []string{"order.xsd.go", "common.xsd.go"}},
+	} {
+		step.change()
+		assert.Equal(t, step.expected, generated(step.args...), step.name)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "out", "common.xsd.go"))
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "package orders\n\n// Code ...\ntype Code string\n")
+}
+
+func TestProjectConfig(t *testing.T) {
+	targets := `targets:
+  - name: orders
+    input: xsd/order.xsd
+    output: gen/orders.go
+    lang: Go
+  - name: common
+    input: xsd/common.xsd
+    output: gen/common.ts
+    lang: TypeScript
+`
+	for name, test := range map[string]struct {
+		config         string
+		args           []string
+		code           int
+		stdout, stderr string
+		generated      []string
+	}{
+		"all targets": {
+			config:    "version: 1\n" + targets,
+			stdout:    "orders: done\ncommon: done\n",
+			generated: []string{"gen/orders.go", "gen/common.ts"},
+		},
+		"selected target": {
+			config:    "version: 1\n" + targets,
+			args:      []string{"-target", "common"},
+			stdout:    "common: done\n",
+			generated: []string{"gen/common.ts"},
+		},
+		"flags override the targets": {
+			config:    "version: 1\n" + targets,
+			args:      []string{"-target", "orders", "-o", "gen/schema.go"},
+			stdout:    "orders: done\n",
+			generated: []string{"gen/schema.go"},
+		},
+		"unknown target": {
+			config: "version: 1\n" + targets,
+			args:   []string{"-target", "orders,invoices"},
+			code:   1,
+			stdout: "no target invoices in xgen.yaml",
+		},
+		"unsupported version": {
+			config: "version: 2\n" + targets,
+			code:   1,
+			stdout: "process error on xgen.yaml:1:10: error: must be 1",
+		},
+		"missing version": {
+			config: targets,
+			code:   1,
+			stdout: `process error on xgen.yaml:1:1: error: missing property "version"`,
+		},
+		"unsupported language": {
+			config: "version: 1\n" + strings.Replace(targets, "TypeScript", "Kotlin", 1),
+			code:   1,
+			stdout: "process error on xgen.yaml:10:11: error: must be one of Go, C, Java, Rust, TypeScript",
+		},
+	} {
+		dir := t.TempDir()
+		writeFiles(t, dir, map[string]string{"xsd/order.xsd": orderSchema, "xsd/common.xsd": commonSchema, "xgen.yaml": test.config})
+		stdout, stderr, code := runXgen(t, dir, test.args...)
+		assert.Equal(t, test.code, code, name)
+		assert.Contains(t, stdout, test.stdout, name)
+		assert.Contains(t, stderr, test.stderr, name)
+		var generated []string
+		require.NoError(t, filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+			if err == nil && !entry.IsDir() && filepath.Ext(path) != ".xsd" && entry.Name() != "xgen.yaml" {
+				rel, _ := filepath.Rel(dir, path)
+				generated = append(generated, filepath.ToSlash(rel))
+			}
+			return err
+		}), name)
+		assert.ElementsMatch(t, test.generated, generated, name)
+	}
+}
+
+func TestJobs(t *testing.T) {
+	input, err := filepath.Abs(filepath.Join("..", "..", "test", "xsd"))
+	require.NoError(t, err)
+	// files returns the contents of the files in the directory by their
+	// relative paths.
+	files := func(dir string) map[string]string {
+		files := map[string]string{}
+		require.NoError(t, filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+			if err != nil || entry.IsDir() {
+				return err
+			}
+			data, err := os.ReadFile(path)
+			rel, _ := filepath.Rel(dir, path)
+			files[filepath.ToSlash(rel)] = string(data)
+			return err
+		}))
+		return files
+	}
+	for lang := range SupportLang {
+		dir := t.TempDir()
+		var expected map[string]string
+		var expectedStdout, expectedStderr string
+		for _, jobs := range []string{"1", "4", "16"} {
+			stdout, stderr, code := runXgen(t, dir, "-i", input, "-o", jobs, "-l", lang, "-j", jobs)
+			require.Equal(t, 0, code, "%s -j %s: %s", lang, jobs, stderr)
+			generated := files(filepath.Join(dir, jobs))
+			if expected == nil {
+				expected, expectedStdout, expectedStderr = generated, stdout, stderr
+				assert.NotEmpty(t, generated, lang)
+				continue
+			}
+			assert.Equal(t, expected, generated, "%s -j %s", lang, jobs)
+			assert.Equal(t, expectedStdout, stdout, "%s -j %s", lang, jobs)
+			assert.Equal(t, expectedStderr, stderr, "%s -j %s", lang, jobs)
+		}
+	}
+
+	stdout, _, code := runXgen(t, t.TempDir(), "-i", input, "-o", "out", "-l", "Go", "-j", "0")
+	assert.Equal(t, 1, code)
+	assert.Contains(t, stdout, "the number of jobs must be at least 1")
+}
+
+func TestWatch(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("interrupting the command is not supported on Windows")
+	}
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{"xsd/order.xsd": orderSchema, "xsd/common.xsd": commonSchema})
+	cmd := command(t, dir, "-i", "xsd", "-o", "out", "-l", "Go", "-watch")
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	pipe, err := cmd.StdoutPipe()
+	require.NoError(t, err)
+	require.NoError(t, cmd.Start())
+	lines := make(chan string)
+	go func() {
+		scanner := bufio.NewScanner(pipe)
+		for scanner.Scan() {
+			lines <- scanner.Text()
+		}
+		close(lines)
+	}()
+	// next returns the next line written by the command.
+	next := func() string {
+		select {
+		case line := <-lines:
+			return line
+		case <-time.After(10 * time.Second):
+			_ = cmd.Process.Kill()
+			require.FailNow(t, "no output of the command", stderr.String())
+			return ""
+		}
+	}
+	generated := regexp.MustCompile(`^generated (\d+) schemas in \S+$`)
+	for _, step := range []struct {
+		name    string
+		files   map[string]string
+		schemas string
+		output  map[string]string
+	}{
+		{
+			name:    "first run",
+			schemas: "2",
+			output:  map[string]string{"common.xsd.go": "type Code string", "order.xsd.go": "Code    string   `xml:\"code\"`"},
+		},
+		{
+			name:    "included schema changed",
+			files:   map[string]string{"xsd/common.xsd": strings.Replace(commonSchema, `"Code"`, `"Currency"`, 1)},
+			schemas: "2",
+			output:  map[string]string{"common.xsd.go": "type Currency string", "order.xsd.go": "Code    *Code    `xml:\"code\"`"},
+		},
+		{
+			name:    "including schema changed",
+			files:   map[string]string{"xsd/order.xsd": strings.Replace(orderSchema, `name="code" type="Code"`, `name="currency" type="Currency"`, 1)},
+			schemas: "1",
+			output:  map[string]string{"order.xsd.go": "Currency string   `xml:\"currency\"`"},
+		},
+	} {
+		writeFiles(t, dir, step.files)
+		line := next()
+		match := generated.FindStringSubmatch(strings.TrimSpace(line))
+		require.NotNil(t, match, "%s: %s", step.name, line)
+		assert.Equal(t, step.schemas, match[1], step.name)
+		for name, expected := range step.output {
+			data, err := os.ReadFile(filepath.Join(dir, "out", name))
+			require.NoError(t, err, step.name)
+			assert.Contains(t, string(data), expected, step.name)
+		}
+	}
+
+	require.NoError(t, cmd.Process.Signal(os.Interrupt))
+	for line := range lines {
+		assert.Fail(t, "unexpected output after the interrupt", line)
+	}
+	require.NoError(t, cmd.Wait())
+	assert.Empty(t, stderr.String())
+
+	stdout, _, code := runXgen(t, dir, "-i", "xsd", "-o", "out", "-l", "Go", "-watch", "-check")
+	assert.Equal(t, 1, code)
+	assert.Contains(t, stdout, "the -watch and -check flags can not be used together")
+}
@@ -0,0 +1,268 @@
+// Copyright 2026 The xgen Authors. All rights reserved. Use of this source
+// code is governed by a BSD-style license that can be found in the LICENSE
+// file.
+//
+// Package xgen written in pure Go providing a set of functions that allow you
+// to parse XSD (XML schema files). This library needs Go version 1.10 or
+// later.
+
+package xgen
+
+import (
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Component is a top-level schema component in the proto tree: *SimpleType,
+// *ComplexType, *Element, *Attribute, *Group or *AttributeGroup. The set of
+// components is sealed, a Visitor handles each of them.
+type Component interface {
+	// Accept calls the method of the visitor for the component.
+	Accept(v Visitor)
+	component()
+}
+
+// Visitor visits the schema components by their types. Implementing all
+// methods lets the compiler check that no component is left unhandled.
+type Visitor interface {
+	VisitSimpleType(c *SimpleType)
+	VisitComplexType(c *ComplexType)
+	VisitElement(c *Element)
+	VisitAttribute(c *Attribute)
+	VisitGroup(c *Group)
+	VisitAttributeGroup(c *AttributeGroup)
+}
+
+// Accept calls the VisitSimpleType method of the visitor.
+func (c *SimpleType) Accept(v Visitor) { v.VisitSimpleType(c) }
+
+// Accept calls the VisitComplexType method of the visitor.
+func (c *ComplexType) Accept(v Visitor) { v.VisitComplexType(c) }
+
+// Accept calls the VisitElement method of the visitor.
+func (c *Element) Accept(v Visitor) { v.VisitElement(c) }
+
+// Accept calls the VisitAttribute method of the visitor.
+func (c *Attribute) Accept(v Visitor) { v.VisitAttribute(c) }
+
+// Accept calls the VisitGroup method of the visitor.
+func (c *Group) Accept(v Visitor) { v.VisitGroup(c) }
+
+// Accept calls the VisitAttributeGroup method of the visitor.
+func (c *AttributeGroup) Accept(v Visitor) { v.VisitAttributeGroup(c) }
+
+func (*SimpleType) component()     {}
+func (*ComplexType) component()    {}
+func (*Element) component()        {}
+func (*Attribute) component()      {}
+func (*Group) component()          {}
+func (*AttributeGroup) component() {}
+
+// QName is the qualified name of a schema component, which consists of the
+// namespace and the local name.
+type QName struct {
+	Space string
+	Local string
+}
+
+// Schema holds the top-level components parsed from a schema document, in
+// the order they are declared. The components of the included, redefined and
+// overridden schema documents are held by the including one.
+type Schema struct {
+	Location        string
+	TargetNamespace string
+	Components      []Component
+
+	output   string            // slash-separated path of the code relative to the output
+	prefixes map[string]string // namespace -> prefix written by the MarshalXML methods
+}
+
+// SchemaSet holds the schemas parsed together, the schema documents and the
+// ones they import, and indexes their components by QName. The types, the
+// elements, the attributes, the groups and the attribute groups are in the
+// distinct symbol spaces, as a type and an element may share a name.
+type SchemaSet struct {
+	// Schemas are the schemas the code is generated for. The index holds the
+	// components of the schemas left out as up to date by the manifest too.
+	Schemas []*Schema
+	// Diagnostics holds the warnings found when loading the schemas.
+	Diagnostics Diagnostics
+	// Lang is the language the built-in types of the components are
+	// resolved in when parsing, which the code is generated in. It's the Lang
+	// of the options the set is loaded by.
+	Lang string
+
+	config          *Options // the options the schemas are loaded by
+	types           map[QName]Component
+	elements        map[QName]*Element
+	attributes      map[QName]*Attribute
+	groups          map[QName]*Group
+	attributeGroups map[QName]*AttributeGroup
+}
+
+// NewSchemaSet creates the schema set by given proto trees of the schema
+// documents by their locations, such as the ParseFileMap of the parser
+// options. The schemas are ordered by their locations, and the first of the
+// components sharing a QName is indexed.
+func NewSchemaSet(trees map[string][]Component) *SchemaSet {
+	set := &SchemaSet{
+		types:           map[QName]Component{},
+		elements:        map[QName]*Element{},
+		attributes:      map[QName]*Attribute{},
+		groups:          map[QName]*Group{},
+		attributeGroups: map[QName]*AttributeGroup{},
+	}
+	locations := make([]string, 0, len(trees))
+	for location := range trees {
+		locations = append(locations, location)
+	}
+	sort.Strings(locations)
+	for _, location := range locations {
+		schema := &Schema{Location: location, Components: trees[location], output: strings.TrimPrefix(filepath.ToSlash(location), "/")}
+		for _, c := range schema.Components {
+			if c == nil {
+				continue
+			}
+			if schema.TargetNamespace == "" {
+				schema.TargetNamespace = componentNamespace(c)
+			}
+			set.index(c)
+		}
+		set.Schemas = append(set.Schemas, schema)
+	}
+	set.resolveSubstitutionGroups()
+	return set
+}
+
+// resolveSubstitutionGroups records the members of the substitution groups
+// on their head elements, the members may be declared in any schema of the
+// set.
+func (set *SchemaSet) resolveSubstitutionGroups() {
+	groups := map[QName][]Element{}
+	var elements []*Element
+	for _, schema := range set.Schemas {
+		for _, c := range schema.Components {
+			e, ok := c.(*Element)
+			if !ok || set.elements[QName{e.Namespace, e.Name}] != e {
+				continue
+			}
+			elements = append(elements, e)
+			if e.SubstitutionGroup.Local != "" {
+				groups[e.SubstitutionGroup] = append(groups[e.SubstitutionGroup], *e)
+			}
+		}
+	}
+	for _, e := range elements {
+		name := QName{e.Namespace, e.Name}
+		e.Substitutes = substitutesOf(groups, name, map[QName]bool{name: true})
+	}
+}
+
+// substitutesOf returns the non-abstract elements which may substitute the
+// head element by given name, following nested substitution groups.
+func substitutesOf(groups map[QName][]Element, head QName, seen map[QName]bool) (members []Element) {
+	for _, member := range groups[head] {
+		name := QName{member.Namespace, member.Name}
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		if !member.Abstract {
+			members = append(members, member)
+		}
+		members = append(members, substitutesOf(groups, name, seen)...)
+	}
+	return
+}
+
+// index records the component by its QName in its symbol space.
+func (set *SchemaSet) index(c Component) {
+	switch c := c.(type) {
+	case *SimpleType:
+		if _, ok := set.types[QName{c.Namespace, c.Name}]; !ok {
+			set.types[QName{c.Namespace, c.Name}] = c
+		}
+	case *ComplexType:
+		if _, ok := set.types[QName{c.Namespace, c.Name}]; !ok {
+			set.types[QName{c.Namespace, c.Name}] = c
+		}
+	case *Element:
+		if _, ok := set.elements[QName{c.Namespace, c.Name}]; !ok {
+			set.elements[QName{c.Namespace, c.Name}] = c
+		}
+	case *Attribute:
+		if _, ok := set.attributes[QName{c.Namespace, c.Name}]; !ok {
+			set.attributes[QName{c.Namespace, c.Name}] = c
+		}
+	case *Group:
+		if _, ok := set.groups[QName{c.Namespace, c.Name}]; !ok {
+			set.groups[QName{c.Namespace, c.Name}] = c
+		}
+	case *AttributeGroup:
+		if _, ok := set.attributeGroups[QName{c.Namespace, c.Name}]; !ok {
+			set.attributeGroups[QName{c.Namespace, c.Name}] = c
+		}
+	}
+}
+
+// Type returns the simple or complex type by given QName, or nil if it's not
+// defined. The anonymous type of a top-level element is found by the name of
+// the element.
+func (set *SchemaSet) Type(name QName) Component {
+	return set.types[name]
+}
+
+// Element returns the top-level element declaration by given QName, or nil
+// if it's not declared.
+func (set *SchemaSet) Element(name QName) *Element {
+	return set.elements[name]
+}
+
+// Attribute returns the top-level attribute declaration by given QName, or
+// nil if it's not declared.
+func (set *SchemaSet) Attribute(name QName) *Attribute {
+	return set.attributes[name]
+}
+
+// Group returns the model group definition by given QName, or nil if it's not
+// defined.
+func (set *SchemaSet) Group(name QName) *Group {
+	return set.groups[name]
+}
+
+// AttributeGroup returns the attribute group definition by given QName, or
+// nil if it's not defined.
+func (set *SchemaSet) AttributeGroup(name QName) *AttributeGroup {
+	return set.attributeGroups[name]
+}
+
+// Walk calls the visitor for the components of the schemas in order.
+func (set *SchemaSet) Walk(v Visitor) {
+	for _, schema := range set.Schemas {
+		for _, c := range schema.Components {
+			if c != nil {
+				c.Accept(v)
+			}
+		}
+	}
+}
+
+// componentNamespace returns the namespace of the component.
+func componentNamespace(c Component) string {
+	switch c := c.(type) {
+	case *SimpleType:
+		return c.Namespace
+	case *ComplexType:
+		return c.Namespace
+	case *Element:
+		return c.Namespace
+	case *Attribute:
+		return c.Namespace
+	case *Group:
+		return c.Namespace
+	case *AttributeGroup:
+		return c.Namespace
+	}
+	return ""
+}
@@ -0,0 +1,261 @@
+// Copyright 2026 The xgen Authors. All rights reserved. Use of this source
+// code is governed by a BSD-style license that can be found in the LICENSE
+// file.
+//
+// Package xgen written in pure Go providing a set of functions that allow you
+// to parse XSD (XML schema files). This library needs Go version 1.10 or
+// later.
+
+package xgen
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io/fs"
+	"strconv"
+	"strings"
+)
+
+// Severity is the severity of a diagnostic.
+type Severity string
+
+const (
+	// SeverityError fails the parsing of the schemas.
+	SeverityError Severity = "error"
+	// SeverityWarning reports a problem without failing the parsing.
+	SeverityWarning Severity = "warning"
+)
+
+// The codes of the diagnostics.
+const (
+	CodeSyntax         = "syntax"           // the schema is not well-formed XML
+	CodeSchemaNotFound = "schema-not-found" // the schema file doesn't exist
+	CodeRemoteSchema   = "remote-schema"    // the remote schema can't be fetched
+	CodeInvalidNumber  = "invalid-number"   // an attribute value is not a number
+	CodeInvalidXPath   = "invalid-xpath"    // the XPath of an assertion is not supported by the generated code
+	CodeUnsupported    = "unsupported"      // the schema element is not supported and ignored
+	CodeInvalidSchema  = "invalid-schema"   // any other problem of the schema
+	CodeInvalidConfig  = "invalid-config"   // the project config doesn't match its schema
+)
+
+// Diagnostic describes a problem found in a schema, located by the schema
+// file, the line and column of the element, which start from 1, and the path
+// of the component such as "/schema/element[@name='order']/complexType".
+type Diagnostic struct {
+	Severity Severity `json:"severity"`
+	Code     string   `json:"code"`
+	File     string   `json:"file"`
+	Line     int      `json:"line,omitempty"`
+	Column   int      `json:"column,omitempty"`
+	Path     string   `json:"path,omitempty"`
+	Message  string   `json:"message"`
+
+	err error // the error reported by the diagnostic
+}
+
+// Error returns the diagnostic in the form of "file:line:column: severity:
+// message".
+func (d Diagnostic) Error() string {
+	location := d.File
+	if d.Line > 0 {
+		location += fmt.Sprintf(":%d", d.Line)
+	}
+	if d.Column > 0 {
+		location += fmt.Sprintf(":%d", d.Column)
+	}
+	return fmt.Sprintf("%s: %s: %s", location, d.Severity, d.Message)
+}
+
+// Unwrap returns the error reported by the diagnostic.
+func (d Diagnostic) Unwrap() error {
+	return d.err
+}
+
+// Diagnostics is the list of diagnostics found in one run, which is returned
+// as the error if any of them is an error.
+type Diagnostics []Diagnostic
+
+// Error returns the diagnostics one per line.
+func (ds Diagnostics) Error() string {
+	lines := make([]string, len(ds))
+	for i, d := range ds {
+		lines[i] = d.Error()
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Unwrap returns the diagnostics as errors.
+func (ds Diagnostics) Unwrap() []error {
+	errs := make([]error, len(ds))
+	for i, d := range ds {
+		errs[i] = d
+	}
+	return errs
+}
+
+// add appends the diagnostics which are not in the list yet, as a schema may be
+// parsed several times on behalf of the schemas referencing it.
+func (ds Diagnostics) add(diagnostics ...Diagnostic) Diagnostics {
+	for _, d := range diagnostics {
+		duplicated := false
+		for _, reported := range ds {
+			if reported.Severity == d.Severity && reported.Code == d.Code && reported.File == d.File &&
+				reported.Line == d.Line && reported.Column == d.Column && reported.Message == d.Message {
+				duplicated = true
+				break
+			}
+		}
+		if !duplicated {
+			ds = append(ds, d)
+		}
+	}
+	return ds
+}
+
+// HasErrors reports whether any of the diagnostics is an error.
+func (ds Diagnostics) HasErrors() bool {
+	for _, d := range ds {
+		if d.Severity == SeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+// codedError is an error with the code of the diagnostic reporting it.
+type codedError struct {
+	code string
+	err  error
+}
+
+func (e *codedError) Error() string { return e.err.Error() }
+
+func (e *codedError) Unwrap() error { return e.err }
+
+// newDiagnostic creates the error diagnostic of the error, the code of which
+// is classified by the error.
+func newDiagnostic(file string, err error) Diagnostic {
+	d := Diagnostic{Severity: SeverityError, Code: CodeInvalidSchema, File: file, Message: err.Error(), err: err}
+	var coded *codedError
+	var syntaxErr *xml.SyntaxError
+	var numErr *strconv.NumError
+	switch {
+	case errors.As(err, &coded):
+		d.Code = coded.code
+	case errors.As(err, &syntaxErr):
+		d.Code = CodeSyntax
+	case errors.As(err, &numErr):
+		d.Code = CodeInvalidNumber
+	case errors.Is(err, fs.ErrNotExist):
+		d.Code = CodeSchemaNotFound
+	}
+	return d
+}
+
+// report records the error raised at the line and column of the component
+// by given path. The diagnostics of a schema parsed on behalf of the schema
+// being parsed are already recorded, as the parsers share the diagnostics.
+func (opt *Options) report(err error, line, column int, path []string) {
+	var ds Diagnostics
+	if errors.As(err, &ds) {
+		return
+	}
+	d := newDiagnostic(opt.FilePath, err)
+	d.Line, d.Column, d.Path = line, column, "/"+strings.Join(path, "/")
+	*opt.diagnostics = opt.diagnostics.add(d)
+}
+
+// reportUnsupported records the schema element by given local name which is
+// not supported, as an error in the strict mode and a warning otherwise.
+func (opt *Options) reportUnsupported(local string, line, column int, path []string) {
+	d := Diagnostic{
+		Severity: SeverityWarning,
+		Code:     CodeUnsupported,
+		File:     opt.FilePath,
+		Line:     line,
+		Column:   column,
+		Path:     "/" + strings.Join(path, "/"),
+		Message:  fmt.Sprintf("%s is not supported and ignored", local),
+	}
+	if opt.Strict {
+		d.Severity = SeverityError
+	}
+	*opt.diagnostics = opt.diagnostics.add(d)
+}
+
+// report records the problem found while generating the code of the schema
+// by given severity, code and error.
+func (gen *CodeGenerator) report(severity Severity, code string, err error) {
+	gen.diagnostics = gen.diagnostics.add(Diagnostic{Severity: severity, Code: code, File: gen.location, Message: err.Error(), err: err})
+}
+
+// unsupportedSeverity returns the severity of the schema constructs the
+// generated code doesn't support, which are errors in the strict mode.
+func (gen *CodeGenerator) unsupportedSeverity() Severity {
+	if gen.strict {
+		return SeverityError
+	}
+	return SeverityWarning
+}
+
+// supportedElements are the schema elements by their local names which the
+// parser supports, any other element is reported as unsupported. An element
+// is listed once the generated code reflects it, a handler of the element
+// alone doesn't make it supported.
+var supportedElements = map[string]bool{
+	"all":            true,
+	"any":            true,
+	"anyAttribute":   true,
+	"assert":         true,
+	"assertion":      true,
+	"attribute":      true,
+	"attributeGroup": true,
+	"choice":         true,
+	"complexContent": true,
+	"complexType":    true,
+	"element":        true,
+	"enumeration":    true,
+	"extension":      true,
+	"field":          true,
+	"fractionDigits": true,
+	"group":          true,
+	"import":         true,
+	"include":        true,
+	"key":            true,
+	"keyref":         true,
+	"length":         true,
+	"list":           true,
+	"maxExclusive":   true,
+	"maxInclusive":   true,
+	"maxLength":      true,
+	"minExclusive":   true,
+	"minInclusive":   true,
+	"minLength":      true,
+	"override":       true,
+	"pattern":        true,
+	"redefine":       true,
+	"restriction":    true,
+	"schema":         true,
+	"selector":       true,
+	"sequence":       true,
+	"simpleContent":  true,
+	"simpleType":     true,
+	"totalDigits":    true,
+	"union":          true,
+	"unique":         true,
+	"whiteSpace":     true,
+}
+
+// componentStep returns the step of the component path for the element,
+// which is the local name with the name or the reference of the component.
+func componentStep(ele xml.StartElement) string {
+	for _, local := range []string{"name", "ref"} {
+		for _, attr := range ele.Attr {
+			if attr.Name.Space == "" && attr.Name.Local == local {
+				return fmt.Sprintf("%s[@%s='%s']", ele.Name.Local, local, attr.Value)
+			}
+		}
+	}
+	return ele.Name.Local
+}
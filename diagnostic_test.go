@@ -0,0 +1,237 @@
+// Copyright 2026 The xgen Authors. All rights reserved. Use of this source
+// code is governed by a BSD-style license that can be found in the LICENSE
+// file.
+//
+// Package xgen written in pure Go providing a set of functions that allow you
+// to parse XSD (XML schema files). This library needs Go version 1.10 or
+// later.
+
+package xgen
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"reflect"
+	"strconv"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadDiagnostics(t *testing.T) {
+	fsys := fstest.MapFS{
+		"order.xsd": {Data: []byte(`<xs:schema xmlns:xs="http://www.w3.org/2001/XMLSchema">
+  <xs:element name="Order">
+    <xs:complexType>
+      <xs:sequence>
+        <xs:element name="qty" type="xs:int" minOccurs="x"/>
+        <xs:element name="note" type="xs:string" maxOccurs="many">
+          <xs:annotation/>
+        </xs:element>
+      </xs:sequence>
+    </xs:complexType>
+  </xs:element>
+</xs:schema>`)},
+		"truncated.xsd": {Data: []byte(`<xs:schema xmlns:xs="http://www.w3.org/2001/XMLSchema">
+  <xs:element name="Item"><
+</xs:schema>`)},
+		"valid.xsd": {Data: []byte(`<xs:schema xmlns:xs="http://www.w3.org/2001/XMLSchema">
+  <xs:element name="Valid" type="xs:string"/>
+</xs:schema>`)},
+	}
+	_, err := Load(context.Background(), &Options{FS: fsys, Lang: "Go"})
+	var diagnostics Diagnostics
+	require.True(t, errors.As(err, &diagnostics))
+	require.Len(t, diagnostics, 3)
+	assert.ErrorIs(t, err, strconv.ErrSyntax)
+	assert.Equal(t, "order.xsd:5:9: error: strconv.Atoi: parsing \"x\": invalid syntax", diagnostics[0].Error())
+	for i, expected := range []Diagnostic{
+		{Severity: SeverityError, Code: CodeInvalidNumber, File: "order.xsd", Line: 5, Column: 9, Path: "/schema/element[@name='Order']/complexType/sequence/element[@name='qty']", Message: `strconv.Atoi: parsing "x": invalid syntax`},
+		{Severity: SeverityError, Code: CodeInvalidNumber, File: "order.xsd", Line: 6, Column: 9, Path: "/schema/element[@name='Order']/complexType/sequence/element[@name='note']", Message: `strconv.Atoi: parsing "many": invalid syntax`},
+		{Severity: SeverityError, Code: CodeSyntax, File: "truncated.xsd", Line: 2, Path: "/schema/element[@name='Item']", Message: "XML syntax error on line 2: expected element name after <"},
+	} {
+		diagnostics[i].err = nil
+		assert.Equal(t, expected, diagnostics[i])
+	}
+
+	_, err = Load(context.Background(), &Options{FS: fsys, FilePath: "missing.xsd", Lang: "Go"})
+	require.True(t, errors.As(err, &diagnostics))
+	assert.Equal(t, CodeSchemaNotFound, diagnostics[0].Code)
+	assert.ErrorIs(t, err, fs.ErrNotExist)
+}
+
+func TestGenerateDiagnostics(t *testing.T) {
+	fsys := fstest.MapFS{
+		"order.xsd": {Data: []byte(`<xs:schema xmlns:xs="http://www.w3.org/2001/XMLSchema">
+  <xs:element name="Order">
+    <xs:complexType>
+      <xs:attribute name="code" type="xs:string"/>
+      <xs:assert test="matches(@code, '[A-Z]+')"/>
+    </xs:complexType>
+  </xs:element>
+</xs:schema>`)},
+	}
+	// The assertions are checked by the Go code only
+	set, err := Load(context.Background(), &Options{FS: fsys, Lang: "TypeScript"})
+	require.NoError(t, err)
+	require.NoError(t, Generate(context.Background(), set, MapSink{}))
+	assert.Empty(t, set.Diagnostics)
+
+	set, err = Load(context.Background(), &Options{FS: fsys, Lang: "Go"})
+	require.NoError(t, err)
+	err = Generate(context.Background(), set, MapSink{})
+	var diagnostics Diagnostics
+	require.True(t, errors.As(err, &diagnostics))
+	require.Len(t, diagnostics, 1)
+	diagnostics[0].err = nil
+	assert.Equal(t, Diagnostic{Severity: SeverityError, Code: CodeInvalidXPath, File: "order.xsd", Message: `assertion "matches(@code, '[A-Z]+')" of Order: unsupported function matches() at offset 0`}, diagnostics[0])
+
+	// The identity constraints which can't be checked are warnings unless
+	// in the strict mode
+	fsys["library.xsd"] = &fstest.MapFile{Data: []byte(`<xs:schema xmlns:xs="http://www.w3.org/2001/XMLSchema">
+  <xs:element name="Library">
+    <xs:complexType>
+      <xs:sequence>
+        <xs:element name="book" maxOccurs="unbounded">
+          <xs:complexType>
+            <xs:attribute name="isbn" type="xs:string"/>
+          </xs:complexType>
+        </xs:element>
+      </xs:sequence>
+    </xs:complexType>
+    <xs:unique name="bookTitle">
+      <xs:selector xpath="book"/>
+      <xs:field xpath="@title"/>
+    </xs:unique>
+  </xs:element>
+</xs:schema>`)}
+	set, err = Load(context.Background(), &Options{FS: fsys, FilePath: "library.xsd", Lang: "Go"})
+	require.NoError(t, err)
+	require.NoError(t, Generate(context.Background(), set, MapSink{}))
+	require.Len(t, set.Diagnostics, 1)
+	set.Diagnostics[0].err = nil
+	expected := Diagnostic{Severity: SeverityWarning, Code: CodeUnsupported, File: "library.xsd", Message: `unique bookTitle of Library is not checked: field "@title" does not select a single value`}
+	assert.Equal(t, expected, set.Diagnostics[0])
+
+	set, err = Load(context.Background(), &Options{FS: fsys, FilePath: "library.xsd", Lang: "Go", Strict: true})
+	require.NoError(t, err)
+	err = Generate(context.Background(), set, MapSink{})
+	require.True(t, errors.As(err, &diagnostics))
+	require.Len(t, diagnostics, 1)
+	diagnostics[0].err = nil
+	expected.Severity = SeverityError
+	assert.Equal(t, expected, diagnostics[0])
+}
+
+func TestLoadStrict(t *testing.T) {
+	fsys := fstest.MapFS{
+		"order.xsd": {Data: []byte(`<xs:schema xmlns:xs="http://www.w3.org/2001/XMLSchema" xmlns:c="urn:common">
+  <xs:import namespace="urn:common" schemaLocation="common.xsd"/>
+  <xs:element name="Order">
+    <xs:annotation>
+      <xs:documentation><xs:example/>An order</xs:documentation>
+    </xs:annotation>
+    <xs:complexType>
+      <xs:openContent mode="interleave"><xs:any/></xs:openContent>
+      <xs:sequence>
+        <xs:element name="code" type="c:Code"/>
+      </xs:sequence>
+    </xs:complexType>
+  </xs:element>
+</xs:schema>`)},
+		"common.xsd": {Data: []byte(`<xs:schema xmlns:xs="http://www.w3.org/2001/XMLSchema" targetNamespace="urn:common">
+  <xs:notation name="gif" public="image/gif"/>
+  <xs:simpleType name="Code">
+    <xs:restriction base="xs:string"/>
+  </xs:simpleType>
+</xs:schema>`)},
+	}
+	set, err := Load(context.Background(), &Options{FS: fsys, FilePath: "order.xsd", Lang: "Go"})
+	require.NoError(t, err)
+	assert.Equal(t, Diagnostics{
+		{Severity: SeverityWarning, Code: CodeUnsupported, File: "order.xsd", Line: 8, Column: 7, Path: "/schema/element[@name='Order']/complexType/openContent", Message: "openContent is not supported and ignored"},
+		{Severity: SeverityWarning, Code: CodeUnsupported, File: "common.xsd", Line: 2, Column: 3, Path: "/schema/notation[@name='gif']", Message: "notation is not supported and ignored"},
+	}, set.Diagnostics)
+
+	_, err = Load(context.Background(), &Options{FS: fsys, FilePath: "order.xsd", Lang: "Go", Strict: true})
+	var diagnostics Diagnostics
+	require.True(t, errors.As(err, &diagnostics))
+	require.Len(t, diagnostics, 2)
+	for _, d := range diagnostics {
+		assert.Equal(t, SeverityError, d.Severity)
+		assert.Equal(t, CodeUnsupported, d.Code)
+	}
+
+	fsys["order.xsd"] = &fstest.MapFile{Data: []byte(`<xs:schema xmlns:xs="http://www.w3.org/2001/XMLSchema">
+  <xs:element name=Order type="xs:string"/>
+</xs:schema>`)}
+	_, err = Load(context.Background(), &Options{FS: fsys, FilePath: "order.xsd", Lang: "Go"})
+	require.NoError(t, err)
+	_, err = Load(context.Background(), &Options{FS: fsys, FilePath: "order.xsd", Lang: "Go", Strict: true})
+	require.True(t, errors.As(err, &diagnostics))
+	require.Len(t, diagnostics, 1)
+	assert.Equal(t, CodeSyntax, diagnostics[0].Code)
+	assert.Equal(t, 2, diagnostics[0].Line)
+}
+
+// TestSupportedElements checks that the parser handles each of the schema
+// elements reported as supported.
+func TestSupportedElements(t *testing.T) {
+	receiver := reflect.ValueOf(&Options{})
+	for local := range supportedElements {
+		assert.True(t, receiver.MethodByName("On"+MakeFirstUpperCase(local)).IsValid() ||
+			receiver.MethodByName("End"+MakeFirstUpperCase(local)).IsValid(), local)
+	}
+}
+
+func TestLoadUnexpectedStructure(t *testing.T) {
+	fsys := fstest.MapFS{
+		"order.xsd": {Data: []byte(`<xs:schema xmlns:xs="http://www.w3.org/2001/XMLSchema">
+  <xs:complexType name="Order">
+    <xs:complexType/>
+  </xs:complexType>
+  <xs:complexType>
+    <xs:all/>
+  </xs:complexType>
+</xs:schema>`)},
+	}
+	_, err := Load(context.Background(), &Options{FS: fsys, Lang: "Go"})
+	var diagnostics Diagnostics
+	require.True(t, errors.As(err, &diagnostics))
+	assert.EqualError(t, diagnostics, `order.xsd:3:5: error: missing the enclosing element
+order.xsd:7:3: error: missing the name of complexType`)
+}
+
+func TestLoadUnexpectedStack(t *testing.T) {
+	for body, expected := range map[string]string{
+		`<xs:enumeration value="a"/>`:                                 "missing the enclosing simpleType",
+		`<xs:pattern value="a"/>`:                                     "missing the enclosing simpleType",
+		`<xs:length value="1"/>`:                                      "missing the enclosing simpleType",
+		`<xs:minLength value="1"/>`:                                   "missing the enclosing simpleType",
+		`<xs:maxLength value="1"/>`:                                   "missing the enclosing simpleType",
+		`<xs:minInclusive value="1"/>`:                                "missing the enclosing simpleType",
+		`<xs:maxInclusive value="1"/>`:                                "missing the enclosing simpleType",
+		`<xs:minExclusive value="1"/>`:                                "missing the enclosing simpleType",
+		`<xs:maxExclusive value="1"/>`:                                "missing the enclosing simpleType",
+		`<xs:assertion test="$value > 0"/>`:                           "missing the enclosing simpleType",
+		`<xs:list itemType="xs:string"/>`:                             "missing the enclosing simpleType",
+		`<xs:union memberTypes="xs:string"/>`:                         "missing the enclosing simpleType",
+		`<xs:simpleContent/>`:                                         "missing the enclosing complexType",
+		`<xs:complexContent/>`:                                        "missing the enclosing complexType",
+		`<xs:extension base="xs:string"/>`:                            "missing the enclosing complexType",
+		`<xs:assert test="count(a) > 0"/>`:                            "missing the enclosing complexType",
+		`<xs:complexType name="A"><xs:complexType/></xs:complexType>`: "missing the enclosing element",
+	} {
+		fsys := fstest.MapFS{
+			"order.xsd": {Data: []byte(`<xs:schema xmlns:xs="http://www.w3.org/2001/XMLSchema">` + body + `</xs:schema>`)},
+		}
+		_, err := Load(context.Background(), &Options{FS: fsys, Lang: "Go"})
+		var diagnostics Diagnostics
+		require.True(t, errors.As(err, &diagnostics), body)
+		require.Len(t, diagnostics, 1, body)
+		assert.Equal(t, expected, diagnostics[0].Message, body)
+	}
+}
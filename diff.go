@@ -0,0 +1,193 @@
+// Copyright 2026 The xgen Authors. All rights reserved. Use of this source
+// code is governed by a BSD-style license that can be found in the LICENSE
+// file.
+//
+// Package xgen written in pure Go providing a set of functions that allow you
+// to parse XSD (XML schema files). This library needs Go version 1.10 or
+// later.
+
+package xgen
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// diffContext is the number of the unchanged lines around the changes in a
+// hunk of the unified diff.
+const diffContext = 3
+
+// diffOp is an operation of the edit script turning the old lines into the
+// new ones, which keeps, deletes or inserts a line.
+type diffOp struct {
+	kind byte // ' ', '-' or '+'
+	line string
+}
+
+// unifiedDiff returns the unified diff of the old text to the new one, the
+// files are named by the headers. An empty string is returned if the texts
+// are equal.
+func unifiedDiff(oldName, newName string, old, new []byte) string {
+	if string(old) == string(new) {
+		return ""
+	}
+	ops := diffLines(splitLines(string(old)), splitLines(string(new)))
+	// The numbers of the old and new lines before each operation
+	oldLines, newLines := make([]int, len(ops)+1), make([]int, len(ops)+1)
+	for i, op := range ops {
+		oldLines[i+1], newLines[i+1] = oldLines[i], newLines[i]
+		if op.kind != '+' {
+			oldLines[i+1]++
+		}
+		if op.kind != '-' {
+			newLines[i+1]++
+		}
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "--- %s\n+++ %s\n", oldName, newName)
+	for i := 0; i < len(ops); {
+		for i < len(ops) && ops[i].kind == ' ' {
+			i++
+		}
+		if i == len(ops) {
+			break
+		}
+		// A hunk covers the following changes until there are more unchanged
+		// lines between two changes than the context around them
+		start, end := max(i-diffContext, 0), i
+		for {
+			for end < len(ops) && ops[end].kind != ' ' {
+				end++
+			}
+			next := end
+			for next < len(ops) && ops[next].kind == ' ' {
+				next++
+			}
+			if next == len(ops) || next-end > 2*diffContext {
+				break
+			}
+			end = next
+		}
+		end = min(end+diffContext, len(ops))
+		oldStart, oldCount := oldLines[start]+1, oldLines[end]-oldLines[start]
+		newStart, newCount := newLines[start]+1, newLines[end]-newLines[start]
+		if oldCount == 0 {
+			oldStart--
+		}
+		if newCount == 0 {
+			newStart--
+		}
+		fmt.Fprintf(&b, "@@ -%d,%d +%d,%d @@\n", oldStart, oldCount, newStart, newCount)
+		for _, op := range ops[start:end] {
+			b.WriteByte(op.kind)
+			b.WriteString(op.line)
+			if !strings.HasSuffix(op.line, "\n") {
+				b.WriteString("\n\\ No newline at end of file\n")
+			}
+		}
+		i = end
+	}
+	return b.String()
+}
+
+// splitLines splits the text into the lines along with their line breaks.
+func splitLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+	lines := strings.SplitAfter(text, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// diffLines returns the edit script turning the lines a into the lines b by
+// the patience diff, which matches the lines appearing once in both of them
+// in order, and diffs the lines between the matched ones recursively. The
+// lines between the matched ones are replaced as a whole if none of them
+// appears once in both.
+func diffLines(a, b []string) []diffOp {
+	var ops []diffOp
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		ops = append(ops, diffOp{' ', a[prefix]})
+		prefix++
+	}
+	a, b = a[prefix:], b[prefix:]
+	suffix := 0
+	for suffix < len(a) && suffix < len(b) && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+	tail := make([]diffOp, 0, suffix)
+	for _, line := range a[len(a)-suffix:] {
+		tail = append(tail, diffOp{' ', line})
+	}
+	a, b = a[:len(a)-suffix], b[:len(b)-suffix]
+
+	matches := uniqueMatches(a, b)
+	if len(matches) == 0 {
+		for _, line := range a {
+			ops = append(ops, diffOp{'-', line})
+		}
+		for _, line := range b {
+			ops = append(ops, diffOp{'+', line})
+		}
+		return append(ops, tail...)
+	}
+	i, j := 0, 0
+	for _, m := range matches {
+		ops = append(ops, diffLines(a[i:m[0]], b[j:m[1]])...)
+		ops = append(ops, diffOp{' ', a[m[0]]})
+		i, j = m[0]+1, m[1]+1
+	}
+	ops = append(ops, diffLines(a[i:], b[j:])...)
+	return append(ops, tail...)
+}
+
+// uniqueMatches returns the longest increasing sequence of the index pairs of
+// the lines appearing once in both a and b.
+func uniqueMatches(a, b []string) [][2]int {
+	counts := map[string][2]int{}
+	positions := map[string]int{}
+	for _, line := range a {
+		c := counts[line]
+		c[0]++
+		counts[line] = c
+	}
+	for j, line := range b {
+		c := counts[line]
+		c[1]++
+		counts[line] = c
+		positions[line] = j
+	}
+	var pairs [][2]int
+	for i, line := range a {
+		if c := counts[line]; c[0] == 1 && c[1] == 1 {
+			pairs = append(pairs, [2]int{i, positions[line]})
+		}
+	}
+	// Patience sorting of the pairs by the index in b
+	var piles []int // the last pair on each pile
+	prev := make([]int, len(pairs))
+	for k, pair := range pairs {
+		p := sort.Search(len(piles), func(p int) bool { return pairs[piles[p]][1] > pair[1] })
+		if prev[k] = -1; p > 0 {
+			prev[k] = piles[p-1]
+		}
+		if p == len(piles) {
+			piles = append(piles, k)
+		} else {
+			piles[p] = k
+		}
+	}
+	if len(piles) == 0 {
+		return nil
+	}
+	matches := make([][2]int, len(piles))
+	for k, n := piles[len(piles)-1], len(piles)-1; k >= 0; k, n = prev[k], n-1 {
+		matches[n] = pairs[k]
+	}
+	return matches
+}
@@ -0,0 +1,152 @@
+// Copyright 2026 The xgen Authors. All rights reserved. Use of this source
+// code is governed by a BSD-style license that can be found in the LICENSE
+// file.
+//
+// Package xgen written in pure Go providing a set of functions that allow you
+// to parse XSD (XML schema files). This library needs Go version 1.10 or
+// later.
+
+package xgen
+
+import (
+	"archive/zip"
+	"embed"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+)
+
+// EmbedFS returns the file system of the schemas embedded by the go:embed
+// directive under given directory, the paths of the embedded files start
+// with the directory.
+func EmbedFS(fsys embed.FS, dir string) (fs.FS, error) {
+	return fs.Sub(fsys, dir)
+}
+
+// ZipFS returns the file system of the schemas in the zip or jar bundle under
+// given directory, such as "META-INF/xsd". An empty directory is the root of
+// the bundle.
+func ZipFS(r *zip.Reader, dir string) (fs.FS, error) {
+	if dir == "" {
+		return r, nil
+	}
+	return fs.Sub(r, dir)
+}
+
+// DirFS returns the file system of the schemas in the directory of the
+// operating system.
+func DirFS(dir string) fs.FS {
+	return os.DirFS(dir)
+}
+
+// inFS reports whether the schema file is in the file system of the options.
+// The absolute paths are the files of the operating system, such as the
+// cached remote schemas and the files mapped by the catalog.
+func (opt *Options) inFS(file string) bool {
+	return opt.FS != nil && !filepath.IsAbs(file)
+}
+
+// open opens the schema file.
+func (opt *Options) open(file string) (fs.File, error) {
+	opt.read(file)
+	if opt.inFS(file) {
+		return opt.FS.Open(file)
+	}
+	return os.Open(file)
+}
+
+// stat returns the file info of the schema file.
+func (opt *Options) stat(file string) (fs.FileInfo, error) {
+	opt.read(file)
+	if opt.inFS(file) {
+		return fs.Stat(opt.FS, file)
+	}
+	return os.Stat(file)
+}
+
+// readDir returns the entries of the directory sorted by their names.
+func (opt *Options) readDir(dir string) ([]fs.DirEntry, error) {
+	if opt.inFS(dir) {
+		return fs.ReadDir(opt.FS, dir)
+	}
+	return os.ReadDir(dir)
+}
+
+// read records the schema file read or looked up when parsing, which the
+// code of the schema being parsed depends on.
+func (opt *Options) read(file string) {
+	if opt.symbols != nil {
+		opt.symbols.files[file] = true
+	}
+}
+
+// fileDir returns the directory of the schema file.
+func (opt *Options) fileDir(file string) string {
+	if opt.inFS(file) {
+		return path.Dir(file)
+	}
+	return filepath.Dir(file)
+}
+
+// joinFileDir returns the path of the schema by given location relative to
+// the directory of the schema being parsed.
+func (opt *Options) joinFileDir(location string) string {
+	if opt.inFS(opt.FileDir) {
+		return path.Join(opt.FileDir, filepath.ToSlash(location))
+	}
+	return filepath.Join(opt.FileDir, location)
+}
+
+// fileList returns the schema files by given path of a file or a directory.
+func (opt *Options) fileList(input string) (files []string, err error) {
+	if !opt.inFS(input) {
+		return GetFileList(input)
+	}
+	err = fs.WalkDir(opt.FS, input, func(file string, d fs.DirEntry, err error) error {
+		if err == nil && !d.IsDir() {
+			files = append(files, file)
+		}
+		return err
+	})
+	return
+}
+
+// inputFiles returns the schema files by given path of a file or a directory,
+// except the ones excluded by the options.
+func (opt *Options) inputFiles(input string) ([]string, error) {
+	for _, pattern := range opt.Exclude {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("invalid exclude pattern %q: %w", pattern, err)
+		}
+	}
+	files, err := opt.fileList(input)
+	if err != nil || len(opt.Exclude) == 0 {
+		return files, err
+	}
+	included := files[:0]
+	for _, file := range files {
+		if !opt.excluded(input, file) {
+			included = append(included, file)
+		}
+	}
+	return included, nil
+}
+
+// excluded reports whether the file of the input directory, or any of its
+// parent directories in the input directory, matches an exclude pattern.
+func (opt *Options) excluded(input, file string) bool {
+	rel, err := filepath.Rel(input, file)
+	if err != nil {
+		return false
+	}
+	for rel = filepath.ToSlash(rel); rel != "." && rel != "/"; rel = path.Dir(rel) {
+		for _, pattern := range opt.Exclude {
+			if ok, _ := path.Match(pattern, rel); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
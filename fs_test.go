@@ -0,0 +1,112 @@
+// Copyright 2026 The xgen Authors. All rights reserved. Use of this source
+// code is governed by a BSD-style license that can be found in the LICENSE
+// file.
+//
+// Package xgen written in pure Go providing a set of functions that allow you
+// to parse XSD (XML schema files). This library needs Go version 1.10 or
+// later.
+
+package xgen
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"embed"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+//go:embed test/xsd
+var testSchemas embed.FS
+
+// generateFS loads the schemas in the file system and returns the generated
+// TypeScript code by the file names.
+func generateFS(t *testing.T, fsys fs.FS) MapSink {
+	set, err := Load(context.Background(), &Options{FS: fsys, Lang: "TypeScript"})
+	require.NoError(t, err)
+	sink := MapSink{}
+	require.NoError(t, Generate(context.Background(), set, sink))
+	return sink
+}
+
+func TestLoadFS(t *testing.T) {
+	expected := MapSink{}
+	require.NoError(t, filepath.WalkDir(filepath.Join(testFixtureDir, "ts"), func(file string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		name, err := filepath.Rel(filepath.Join(testFixtureDir, "ts"), file)
+		if err != nil {
+			return err
+		}
+		expected[filepath.ToSlash(name)], err = os.ReadFile(file)
+		return err
+	}))
+
+	embedFS, err := EmbedFS(testSchemas, "test/xsd")
+	require.NoError(t, err)
+	assert.Equal(t, expected, generateFS(t, embedFS))
+
+	assert.Equal(t, expected, generateFS(t, DirFS(filepath.Join(testFixtureDir, "xsd"))))
+
+	var bundle bytes.Buffer
+	w := zip.NewWriter(&bundle)
+	require.NoError(t, fs.WalkDir(testSchemas, "test/xsd", func(file string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		data, err := testSchemas.ReadFile(file)
+		if err != nil {
+			return err
+		}
+		f, err := w.Create("META-INF/" + strings.TrimPrefix(file, "test/"))
+		if err != nil {
+			return err
+		}
+		_, err = f.Write(data)
+		return err
+	}))
+	require.NoError(t, w.Close())
+	r, err := zip.NewReader(bytes.NewReader(bundle.Bytes()), int64(bundle.Len()))
+	require.NoError(t, err)
+	zipFS, err := ZipFS(r, "META-INF/xsd")
+	require.NoError(t, err)
+	assert.Equal(t, expected, generateFS(t, zipFS))
+}
+
+func TestLoadReader(t *testing.T) {
+	fsys := fstest.MapFS{
+		"schemas/common/types.xsd": {Data: []byte(`<xs:schema xmlns:xs="http://www.w3.org/2001/XMLSchema">
+	<xs:simpleType name="Code">
+		<xs:restriction base="xs:string"/>
+	</xs:simpleType>
+</xs:schema>`)},
+	}
+	document := `<xs:schema xmlns:xs="http://www.w3.org/2001/XMLSchema">
+	<xs:include schemaLocation="common/types.xsd"/>
+	<xs:element name="Product">
+		<xs:complexType>
+			<xs:attribute name="code" type="Code"/>
+		</xs:complexType>
+	</xs:element>
+</xs:schema>`
+	set, err := LoadReader(context.Background(), strings.NewReader(document), &Options{FilePath: "schemas/product.xsd", FS: fsys, Lang: "Go"})
+	require.NoError(t, err)
+	require.Len(t, set.Schemas, 1)
+	assert.Equal(t, "schemas/product.xsd", set.Schemas[0].Location)
+	product, ok := set.Type(QName{Local: "Product"}).(*ComplexType)
+	require.True(t, ok)
+	require.Len(t, product.Attributes, 1)
+	assert.Equal(t, "string", product.Attributes[0].Type)
+
+	_, err = Load(context.Background(), &Options{FilePath: "schemas/product.xsd", FS: fsys, Lang: "Go"})
+	assert.ErrorIs(t, err, fs.ErrNotExist)
+}
@@ -10,8 +10,6 @@ package xgen
 
 import (
 	"fmt"
-	"os"
-	"reflect"
 	"strings"
 )
 
@@ -37,24 +35,27 @@ var cBuildInType = map[string]bool{
 // GenC generates C programming language source code for XML schema definition
 // files.
 func (gen *CodeGenerator) GenC() error {
-	fieldNameCount = make(map[string]int)
+	gen.fieldNameCount = make(map[string]int)
 	for _, ele := range gen.ProtoTree {
 		if ele == nil {
 			continue
 		}
-		funcName := fmt.Sprintf("C%s", reflect.TypeOf(ele).String()[6:])
-		callFuncByName(gen, funcName, []reflect.Value{reflect.ValueOf(ele)})
+		ele.Accept(cVisitor{gen})
 	}
-	f, err := os.Create(gen.FileWithExtension(".h"))
-	if err != nil {
-		return err
-	}
-	defer f.Close()
-	source := []byte(fmt.Sprintf("%s\n%s", copyright, gen.Field))
-	f.Write(source)
-	return err
+	source := []byte(fmt.Sprintf("%s\n%s%s", copyright, gen.mappedImports(cInclude), gen.Field.String()))
+	return gen.writeFile(".h", source)
 }
 
+// cVisitor generates C code for the schema components it visits.
+type cVisitor struct{ *CodeGenerator }
+
+func (v cVisitor) VisitSimpleType(c *SimpleType)         { v.CSimpleType(c) }
+func (v cVisitor) VisitComplexType(c *ComplexType)       { v.CComplexType(c) }
+func (v cVisitor) VisitElement(c *Element)               { v.CElement(c) }
+func (v cVisitor) VisitAttribute(c *Attribute)           { v.CAttribute(c) }
+func (v cVisitor) VisitGroup(c *Group)                   { v.CGroup(c) }
+func (v cVisitor) VisitAttributeGroup(c *AttributeGroup) { v.CAttributeGroup(c) }
+
 func innerArray(dataType string) (string, bool) {
 	if strings.HasSuffix(dataType, "[]") {
 		return strings.TrimSuffix(dataType, "[]"), true
@@ -62,7 +63,7 @@ func innerArray(dataType string) (string, bool) {
 	return dataType, false
 }
 
-func genCFieldName(name string, unique bool) (fieldName string) {
+func genCFieldName(name string) (fieldName string) {
 	for _, str := range strings.Split(name, ":") {
 		fieldName += MakeFirstUpperCase(str)
 	}
@@ -72,17 +73,11 @@ func genCFieldName(name string, unique bool) (fieldName string) {
 	}
 	fieldName = tmp
 	fieldName = strings.Replace(fieldName, "-", "", -1)
-	if unique {
-		fieldNameCount[fieldName]++
-		if count := fieldNameCount[fieldName]; count != 1 {
-			fieldName = fmt.Sprintf("%s%d", fieldName, count)
-		}
-	}
 	return
 }
 
-func genCFieldType(name string) string {
-	if _, ok := cBuildInType[name]; ok {
+func (gen *CodeGenerator) genCFieldType(name string) string {
+	if _, ok := cBuildInType[name]; ok || gen.isMappedType(name) {
 		return name
 	}
 	var fieldType string
@@ -101,11 +96,11 @@ func genCFieldType(name string) string {
 func (gen *CodeGenerator) CSimpleType(v *SimpleType) {
 	if v.List {
 		if _, ok := gen.StructAST[v.Name]; !ok {
-			fieldType := genCFieldType(getBasefromSimpleType(trimNSPrefix(v.Base), gen.ProtoTree))
-			content := fmt.Sprintf("%s %s[];\n", genCFieldType(fieldType), genCFieldName(v.Name, false))
+			fieldType := gen.genCFieldType(gen.baseType(trimNSPrefix(v.Base)))
+			content := fmt.Sprintf("%s %s[];\n", gen.genCFieldType(fieldType), genCFieldName(v.Name))
 			gen.StructAST[v.Name] = content
-			fieldName := genCFieldName(v.Name, true)
-			gen.Field += fmt.Sprintf("%stypedef %s", genFieldComment(fieldName, v.Doc, "//"), gen.StructAST[v.Name])
+			fieldName := gen.uniqueName(genCFieldName(v.Name))
+			fmt.Fprintf(&gen.Field, "%stypedef %s", genFieldComment(fieldName, v.Doc, "//"), gen.StructAST[v.Name])
 			return
 		}
 	}
@@ -117,31 +112,31 @@ func (gen *CodeGenerator) CSimpleType(v *SimpleType) {
 				memberType := member.value
 
 				if memberType == "" { // fix order issue
-					memberType = getBasefromSimpleType(memberName, gen.ProtoTree)
+					memberType = gen.baseType(memberName)
 				}
 				var plural, fieldType string
 				var ok bool
-				if fieldType, ok = innerArray(genCFieldType(memberType)); ok {
+				if fieldType, ok = innerArray(gen.genCFieldType(memberType)); ok {
 					plural = "[]"
 				}
-				content += fmt.Sprintf("\t%s %s%s;\n", fieldType, genCFieldName(memberName, false), plural)
+				content += fmt.Sprintf("\t%s %s%s;\n", fieldType, genCFieldName(memberName), plural)
 			}
 			content += "}"
 			gen.StructAST[v.Name] = content
-			fieldName := genCFieldName(v.Name, true)
-			gen.Field += fmt.Sprintf("%stypedef %s %s;\n", genFieldComment(fieldName, v.Doc, "//"), gen.StructAST[v.Name], fieldName)
+			fieldName := gen.uniqueName(genCFieldName(v.Name))
+			fmt.Fprintf(&gen.Field, "%stypedef %s %s;\n", genFieldComment(fieldName, v.Doc, "//"), gen.StructAST[v.Name], fieldName)
 		}
 		return
 	}
 	if _, ok := gen.StructAST[v.Name]; !ok {
 		var plural, fieldType string
 		var ok bool
-		if fieldType, ok = innerArray(genCFieldType(getBasefromSimpleType(trimNSPrefix(v.Base), gen.ProtoTree))); ok {
+		if fieldType, ok = innerArray(gen.genCFieldType(gen.baseType(trimNSPrefix(v.Base)))); ok {
 			plural = "[]"
 		}
-		gen.StructAST[v.Name] = fmt.Sprintf("%s %s%s", fieldType, genCFieldName(v.Name, false), plural)
-		fieldName := genCFieldName(v.Name, true)
-		gen.Field += fmt.Sprintf("%stypedef %s;\n", genFieldComment(fieldName, v.Doc, "//"), gen.StructAST[v.Name])
+		gen.StructAST[v.Name] = fmt.Sprintf("%s %s%s", fieldType, genCFieldName(v.Name), plural)
+		fieldName := gen.uniqueName(genCFieldName(v.Name))
+		fmt.Fprintf(&gen.Field, "%stypedef %s;\n", genFieldComment(fieldName, v.Doc, "//"), gen.StructAST[v.Name])
 	}
 }
 
@@ -151,21 +146,22 @@ func (gen *CodeGenerator) CComplexType(v *ComplexType) {
 	if _, ok := gen.StructAST[v.Name]; !ok {
 		content := "struct {\n"
 		for _, attrGroup := range v.AttributeGroup {
-			fieldType := getBasefromSimpleType(trimNSPrefix(attrGroup.Ref), gen.ProtoTree)
-			content += fmt.Sprintf("\t%s %s;\n", genCFieldType(fieldType), genCFieldName(attrGroup.Name, false))
+			fieldType := gen.baseType(trimNSPrefix(attrGroup.Ref))
+			content += fmt.Sprintf("\t%s %s;\n", gen.genCFieldType(fieldType), genCFieldName(attrGroup.Name))
 		}
 
-		for _, attribute := range v.Attributes {
+		attributes, elements := gen.complexTypeContent(v)
+		for _, attribute := range attributes {
 			var optional string
 			if attribute.Optional {
 				optional = `, optional`
 			}
 			var plural, fieldType string
 			var ok bool
-			if fieldType, ok = innerArray(genCFieldType(getBasefromSimpleType(trimNSPrefix(attribute.Type), gen.ProtoTree))); ok {
+			if fieldType, ok = innerArray(gen.genCFieldType(gen.baseType(trimNSPrefix(attribute.Type)))); ok {
 				plural = "[]"
 			}
-			content += fmt.Sprintf("\t%s %sAttr%s; // attr%s\n", fieldType, genCFieldName(attribute.Name, false), plural, optional)
+			content += fmt.Sprintf("\t%s %sAttr%s; // attr%s\n", fieldType, genCFieldName(attribute.Name), plural, optional)
 		}
 
 		for _, group := range v.Groups {
@@ -173,23 +169,23 @@ func (gen *CodeGenerator) CComplexType(v *ComplexType) {
 			if group.Plural {
 				plural = "[]"
 			}
-			content += fmt.Sprintf("\t%s %s%s;\n", genCFieldType(getBasefromSimpleType(trimNSPrefix(group.Ref), gen.ProtoTree)), genCFieldName(group.Name, false), plural)
+			content += fmt.Sprintf("\t%s %s%s;\n", gen.genCFieldType(gen.baseType(trimNSPrefix(group.Ref))), genCFieldName(group.Name), plural)
 		}
 
-		for _, element := range v.Elements {
+		for _, element := range elements {
 			var plural, fieldType string
 			var ok bool
-			if fieldType, ok = innerArray(genCFieldType(getBasefromSimpleType(trimNSPrefix(element.Type), gen.ProtoTree))); ok || element.Plural {
+			if fieldType, ok = innerArray(gen.genCFieldType(gen.baseType(trimNSPrefix(element.Type)))); ok || element.Plural {
 				plural = "[]"
 			}
-			content += fmt.Sprintf("\t%s %s%s;\n", fieldType, genCFieldName(element.Name, false), plural)
+			content += fmt.Sprintf("\t%s %s%s;\n", fieldType, genCFieldName(element.Name), plural)
 		}
 		// TODO: Implement handling of v.Base for the cases of the type being a built-in one and
 		// the case of inheritance/embedding
 		content += "}"
 		gen.StructAST[v.Name] = content
-		fieldName := genCFieldName(v.Name, true)
-		gen.Field += fmt.Sprintf("%stypedef %s %s;\n", genFieldComment(fieldName, v.Doc, "//"), gen.StructAST[v.Name], fieldName)
+		fieldName := gen.uniqueName(genCFieldName(v.Name))
+		fmt.Fprintf(&gen.Field, "%stypedef %s %s;\n", genFieldComment(fieldName, v.Doc, "//"), gen.StructAST[v.Name], fieldName)
 	}
 }
 
@@ -202,7 +198,7 @@ func (gen *CodeGenerator) CGroup(v *Group) {
 			if element.Plural {
 				plural = "[]"
 			}
-			content += fmt.Sprintf("\t%s %s%s;\n", genCFieldType(getBasefromSimpleType(trimNSPrefix(element.Type), gen.ProtoTree)), genCFieldName(element.Name, false), plural)
+			content += fmt.Sprintf("\t%s %s%s;\n", gen.genCFieldType(gen.baseType(trimNSPrefix(element.Type))), genCFieldName(element.Name), plural)
 		}
 
 		for _, group := range v.Groups {
@@ -210,13 +206,13 @@ func (gen *CodeGenerator) CGroup(v *Group) {
 			if group.Plural {
 				plural = "[]"
 			}
-			content += fmt.Sprintf("\t%s %s%s;\n", genCFieldType(getBasefromSimpleType(trimNSPrefix(group.Ref), gen.ProtoTree)), genCFieldName(group.Name, false), plural)
+			content += fmt.Sprintf("\t%s %s%s;\n", gen.genCFieldType(gen.baseType(trimNSPrefix(group.Ref))), genCFieldName(group.Name), plural)
 		}
 
 		content += "}"
 		gen.StructAST[v.Name] = content
-		fieldName := genCFieldName(v.Name, true)
-		gen.Field += fmt.Sprintf("%stypedef %s %s;\n", genFieldComment(fieldName, v.Doc, "//"), gen.StructAST[v.Name], fieldName)
+		fieldName := gen.uniqueName(genCFieldName(v.Name))
+		fmt.Fprintf(&gen.Field, "%stypedef %s %s;\n", genFieldComment(fieldName, v.Doc, "//"), gen.StructAST[v.Name], fieldName)
 	}
 }
 
@@ -231,15 +227,15 @@ func (gen *CodeGenerator) CAttributeGroup(v *AttributeGroup) {
 			if attribute.Optional {
 				optional = `, optional`
 			}
-			if fieldType, ok = innerArray(genCFieldType(getBasefromSimpleType(trimNSPrefix(attribute.Type), gen.ProtoTree))); ok {
+			if fieldType, ok = innerArray(gen.genCFieldType(gen.baseType(trimNSPrefix(attribute.Type)))); ok {
 				plural = "[]"
 			}
-			content += fmt.Sprintf("\t%s %sAttr%s; // attr%s\n", fieldType, genCFieldName(attribute.Name, false), plural, optional)
+			content += fmt.Sprintf("\t%s %sAttr%s; // attr%s\n", fieldType, genCFieldName(attribute.Name), plural, optional)
 		}
 		content += "}"
 		gen.StructAST[v.Name] = content
-		fieldName := genCFieldName(v.Name, true)
-		gen.Field += fmt.Sprintf("%stypedef %s %s;\n", genFieldComment(fieldName, v.Doc, "//"), gen.StructAST[v.Name], fieldName)
+		fieldName := gen.uniqueName(genCFieldName(v.Name))
+		fmt.Fprintf(&gen.Field, "%stypedef %s %s;\n", genFieldComment(fieldName, v.Doc, "//"), gen.StructAST[v.Name], fieldName)
 	}
 }
 
@@ -248,11 +244,11 @@ func (gen *CodeGenerator) CElement(v *Element) {
 	if _, ok := gen.StructAST[v.Name]; !ok {
 		var plural, fieldType string
 		var ok bool
-		if fieldType, ok = innerArray(genCFieldType(getBasefromSimpleType(trimNSPrefix(v.Type), gen.ProtoTree))); ok || v.Plural {
+		if fieldType, ok = innerArray(gen.genCFieldType(gen.baseType(trimNSPrefix(v.Type)))); ok || v.Plural {
 			plural = "[]"
 		}
-		gen.StructAST[v.Name] = fmt.Sprintf("%s %s%s", fieldType, genCFieldName(v.Name, false), plural)
-		gen.Field += fmt.Sprintf("\ntypedef %s;\n", gen.StructAST[v.Name])
+		gen.StructAST[v.Name] = fmt.Sprintf("%s %s%s", fieldType, genCFieldName(v.Name), plural)
+		fmt.Fprintf(&gen.Field, "\ntypedef %s;\n", gen.StructAST[v.Name])
 	}
 }
 
@@ -261,11 +257,11 @@ func (gen *CodeGenerator) CAttribute(v *Attribute) {
 	if _, ok := gen.StructAST[v.Name]; !ok {
 		var plural, fieldType string
 		var ok bool
-		if fieldType, ok = innerArray(genCFieldType(getBasefromSimpleType(trimNSPrefix(v.Type), gen.ProtoTree))); ok || v.Plural {
+		if fieldType, ok = innerArray(gen.genCFieldType(gen.baseType(trimNSPrefix(v.Type)))); ok || v.Plural {
 			plural = "[]"
 		}
-		gen.StructAST[v.Name] = fmt.Sprintf("%s %s%s", fieldType, genCFieldName(v.Name, false), plural)
-		fieldName := genCFieldName(v.Name, true)
-		gen.Field += fmt.Sprintf("%stypedef %s;\n", genFieldComment(fieldName, v.Doc, "//"), gen.StructAST[v.Name])
+		gen.StructAST[v.Name] = fmt.Sprintf("%s %s%s", fieldType, genCFieldName(v.Name), plural)
+		fieldName := gen.uniqueName(genCFieldName(v.Name))
+		fmt.Fprintf(&gen.Field, "%stypedef %s;\n", genFieldComment(fieldName, v.Doc, "//"), gen.StructAST[v.Name])
 	}
 }
@@ -0,0 +1,118 @@
+// Copyright 2026 The xgen Authors. All rights reserved. Use of this source
+// code is governed by a BSD-style license that can be found in the LICENSE
+// file.
+//
+// Package xgen written in pure Go providing a set of functions that allow you
+// to parse XSD (XML schema files). This library needs Go version 1.10 or
+// later.
+
+package xgen
+
+// findComplexType returns the named complex type declared in the schema, or
+// nil if there is no such complex type.
+func (gen *CodeGenerator) findComplexType(name string) *ComplexType {
+	return gen.symbols.complexType(trimNSPrefix(name), gen.ProtoTree)
+}
+
+// isContentRestriction reports whether the complex type derives its
+// content from the base type by restriction. Such types are generated
+// without inheriting from their base type.
+func isContentRestriction(v *ComplexType) bool {
+	return v.Derivation == "restriction" && v.Base != ""
+}
+
+// complexTypeContent returns the attributes and elements to be generated for
+// the complex type. A type derived by restriction inherits the attributes of
+// its base type unless they are prohibited or redeclared, and keeps only the
+// particles of its base type that are declared in the restriction. Prohibited
+// attributes are never generated.
+func (gen *CodeGenerator) complexTypeContent(v *ComplexType) (attributes []Attribute, elements []Element) {
+	if !isContentRestriction(v) {
+		return permittedAttributes(v.Attributes), v.Elements
+	}
+	base := gen.findComplexType(v.Base)
+	if base == nil || base == v {
+		return permittedAttributes(v.Attributes), v.Elements
+	}
+	seen := map[*ComplexType]bool{v: true}
+	baseAttributes, baseElements := gen.derivedContent(base, seen)
+	attributes = mergeAttributes(baseAttributes, v.Attributes)
+	if len(baseElements) == 0 {
+		return attributes, v.Elements
+	}
+	permitted := make(map[string]bool, len(baseElements))
+	for _, element := range baseElements {
+		permitted[trimNSPrefix(element.Name)] = true
+	}
+	for _, element := range v.Elements {
+		if permitted[trimNSPrefix(element.Name)] {
+			elements = append(elements, element)
+		}
+	}
+	return
+}
+
+// derivedContent returns all attributes and elements of the complex type,
+// including the ones inherited along its derivation chain.
+func (gen *CodeGenerator) derivedContent(v *ComplexType, seen map[*ComplexType]bool) (attributes []Attribute, elements []Element) {
+	seen[v] = true
+	if v.Derivation == "" || v.Base == "" {
+		return permittedAttributes(v.Attributes), v.Elements
+	}
+	base := gen.findComplexType(v.Base)
+	if base == nil || seen[base] {
+		return permittedAttributes(v.Attributes), v.Elements
+	}
+	baseAttributes, baseElements := gen.derivedContent(base, seen)
+	attributes = mergeAttributes(baseAttributes, v.Attributes)
+	if v.Derivation == "restriction" {
+		return attributes, v.Elements
+	}
+	return attributes, append(append([]Element{}, baseElements...), v.Elements...)
+}
+
+// simpleContentBase returns the simple type of the text content of the
+// complex type with simple content, following the derivation chain through
+// the complex base types.
+func (gen *CodeGenerator) simpleContentBase(v *ComplexType) string {
+	seen := map[*ComplexType]bool{}
+	for v != nil && !seen[v] {
+		seen[v] = true
+		base := gen.findComplexType(v.Base)
+		if base == nil {
+			return v.Base
+		}
+		v = base
+	}
+	return ""
+}
+
+// mergeAttributes overrides the inherited attributes by the declared ones
+// with the same name and drops the prohibited attributes.
+func mergeAttributes(inherited, declared []Attribute) []Attribute {
+	var attributes []Attribute
+	for _, attribute := range inherited {
+		redeclared := false
+		for _, declaredAttr := range declared {
+			if declaredAttr.Name == attribute.Name {
+				redeclared = true
+				break
+			}
+		}
+		if !redeclared {
+			attributes = append(attributes, attribute)
+		}
+	}
+	return permittedAttributes(append(attributes, declared...))
+}
+
+// permittedAttributes returns the attributes excluding the prohibited ones.
+func permittedAttributes(attributes []Attribute) []Attribute {
+	var permitted []Attribute
+	for _, attribute := range attributes {
+		if !attribute.Prohibited {
+			permitted = append(permitted, attribute)
+		}
+	}
+	return permitted
+}
@@ -11,8 +11,6 @@ package xgen
 import (
 	"fmt"
 	"go/format"
-	"os"
-	"reflect"
 	"strings"
 )
 
@@ -21,17 +19,37 @@ import (
 type CodeGenerator struct {
 	Lang              string
 	File              string
-	Field             string
+	Field             strings.Builder
 	Package           string
 	ImportTime        bool // For Go language
 	ImportEncodingXML bool // For Go language
+	ImportErrors      bool // For identity constraint validation methods
 	ImportFmt         bool // For validation methods
 	ImportRegexp      bool // For pattern validation
-	ProtoTree         []interface{}
+	ImportBytes       bool // For writing the namespaces with prefixes
+	ImportIO          bool // For writing the namespaces with prefixes
+	ProtoTree         []Component
 	StructAST         map[string]string
-	TypeNameMap       map[string]string // XSD type name -> Go type name used
-	ValidatedTypes    map[string]bool   // Go type names that have Validate method
-	EmitXMLName       bool              // When true, emit XMLName xml.Name fields (default true)
+	TypeNameMap       map[string]string     // XSD type name -> Go type name used
+	ValidatedTypes    map[string]bool       // Go type names that have Validate method
+	EmitXMLName       bool                  // When true, emit XMLName xml.Name fields (default true)
+	PrefixNamespaces  bool                  // When true, emit MarshalXML methods writing the namespaces with prefixes
+	NamespacePrefixes map[string]string     // Namespace -> prefix written by the MarshalXML methods
+	TypeMappings      map[QName]TypeMapping // XSD type -> type of the generated code overriding the built-in one
+	Sink              Sink                  // Receives the generated files, which are written by the File path if it's nil
+
+	location         string                 // The location of the schema the code is generated from
+	strict           bool                   // Whether the unsupported schema constructs are reported as errors
+	diagnostics      Diagnostics            // The problems found while generating code
+	fieldNameCount   map[string]int         // The number of the types generated by each name
+	typeNames        map[string]bool        // The names of the types generated by uniqueName
+	symbols          symbolScope            // The index of the proto tree
+	goTypeNames      map[string]*SimpleType // The simple types by their Go type names
+	identityElements map[string][]Element   // The elements with identity constraints by their types
+	set              *SchemaSet             // The schema set the proto tree is generated from
+	mappedTypes      map[string]bool        // The types of the generated code mapped from XSD types
+	sharedHelpers    bool                   // Whether the helpers are declared in the helpers file of the package
+	usesHelpers      bool                   // Whether the generated code uses the helpers
 }
 
 func (gen *CodeGenerator) isRegexAttrEnabled() bool {
@@ -121,36 +139,39 @@ func (gen *CodeGenerator) buildValidateTag(base string, r *Restriction, optional
 func (gen *CodeGenerator) ensureReferencedTypesDeclared() {
 	declared := map[string]bool{}
 	for k := range gen.StructAST {
-		declared[k] = true                        // XSD name (e.g., tStateCode)
-		declared[genGoFieldName(k, false)] = true // Go name (e.g., TStateCode)
+		declared[k] = true                 // XSD name (e.g., tStateCode)
+		declared[genGoFieldName(k)] = true // Go name (e.g., TStateCode)
 	}
-	// Scan gen.Field for type identifiers
-	candidates := map[string]bool{}
+	// Scan gen.Field for type identifiers, which are declared in the order
+	// they first appear so that the output is stable
+	var candidates []string
+	seen := map[string]bool{}
 	sep := func(r rune) bool {
 		return !(r == '_' || (r >= '0' && r <= '9') || (r >= 'A' && r <= 'Z') || (r >= 'a' && r <= 'z'))
 	}
-	for _, tok := range strings.FieldsFunc(gen.Field, sep) {
-		if len(tok) >= 2 && tok[0] == 'T' && tok[1] >= 'A' && tok[1] <= 'Z' {
-			candidates[tok] = true
+	for _, tok := range strings.FieldsFunc(gen.Field.String(), sep) {
+		if len(tok) >= 2 && tok[0] == 'T' && tok[1] >= 'A' && tok[1] <= 'Z' && !seen[tok] {
+			seen[tok] = true
+			candidates = append(candidates, tok)
 		}
 	}
-	for name := range candidates {
+	for _, name := range candidates {
 		// Skip when already declared or will be declared (by Go name) in this file
 		if declared[name] || declared[strings.ToLower(name[:1])+name[1:]] {
 			continue
 		}
-		if strings.Contains(gen.Field, "type "+name+" ") {
+		if gen.typeNames[name] {
 			continue
 		}
-		// Skip built-ins and known non-types
-		if goBuildinType[name] {
+		// Skip built-ins, mapped types and known non-types
+		if goBuildinType[name] || gen.isMappedType(name) {
 			continue
 		}
 		// Declare a simple alias to string for unresolved references only
 		content := fmt.Sprintf(" string\n")
 		gen.StructAST[name] = content
-		fieldName := genGoFieldName(name, true)
-		gen.Field += fmt.Sprintf("%stype %s%s", genFieldComment(fieldName, "", "//"), fieldName, content)
+		fieldName := gen.uniqueName(genGoFieldName(name))
+		fmt.Fprintf(&gen.Field, "%stype %s%s", genFieldComment(fieldName, "", "//"), fieldName, content)
 	}
 }
 
@@ -184,7 +205,7 @@ var goBuildinType = map[string]bool{
 // GenGo generate Go programming language source code for XML schema
 // definition files.
 func (gen *CodeGenerator) GenGo() error {
-	fieldNameCount = make(map[string]int)
+	gen.fieldNameCount, gen.typeNames = make(map[string]int), make(map[string]bool)
 	// First pass: emit all named simple types to ensure they are available for references
 	for _, ele := range gen.ProtoTree {
 		if st, ok := ele.(*SimpleType); ok && st != nil && st.Name != "" {
@@ -196,8 +217,7 @@ func (gen *CodeGenerator) GenGo() error {
 		if ele == nil {
 			continue
 		}
-		funcName := fmt.Sprintf("Go%s", reflect.TypeOf(ele).String()[6:])
-		callFuncByName(gen, funcName, []reflect.Value{reflect.ValueOf(ele)})
+		ele.Accept(goVisitor{gen})
 	}
 	// Final sweep: ensure all named simpleTypes are emitted (some schemas reference them in ways that skip first pass)
 	for _, ele := range gen.ProtoTree {
@@ -209,15 +229,13 @@ func (gen *CodeGenerator) GenGo() error {
 	}
 
 	gen.ensureReferencedTypesDeclared()
-
-	f, err := os.Create(gen.FileWithExtension(".go"))
-	if err != nil {
-		return err
+	if gen.diagnostics.HasErrors() {
+		return gen.diagnostics
 	}
-	defer f.Close()
+
 	var importPackage, packages string
 	// If any emitted content uses xml.Name (e.g., QName), ensure we import encoding/xml.
-	if !gen.ImportEncodingXML && strings.Contains(gen.Field, "xml.Name") {
+	if !gen.ImportEncodingXML && strings.Contains(gen.Field.String(), "xml.Name") {
 		gen.ImportEncodingXML = true
 	}
 	if gen.ImportTime {
@@ -226,48 +244,59 @@ func (gen *CodeGenerator) GenGo() error {
 	if gen.ImportEncodingXML {
 		packages += "\t\"encoding/xml\"\n"
 	}
+	if gen.ImportErrors {
+		packages += "\t\"errors\"\n"
+	}
 	if gen.ImportFmt {
 		packages += "\t\"fmt\"\n"
 	}
 	if gen.ImportRegexp {
 		packages += "\t\"regexp\"\n"
 	}
+	if gen.ImportBytes {
+		packages += "\t\"bytes\"\n"
+	}
+	if gen.ImportIO {
+		packages += "\t\"io\"\n"
+	}
+	packages += gen.mappedImports(func(mapping TypeMapping) string {
+		return fmt.Sprintf("\t%q\n", mapping.Import)
+	})
 	if packages != "" {
 		importPackage = fmt.Sprintf("import (\n%s)", packages)
 	}
-	packageName := gen.Package
-	if packageName == "" {
-		packageName = "schema"
-	}
-	source, err := format.Source([]byte(fmt.Sprintf("%s\n\npackage %s\n%s%s", copyright, packageName, importPackage, gen.Field)))
+	packageName := goPackageName(gen.Package)
+	source, err := format.Source([]byte(fmt.Sprintf("%s\n\npackage %s\n%s%s", copyright, packageName, importPackage, gen.Field.String())))
 	if err != nil {
-		f.WriteString(fmt.Sprintf("package %s\n%s%s", packageName, importPackage, gen.Field))
+		gen.writeFile(".go", []byte(fmt.Sprintf("package %s\n%s%s", packageName, importPackage, gen.Field.String())))
 		return err
 	}
-	f.Write(source)
-	return err
+	return gen.writeFile(".go", source)
 }
 
+// goVisitor generates Go code for the schema components it visits.
+type goVisitor struct{ *CodeGenerator }
+
+func (v goVisitor) VisitSimpleType(c *SimpleType)         { v.GoSimpleType(c) }
+func (v goVisitor) VisitComplexType(c *ComplexType)       { v.GoComplexType(c) }
+func (v goVisitor) VisitElement(c *Element)               { v.GoElement(c) }
+func (v goVisitor) VisitAttribute(c *Attribute)           { v.GoAttribute(c) }
+func (v goVisitor) VisitGroup(c *Group)                   { v.GoGroup(c) }
+func (v goVisitor) VisitAttributeGroup(c *AttributeGroup) { v.GoAttributeGroup(c) }
+
 func splitter(r rune) bool {
 	return strings.ContainsRune(":.-_", r)
 }
 
-func genGoFieldName(name string, unique bool) (fieldName string) {
+func genGoFieldName(name string) (fieldName string) {
 	for _, str := range strings.FieldsFunc(name, splitter) {
 		fieldName += MakeFirstUpperCase(str)
 	}
-
-	if unique {
-		fieldNameCount[fieldName]++
-		if count := fieldNameCount[fieldName]; count != 1 {
-			fieldName = fmt.Sprintf("%s%d", fieldName, count)
-		}
-	}
 	return
 }
 
-func genGoFieldType(name string) string {
-	if _, ok := goBuildinType[name]; ok {
+func (gen *CodeGenerator) genGoFieldType(name string) string {
+	if _, ok := goBuildinType[name]; ok || gen.isMappedType(name) {
 		return name
 	}
 	var fieldType string
@@ -286,7 +315,7 @@ func genGoFieldType(name string) string {
 // when global elements/attributes reuse the same names as types.
 func (gen *CodeGenerator) isGoTypeDeclared(goName string) bool {
 	for k := range gen.StructAST {
-		if k == goName || genGoFieldName(k, false) == goName {
+		if k == goName || genGoFieldName(k) == goName {
 			return true
 		}
 	}
@@ -298,21 +327,21 @@ func (gen *CodeGenerator) isGoTypeDeclared(goName string) bool {
 func (gen *CodeGenerator) GoSimpleType(v *SimpleType) {
 	if v.List {
 		if _, ok := gen.StructAST[v.Name]; !ok {
-			fieldType := genGoFieldType(getBasefromSimpleType(trimNSPrefix(v.Base), gen.ProtoTree))
+			fieldType := gen.genGoFieldType(gen.baseType(trimNSPrefix(v.Base)))
 			if fieldType == "time.Time" {
 				gen.ImportTime = true
 			}
-			content := fmt.Sprintf(" []%s\n", genGoFieldType(fieldType))
+			content := fmt.Sprintf(" []%s\n", gen.genGoFieldType(fieldType))
 			gen.StructAST[v.Name] = content
-			fieldName := genGoFieldName(v.Name, true)
-			gen.Field += fmt.Sprintf("%stype %s%s", genFieldComment(fieldName, v.Doc, "//"), fieldName, gen.StructAST[v.Name])
+			fieldName := gen.uniqueName(genGoFieldName(v.Name))
+			fmt.Fprintf(&gen.Field, "%stype %s%s", genFieldComment(fieldName, v.Doc, "//"), fieldName, gen.StructAST[v.Name])
 			return
 		}
 	}
 	if v.Union && len(v.MemberTypes) > 0 {
 		if _, ok := gen.StructAST[v.Name]; !ok {
 			content := " struct {\n"
-			fieldName := genGoFieldName(v.Name, true)
+			fieldName := gen.uniqueName(genGoFieldName(v.Name))
 			if gen.EmitXMLName && fieldName != v.Name {
 				gen.ImportEncodingXML = true
 				content += fmt.Sprintf("\tXMLName\txml.Name\t`xml:\"%s\"`\n", v.Name)
@@ -323,22 +352,22 @@ func (gen *CodeGenerator) GoSimpleType(v *SimpleType) {
 				// Ensure named member type is available if referenced by name
 				gen.ensureNamedType(memberName)
 				if memberType == "" { // fix order issue and includes
-					memberType = getBasefromSimpleType(memberName, gen.ProtoTree)
+					memberType = gen.baseType(memberName)
 				}
-				content += fmt.Sprintf("\t%s\t%s\n", genGoFieldName(memberName, false), genGoFieldType(memberType))
+				content += fmt.Sprintf("\t%s\t%s\n", genGoFieldName(memberName), gen.genGoFieldType(memberType))
 			}
 			content += "}\n"
 			gen.StructAST[v.Name] = content
-			gen.Field += fmt.Sprintf("%stype %s%s", genFieldComment(fieldName, v.Doc, "//"), fieldName, gen.StructAST[v.Name])
+			fmt.Fprintf(&gen.Field, "%stype %s%s", genFieldComment(fieldName, v.Doc, "//"), fieldName, gen.StructAST[v.Name])
 		}
 		return
 	}
 	if _, ok := gen.StructAST[v.Name]; !ok {
-		base := getBasefromSimpleType(trimNSPrefix(v.Base), gen.ProtoTree)
-		content := fmt.Sprintf(" %s\n", genGoFieldType(base))
+		base := gen.baseType(trimNSPrefix(v.Base))
+		content := fmt.Sprintf(" %s\n", gen.genGoFieldType(base))
 		gen.StructAST[v.Name] = content
-		fieldName := genGoFieldName(v.Name, true)
-		gen.Field += fmt.Sprintf("%stype %s%s", genFieldComment(fieldName, v.Doc, "//"), fieldName, gen.StructAST[v.Name])
+		fieldName := gen.uniqueName(genGoFieldName(v.Name))
+		fmt.Fprintf(&gen.Field, "%stype %s%s", genFieldComment(fieldName, v.Doc, "//"), fieldName, gen.StructAST[v.Name])
 		// Generate Validate method if there are restrictions
 		gen.generateSimpleTypeValidator(fieldName, base, &v.Restriction)
 	}
@@ -349,20 +378,27 @@ func (gen *CodeGenerator) GoSimpleType(v *SimpleType) {
 func (gen *CodeGenerator) GoComplexType(v *ComplexType) {
 	if _, ok := gen.StructAST[v.Name]; !ok {
 		content := " struct {\n"
-		fieldName := genGoFieldName(v.Name, true)
-		if gen.EmitXMLName && fieldName != v.Name {
+		fieldName := gen.uniqueName(genGoFieldName(v.Name))
+		if gen.EmitXMLName && (fieldName != v.Name || v.Global && v.Namespace != "") {
+			// The anonymous type of a top-level element names the document
+			// element, which is qualified by the target namespace
+			var namespace string
+			if v.Global {
+				namespace = v.Namespace
+			}
 			gen.ImportEncodingXML = true
-			content += fmt.Sprintf("\tXMLName\txml.Name\t`xml:\"%s\"`\n", v.Name)
+			content += fmt.Sprintf("\tXMLName\txml.Name\t`xml:\"%s\"`\n", goXMLName(namespace, v.Name))
 		}
 		for _, attrGroup := range v.AttributeGroup {
-			fieldType := getBasefromSimpleType(trimNSPrefix(attrGroup.Ref), gen.ProtoTree)
+			fieldType := gen.baseType(trimNSPrefix(attrGroup.Ref))
 			if fieldType == "time.Time" {
 				gen.ImportTime = true
 			}
-			content += fmt.Sprintf("\t%s\t%s\n", genGoFieldName(attrGroup.Name, false), genGoFieldType(fieldType))
+			content += fmt.Sprintf("\t%s\t%s\n", genGoFieldName(attrGroup.Name), gen.genGoFieldType(fieldType))
 		}
 
-		for _, attribute := range v.Attributes {
+		attributes, elements := gen.complexTypeContent(v)
+		for _, attribute := range attributes {
 			// Ensure the referenced simple type is emitted
 			gen.ensureNamedType(attribute.TypeRef)
 			// Also ensure if attribute.Type directly references a named simpleType
@@ -370,10 +406,10 @@ func (gen *CodeGenerator) GoComplexType(v *ComplexType) {
 			// Prefer using the named simpleType (TypeRef) as the Go field type when available
 			var base string
 			var fieldType string
-			if st := gen.findSimpleType(trimNSPrefix(attribute.TypeRef)); st != nil {
-				base = getBasefromSimpleType(trimNSPrefix(st.Base), gen.ProtoTree)
+			if st := gen.findReferencedSimpleType(attribute.TypeRef, attribute.Type); st != nil {
+				base = gen.baseType(trimNSPrefix(st.Base))
 				// Use the named simple type directly (no pointer by default)
-				fieldType = genGoFieldName(st.Name, false)
+				fieldType = genGoFieldName(st.Name)
 			} else {
 				// Fallback to resolved Go base type from parser or built-in map
 				resolved := strings.TrimSpace(attribute.Type)
@@ -381,14 +417,14 @@ func (gen *CodeGenerator) GoComplexType(v *ComplexType) {
 					if bt, ok := getBuildInTypeByLang(trimNSPrefix(attribute.TypeRef), "Go"); ok && bt != "" {
 						resolved = bt
 					} else {
-						resolved = getBasefromSimpleType(trimNSPrefix(attribute.TypeRef), gen.ProtoTree)
+						resolved = gen.baseType(trimNSPrefix(attribute.TypeRef))
 					}
 				}
 				if resolved == "" {
-					resolved = getBasefromSimpleType(trimNSPrefix(attribute.Type), gen.ProtoTree)
+					resolved = gen.baseType(trimNSPrefix(attribute.Type))
 				}
 				base = resolved
-				fieldType = genGoFieldType(resolved)
+				fieldType = gen.genGoFieldType(resolved)
 			}
 			var optional string
 			if attribute.Optional {
@@ -404,41 +440,64 @@ func (gen *CodeGenerator) GoComplexType(v *ComplexType) {
 			// Determine restriction: prefer inline, otherwise named simpleType's
 			r := attribute.Restriction
 			if !hasRestrictions(&r) {
-				if st := gen.findSimpleType(trimNSPrefix(attribute.TypeRef)); st != nil {
+				if st := gen.findReferencedSimpleType(attribute.TypeRef, attribute.Type); st != nil {
 					r = st.Restriction
-					base = getBasefromSimpleType(trimNSPrefix(st.Base), gen.ProtoTree)
+					base = gen.baseType(trimNSPrefix(st.Base))
 				} else if st2 := gen.findSimpleType(trimNSPrefix(attribute.Type)); st2 != nil {
 					r = st2.Restriction
-					base = getBasefromSimpleType(trimNSPrefix(st2.Base), gen.ProtoTree)
+					base = gen.baseType(trimNSPrefix(st2.Base))
 				}
 			}
 			vtag := gen.buildValidateTag(base, &r, attribute.Optional, false)
-			tag := fmt.Sprintf("xml:\"%s,attr%s\"", attribute.Name, optional)
+			tag := fmt.Sprintf("xml:\"%s,attr%s\"", goXMLName(attribute.Namespace, attribute.Name), optional)
 			if vtag != "" {
 				tag += fmt.Sprintf(" validate:\"%s\"", vtag)
 			}
-			content += fmt.Sprintf("\t%s\t%s\t`%s`\n", genGoFieldName(attribute.Name, false), fieldType, tag)
+			content += fmt.Sprintf("\t%s\t%s\t`%s`\n", genGoFieldName(attribute.Name), fieldType, tag)
+		}
+		if v.AnyAttribute != nil {
+			content += gen.goAnyAttributeField()
 		}
 		for _, group := range v.Groups {
 			// Ensure named types referenced by group elements
 			gen.ensureNamedType(group.Ref)
-			fieldType := genGoFieldType(getBasefromSimpleType(trimNSPrefix(group.Ref), gen.ProtoTree))
+			fieldType := gen.genGoFieldType(gen.baseType(trimNSPrefix(group.Ref)))
 			if group.Plural {
 				fieldType = "[]" + fieldType
 			}
-			content += fmt.Sprintf("\t%s\t%s\n", genGoFieldName(group.Name, false), fieldType)
+			content += fmt.Sprintf("\t%s\t%s\n", genGoFieldName(group.Name), fieldType)
 		}
 
-		for _, element := range v.Elements {
+		var substitutions []goSubstitutionField
+		for _, element := range elements {
+			if head, members := gen.findSubstitutionGroup(element); head != nil {
+				gen.ImportEncodingXML = true
+				field := goSubstitutionField{
+					Name:      genGoFieldName(element.Name),
+					Plural:    element.Plural,
+					Interface: goSubstitutionGroup(head.Name),
+					Members:   members,
+				}
+				substitutions = append(substitutions, field)
+				fieldType, optional := field.Interface, ""
+				if element.Plural {
+					fieldType = "[]" + fieldType
+				}
+				if element.Optional {
+					optional = ",omitempty"
+				}
+				content += fmt.Sprintf("\t%s\t%s\t`xml:\"%s%s\"`\n", field.Name, fieldType, goXMLName(element.Namespace, element.Name), optional)
+				continue
+			}
 			// Ensure the referenced named simple type is emitted (use TypeRef, not resolved Type)
 			gen.ensureNamedType(element.TypeRef)
 			// Prefer using the named simpleType (TypeRef) as the Go field type when available
 			var base string
 			var fieldType string
-			if st := gen.findSimpleType(trimNSPrefix(element.TypeRef)); st != nil {
-				base = getBasefromSimpleType(trimNSPrefix(st.Base), gen.ProtoTree)
+			if st := gen.findReferencedSimpleType(element.TypeRef, element.Type); st != nil {
+				base = gen.baseType(trimNSPrefix(st.Base))
 				// Use the named simple type directly (no pointer by default)
-				fieldType = genGoFieldName(st.Name, false)
+				fieldType = genGoFieldName(st.Name)
 			} else {
 				// Fallback to resolved Go base type from parser or built-in map
 				resolved := strings.TrimSpace(element.Type)
@@ -446,14 +505,17 @@ func (gen *CodeGenerator) GoComplexType(v *ComplexType) {
 					if bt, ok := getBuildInTypeByLang(trimNSPrefix(element.TypeRef), "Go"); ok && bt != "" {
 						resolved = bt
 					} else {
-						resolved = getBasefromSimpleType(trimNSPrefix(element.TypeRef), gen.ProtoTree)
+						resolved = gen.baseType(trimNSPrefix(element.TypeRef))
 					}
 				}
 				if resolved == "" {
-					resolved = getBasefromSimpleType(trimNSPrefix(element.Type), gen.ProtoTree)
+					resolved = gen.baseType(trimNSPrefix(element.Type))
 				}
 				base = resolved
-				fieldType = genGoFieldType(resolved)
+				fieldType = gen.genGoFieldType(resolved)
+			}
+			if element.Nillable {
+				fieldType = gen.goNillableType(fieldType)
 			}
 			if element.Plural {
 				fieldType = "[]" + fieldType
@@ -471,51 +533,78 @@ func (gen *CodeGenerator) GoComplexType(v *ComplexType) {
 			// Determine restriction: prefer inline, otherwise named simpleType's
 			r := element.Restriction
 			if !hasRestrictions(&r) {
-				if st := gen.findSimpleType(trimNSPrefix(element.TypeRef)); st != nil {
+				if st := gen.findReferencedSimpleType(element.TypeRef, element.Type); st != nil {
 					r = st.Restriction
-					base = getBasefromSimpleType(trimNSPrefix(st.Base), gen.ProtoTree)
+					base = gen.baseType(trimNSPrefix(st.Base))
 				} else if st2 := gen.findSimpleType(trimNSPrefix(element.Type)); st2 != nil {
 					r = st2.Restriction
-					base = getBasefromSimpleType(trimNSPrefix(st2.Base), gen.ProtoTree)
+					base = gen.baseType(trimNSPrefix(st2.Base))
 				}
 			}
 			vtag := gen.buildValidateTag(base, &r, element.Optional, element.Plural)
-			tag := fmt.Sprintf("xml:\"%s%s\"", element.Name, optional)
-			if vtag != "" {
+			tag := fmt.Sprintf("xml:\"%s%s\"", goXMLName(element.Namespace, element.Name), optional)
+			if vtag != "" && !element.Nillable {
 				tag += fmt.Sprintf(" validate:\"%s\"", vtag)
 			}
-			content += fmt.Sprintf("\t%s\t%s\t`%s`\n", genGoFieldName(element.Name, false), fieldType, tag)
+			content += fmt.Sprintf("\t%s\t%s\t`%s`\n", genGoFieldName(element.Name), fieldType, tag)
+		}
+		if len(v.Any) > 0 {
+			content += gen.goAnyField()
+		}
+		if countsOccurrences(v) {
+			content += fmt.Sprintf("\t%s\tmap[string]int\n", goOccurrencesField)
 		}
 		if len(v.Base) > 0 {
 			// If the type is a built-in type, generate a Value field as chardata.
 			// If it's not built-in one, embed the base type in the struct for the child type
-			// to effectively inherit all of the base type's fields
-			if isGoBuiltInType(v.Base) {
-				content += fmt.Sprintf("\tValue\t%s\t`xml:\",chardata\"`\n", genGoFieldType(v.Base))
+			// to effectively inherit all of the base type's fields. The type derived
+			// by restriction doesn't embed the base type, the restricted content has
+			// been generated above.
+			if isContentRestriction(v) {
+				if v.Content == "simpleContent" {
+					content += fmt.Sprintf("\tValue\t%s\t`xml:\",chardata\"`\n", gen.genGoFieldType(gen.simpleContentBase(v)))
+				}
+			} else if gen.isGoBuiltInType(v.Base) {
+				if v.Content != "complexContent" {
+					content += fmt.Sprintf("\tValue\t%s\t`xml:\",chardata\"`\n", gen.genGoFieldType(v.Base))
+				}
 			} else {
 				// Ensure the base named type is emitted
 				gen.ensureNamedType(v.Base)
-				content += fmt.Sprintf("\t%s\n", genGoFieldType(v.Base))
+				content += fmt.Sprintf("\t%s\n", gen.genGoFieldType(v.Base))
 			}
 		}
 		content += "}\n"
 		gen.StructAST[v.Name] = content
-		gen.Field += fmt.Sprintf("%stype %s%s", genFieldComment(fieldName, v.Doc, "//"), fieldName, gen.StructAST[v.Name])
+		fmt.Fprintf(&gen.Field, "%stype %s%s", genFieldComment(fieldName, v.Doc, "//"), fieldName, gen.StructAST[v.Name])
 		// Generate validator for complex type fields with inline restrictions
 		gen.generateComplexTypeValidator(fieldName, v)
+		gen.generateUnmarshaler(fieldName, v, substitutions)
+		gen.generateMarshaler(fieldName, v)
+		gen.generateIdentityValidator(fieldName, v)
 	}
 }
 
-func isGoBuiltInType(typeName string) bool {
+// goXMLName returns the name in the xml struct tag of an element or attribute
+// by given namespace and name, the namespace precedes the local name when the
+// name is qualified.
+func goXMLName(namespace, name string) string {
+	if namespace == "" {
+		return trimNSPrefix(name)
+	}
+	return namespace + " " + trimNSPrefix(name)
+}
+
+func (gen *CodeGenerator) isGoBuiltInType(typeName string) bool {
 	_, builtIn := goBuildinType[typeName]
-	return builtIn
+	return builtIn || gen.isMappedType(typeName)
 }
 
 // GoGroup generates code for group XML schema in Go language syntax.
 func (gen *CodeGenerator) GoGroup(v *Group) {
 	if _, ok := gen.StructAST[v.Name]; !ok {
 		content := " struct {\n"
-		fieldName := genGoFieldName(v.Name, true)
+		fieldName := gen.uniqueName(genGoFieldName(v.Name))
 		if gen.EmitXMLName && fieldName != v.Name {
 			gen.ImportEncodingXML = true
 			content += fmt.Sprintf("\tXMLName\txml.Name\t`xml:\"%s\"`\n", v.Name)
@@ -528,7 +617,7 @@ func (gen *CodeGenerator) GoGroup(v *Group) {
 			if element.Plural {
 				plural = "[]"
 			}
-			content += fmt.Sprintf("\t%s\t%s%s\n", genGoFieldName(element.Name, false), plural, genGoFieldType(getBasefromSimpleType(trimNSPrefix(element.Type), gen.ProtoTree)))
+			content += fmt.Sprintf("\t%s\t%s%s\n", genGoFieldName(element.Name), plural, gen.genGoFieldType(gen.baseType(trimNSPrefix(element.Type))))
 		}
 
 		for _, group := range v.Groups {
@@ -536,12 +625,15 @@ func (gen *CodeGenerator) GoGroup(v *Group) {
 			if group.Plural {
 				plural = "[]"
 			}
-			content += fmt.Sprintf("\t%s\t%s%s\n", genGoFieldName(group.Name, false), plural, genGoFieldType(getBasefromSimpleType(trimNSPrefix(group.Ref), gen.ProtoTree)))
+			content += fmt.Sprintf("\t%s\t%s%s\n", genGoFieldName(group.Name), plural, gen.genGoFieldType(gen.baseType(trimNSPrefix(group.Ref))))
+		}
+		if len(v.Any) > 0 {
+			content += gen.goAnyField()
 		}
 
 		content += "}\n"
 		gen.StructAST[v.Name] = content
-		gen.Field += fmt.Sprintf("%stype %s%s", genFieldComment(fieldName, v.Doc, "//"), fieldName, gen.StructAST[v.Name])
+		fmt.Fprintf(&gen.Field, "%stype %s%s", genFieldComment(fieldName, v.Doc, "//"), fieldName, gen.StructAST[v.Name])
 	}
 }
 
@@ -550,7 +642,7 @@ func (gen *CodeGenerator) GoGroup(v *Group) {
 func (gen *CodeGenerator) GoAttributeGroup(v *AttributeGroup) {
 	if _, ok := gen.StructAST[v.Name]; !ok {
 		content := " struct {\n"
-		fieldName := genGoFieldName(v.Name, true)
+		fieldName := gen.uniqueName(genGoFieldName(v.Name))
 		if gen.EmitXMLName && fieldName != v.Name {
 			gen.ImportEncodingXML = true
 			content += fmt.Sprintf("\tXMLName\txml.Name\t`xml:\"%s\"`\n", v.Name)
@@ -559,7 +651,7 @@ func (gen *CodeGenerator) GoAttributeGroup(v *AttributeGroup) {
 			// Ensure named simple types referenced by attribute group attributes
 			gen.ensureNamedType(attribute.TypeRef)
 			gen.ensureNamedType(attribute.Type)
-			base := getBasefromSimpleType(trimNSPrefix(attribute.Type), gen.ProtoTree)
+			base := gen.baseType(trimNSPrefix(attribute.Type))
 			var optional string
 			if attribute.Optional {
 				optional = `,omitempty`
@@ -567,24 +659,27 @@ func (gen *CodeGenerator) GoAttributeGroup(v *AttributeGroup) {
 			// Determine restriction: prefer inline, otherwise named simpleType's
 			r := attribute.Restriction
 			if !hasRestrictions(&r) {
-				if st := gen.findSimpleType(trimNSPrefix(attribute.TypeRef)); st != nil {
+				if st := gen.findReferencedSimpleType(attribute.TypeRef, attribute.Type); st != nil {
 					r = st.Restriction
-					base = getBasefromSimpleType(trimNSPrefix(st.Base), gen.ProtoTree)
+					base = gen.baseType(trimNSPrefix(st.Base))
 				} else if st2 := gen.findSimpleType(trimNSPrefix(attribute.Type)); st2 != nil {
 					r = st2.Restriction
-					base = getBasefromSimpleType(trimNSPrefix(st2.Base), gen.ProtoTree)
+					base = gen.baseType(trimNSPrefix(st2.Base))
 				}
 			}
 			vtag := gen.buildValidateTag(base, &r, attribute.Optional, false)
-			tag := fmt.Sprintf("xml:\"%s,attr%s\"", attribute.Name, optional)
+			tag := fmt.Sprintf("xml:\"%s,attr%s\"", goXMLName(attribute.Namespace, attribute.Name), optional)
 			if vtag != "" {
 				tag += fmt.Sprintf(" validate:\"%s\"", vtag)
 			}
-			content += fmt.Sprintf("\t%s\t%s\t`%s`\n", genGoFieldName(attribute.Name, false), genGoFieldType(base), tag)
+			content += fmt.Sprintf("\t%s\t%s\t`%s`\n", genGoFieldName(attribute.Name), gen.genGoFieldType(base), tag)
+		}
+		if v.AnyAttribute != nil {
+			content += gen.goAnyAttributeField()
 		}
 		content += "}\n"
 		gen.StructAST[v.Name] = content
-		gen.Field += fmt.Sprintf("%stype %s%s", genFieldComment(fieldName, v.Doc, "//"), fieldName, gen.StructAST[v.Name])
+		fmt.Fprintf(&gen.Field, "%stype %s%s", genFieldComment(fieldName, v.Doc, "//"), fieldName, gen.StructAST[v.Name])
 	}
 }
 
@@ -592,7 +687,7 @@ func (gen *CodeGenerator) GoAttributeGroup(v *AttributeGroup) {
 func (gen *CodeGenerator) GoElement(v *Element) {
 	// Do not emit standalone Go types for global elements. Their types are already
 	// represented by named simpleTypes/complexTypes. Emitting both causes duplicates.
-	return
+	gen.goSubstitutionTypes(v)
 }
 
 // GoAttribute generates code for attribute XML schema in Go language syntax.
@@ -601,6 +696,169 @@ func (gen *CodeGenerator) GoAttribute(v *Attribute) {
 	return
 }
 
+// goAnyAttributeField returns the struct field which captures the attributes
+// matched by an anyAttribute wildcard.
+func (gen *CodeGenerator) goAnyAttributeField() string {
+	gen.ImportEncodingXML = true
+	return "\tAnyAttr\t[]xml.Attr\t`xml:\",any,attr\"`\n"
+}
+
+// goAnyField returns the struct field which captures the elements matched by
+// any wildcards.
+func (gen *CodeGenerator) goAnyField() string {
+	gen.useGoHelper(goAnyElementType, goAnyElementSource)
+	return fmt.Sprintf("\tAny\t[]%s\t`xml:\",any\"`\n", goAnyElementType)
+}
+
+// goNillableType returns the Go type of the element declared with
+// nillable="true" by given field type.
+func (gen *CodeGenerator) goNillableType(fieldType string) string {
+	gen.useGoHelper(goNillableType, goNillableSource)
+	return fmt.Sprintf("%s[%s]", goNillableType, strings.TrimPrefix(fieldType, "*"))
+}
+
+// useGoHelper marks a helper type or function as used by the generated code.
+// The helpers are declared once per package in the helpers file when the
+// files are generated by Generate, as the files generated from several
+// schemas share the package. A single file declares them itself.
+func (gen *CodeGenerator) useGoHelper(name, source string) {
+	if gen.sharedHelpers {
+		gen.usesHelpers = true
+		return
+	}
+	gen.ImportEncodingXML = true
+	gen.addGoHelper(name, source)
+}
+
+// addGoHelper emits the declaration of a helper type used by the generated
+// fields once per file.
+func (gen *CodeGenerator) addGoHelper(name, source string) {
+	if _, ok := gen.StructAST[name]; ok {
+		return
+	}
+	gen.StructAST[name] = source
+	gen.Field.WriteString(source)
+}
+
+const (
+	goAnyElementType = "AnyElement"
+	goNillableType   = "Nillable"
+)
+
+// goHelpersFile is the name of the file declaring the helpers used by
+// the Go files generated into the same directory.
+const goHelpersFile = "xgen_helpers.go"
+
+// goPackageName returns the name of the package of the generated Go code by
+// given option, which is schema by default.
+func goPackageName(name string) string {
+	if name == "" {
+		return "schema"
+	}
+	return name
+}
+
+// goHelpers returns the source of the helpers file of the package by given
+// name. The file declares all the helpers, so that it stays the same
+// whichever of them the schemas generated into the package use.
+func goHelpers(packageName string) ([]byte, error) {
+	return format.Source([]byte(fmt.Sprintf("%s\n\npackage %s\n\nimport (\n\t\"bytes\"\n\t\"encoding/xml\"\n\t\"fmt\"\n\t\"io\"\n)\n%s%s%s%s",
+		copyright, goPackageName(packageName), goNillableSource, goAnyElementSource, goPrefixWriterSource, goChildReaderSource)))
+}
+
+// goNillableSource is the declaration of the type holding the value of an
+// element declared with nillable="true", it tells an element with
+// xsi:nil="true" apart from an absent one, which is left to the pointer or
+// slice around it.
+var goNillableSource = `
+// Nillable holds the value of an element declared with nillable="true". Nil
+// reports whether the element carried xsi:nil="true".
+type Nillable[T any] struct {
+	Value T
+	Nil   bool
+}
+
+// MarshalXML writes the element with xsi:nil="true" and no content when Nil
+// is set, or the Value otherwise.
+func (n Nillable[T]) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	if !n.Nil {
+		return e.EncodeElement(n.Value, start)
+	}
+	start.Attr = append(start.Attr,
+		xml.Attr{Name: xml.Name{Local: "xmlns:xsi"}, Value: "http://www.w3.org/2001/XMLSchema-instance"},
+		xml.Attr{Name: xml.Name{Local: "xsi:nil"}, Value: "true"},
+	)
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+	return e.EncodeToken(start.End())
+}
+
+// UnmarshalXML reads the xsi:nil attribute of the element and decodes the
+// Value unless the element is nil.
+func (n *Nillable[T]) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	*n = Nillable[T]{}
+	for _, attr := range start.Attr {
+		if attr.Name.Space == "http://www.w3.org/2001/XMLSchema-instance" && attr.Name.Local == "nil" {
+			if n.Nil = attr.Value == "true" || attr.Value == "1"; n.Nil {
+				return d.Skip()
+			}
+		}
+	}
+	return d.DecodeElement(&n.Value, &start)
+}
+`
+
+// goAnyElementSource is the declaration of the type holding an element matched
+// by an any wildcard. The inner XML is kept as it was read, and the namespace
+// prefixes declared on the element are restored when it is marshalled again,
+// which the encoding/xml package does not do on its own.
+var goAnyElementSource = `
+// AnyElement holds an element matched by an any wildcard.
+type AnyElement struct {
+	XMLName xml.Name
+	Attrs   []xml.Attr ` + "`xml:\",any,attr\"`" + `
+	Content string     ` + "`xml:\",innerxml\"`" + `
+}
+
+// MarshalXML writes the element back with the namespace prefixes declared on
+// it and its inner XML unchanged.
+func (a AnyElement) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	var defaultNS string
+	prefixes := map[string]string{}
+	for _, attr := range a.Attrs {
+		if attr.Name.Space == "xmlns" {
+			prefixes[attr.Value] = attr.Name.Local
+		}
+		if attr.Name.Space == "" && attr.Name.Local == "xmlns" {
+			defaultNS = attr.Value
+		}
+	}
+	qualify := func(name xml.Name) xml.Name {
+		if prefix, ok := prefixes[name.Space]; ok && name.Space != "" {
+			return xml.Name{Local: prefix + ":" + name.Local}
+		}
+		if name.Space == defaultNS {
+			return xml.Name{Local: name.Local}
+		}
+		return name
+	}
+	start = xml.StartElement{Name: qualify(a.XMLName)}
+	for _, attr := range a.Attrs {
+		switch {
+		case attr.Name.Space == "xmlns":
+			attr.Name = xml.Name{Local: "xmlns:" + attr.Name.Local}
+		case attr.Name.Space != "":
+			attr.Name = qualify(attr.Name)
+		}
+		start.Attr = append(start.Attr, attr)
+	}
+	return e.EncodeElement(struct {
+		Content string ` + "`xml:\",innerxml\"`" + `
+	}{a.Content}, start)
+}
+`
+
 func (gen *CodeGenerator) FileWithExtension(extension string) string {
 	if !strings.HasPrefix(extension, ".") {
 		extension = "." + extension
@@ -619,26 +877,39 @@ func (gen *CodeGenerator) FileWithExtension(extension string) string {
 // restrictions to work.
 // findSimpleType retrieves a named simpleType definition from the ProtoTree.
 func (gen *CodeGenerator) findSimpleType(name string) *SimpleType {
-	name = trimNSPrefix(name)
-	for _, ele := range gen.ProtoTree {
-		if st, ok := ele.(*SimpleType); ok {
-			if st.Name == name {
-				return st
-			}
-		}
+	return gen.symbols.simpleType(trimNSPrefix(name), gen.ProtoTree)
+}
+
+// findReferencedSimpleType retrieves the named simpleType referenced by a
+// field of given resolved type, unless the simpleType itself is mapped to
+// another type by the type mappings.
+func (gen *CodeGenerator) findReferencedSimpleType(typeRef, resolved string) *SimpleType {
+	st := gen.findSimpleType(typeRef)
+	if st != nil && gen.isMappedType(resolved) && gen.baseType(trimNSPrefix(st.Base)) != resolved {
+		return nil
 	}
-	return nil
+	return st
 }
 
 func (gen *CodeGenerator) findSimpleTypeByGoName(goName string) *SimpleType {
-	for _, ele := range gen.ProtoTree {
-		if st, ok := ele.(*SimpleType); ok {
-			if genGoFieldName(st.Name, false) == goName {
-				return st
+	if gen.goTypeNames == nil {
+		gen.goTypeNames = map[string]*SimpleType{}
+		for _, ele := range gen.ProtoTree {
+			if st, ok := ele.(*SimpleType); ok {
+				if _, indexed := gen.goTypeNames[genGoFieldName(st.Name)]; !indexed {
+					gen.goTypeNames[genGoFieldName(st.Name)] = st
+				}
 			}
 		}
 	}
-	return nil
+	return gen.goTypeNames[goName]
+}
+
+// baseType returns the base type of the simple type, or the type of the
+// attribute or element declared in the schema by given name, or the name
+// itself if there is no such declaration.
+func (gen *CodeGenerator) baseType(name string) string {
+	return gen.symbols.base(name, gen.ProtoTree)
 }
 
 // ensureNamedType ensures that a named XSD simpleType referenced in fields has
@@ -675,11 +946,11 @@ func (gen *CodeGenerator) ensureNamedType(xsdOrGoName string) {
 	if _, ok := gen.StructAST[key]; ok {
 		return
 	}
-	base := getBasefromSimpleType(trimNSPrefix(st.Base), gen.ProtoTree)
-	content := fmt.Sprintf(" %s\n", genGoFieldType(base))
+	base := gen.baseType(trimNSPrefix(st.Base))
+	content := fmt.Sprintf(" %s\n", gen.genGoFieldType(base))
 	gen.StructAST[key] = content
-	fieldName := genGoFieldName(st.Name, true)
-	gen.Field += fmt.Sprintf("%stype %s%s", genFieldComment(fieldName, st.Doc, "//"), fieldName, content)
+	fieldName := gen.uniqueName(genGoFieldName(st.Name))
+	fmt.Fprintf(&gen.Field, "%stype %s%s", genFieldComment(fieldName, st.Doc, "//"), fieldName, content)
 	// Generate validator for this simpleType if it has restrictions
 	gen.generateSimpleTypeValidator(fieldName, base, &st.Restriction)
 }
@@ -694,7 +965,7 @@ func (gen *CodeGenerator) generateSimpleTypeValidator(typeName, base string, r *
 	}
 	// Determine if there is anything to validate
 	has := false
-	if len(r.Enum) > 0 || r.PatternStr != "" || r.HasLength || r.HasMinLength || r.HasMaxLength || r.HasMin || r.HasMax {
+	if len(r.Enum) > 0 || r.PatternStr != "" || r.HasLength || r.HasMinLength || r.HasMaxLength || r.HasMin || r.HasMax || len(r.Assertions) > 0 {
 		has = true
 	}
 	if !has {
@@ -768,11 +1039,15 @@ func (gen *CodeGenerator) generateSimpleTypeValidator(typeName, base string, r *
 			needsFmt = true
 		}
 	}
+	if len(r.Assertions) > 0 {
+		gen.writeAssertions(&b, &goAssertionContext{value: "v", valueType: base}, typeName, r.Assertions)
+		needsFmt = true
+	}
 	b.WriteString("\treturn nil\n}")
 	if needsFmt {
 		gen.ImportFmt = true
 	}
-	gen.Field += b.String() + "\n"
+	gen.Field.WriteString(b.String() + "\n")
 }
 
 func isNumericGoType(t string) bool {
@@ -789,23 +1064,26 @@ func hasRestrictions(r *Restriction) bool {
 	if r == nil {
 		return false
 	}
-	return len(r.Enum) > 0 || r.PatternStr != "" || r.HasLength || r.HasMinLength || r.HasMaxLength || r.HasMin || r.HasMax
+	return len(r.Enum) > 0 || r.PatternStr != "" || r.HasLength || r.HasMinLength || r.HasMaxLength || r.HasMin || r.HasMax || len(r.Assertions) > 0
 }
 
 // generateComplexTypeValidator emits a Validate() method for complex types that
 // have inline restrictions on their attributes or elements.
 func (gen *CodeGenerator) generateComplexTypeValidator(typeName string, v *ComplexType) {
-	any := false
 	var b strings.Builder
+	attributes, elements := gen.complexTypeContent(v)
+	once := gen.allElementsOnce(v, elements)
+	assertions := gen.assertionsOf(v)
+	any := len(once) > 0 || len(assertions) > 0 || v.Content == "simpleContent" && hasRestrictions(&v.Restriction)
 	// Scan to see if there is any restriction to enforce
-	for _, a := range v.Attributes {
+	for _, a := range attributes {
 		if hasRestrictions(&a.Restriction) {
 			any = true
 			break
 		}
 	}
 	if !any {
-		for _, e := range v.Elements {
+		for _, e := range elements {
 			if hasRestrictions(&e.Restriction) {
 				any = true
 				break
@@ -820,14 +1098,22 @@ func (gen *CodeGenerator) generateComplexTypeValidator(typeName string, v *Compl
 	b.WriteString(typeName)
 	b.WriteString(") Validate() error {\n")
 	b.WriteString("\tif m == nil { return nil }\n")
+	// Elements of the all group
+	for _, name := range once {
+		fmt.Fprintf(&b, "\tif m.%s[%q] > 1 {\n\t\treturn fmt.Errorf(\"%s must occur at most once\")\n\t}\n", goOccurrencesField, name, name)
+	}
+	// Text content
+	if v.Content == "simpleContent" && hasRestrictions(&v.Restriction) {
+		b.WriteString(gen.generateRestrictionChecks("m.Value", gen.simpleContentBase(v), "Value", &v.Restriction))
+	}
 	// Attributes
-	for _, a := range v.Attributes {
+	for _, a := range attributes {
 		r := a.Restriction
 		if !hasRestrictions(&r) {
 			continue
 		}
-		fieldName := genGoFieldName(a.Name, false)
-		base := getBasefromSimpleType(trimNSPrefix(a.Type), gen.ProtoTree)
+		fieldName := genGoFieldName(a.Name)
+		base := gen.baseType(trimNSPrefix(a.Type))
 		if a.Optional {
 			checks := gen.generateRestrictionChecks("*m."+fieldName, base, fieldName, &r)
 			if len(checks) > 0 {
@@ -841,13 +1127,29 @@ func (gen *CodeGenerator) generateComplexTypeValidator(typeName string, v *Compl
 		}
 	}
 	// Elements
-	for _, e := range v.Elements {
+	for _, e := range elements {
 		r := e.Restriction
 		if !hasRestrictions(&r) {
 			continue
 		}
-		fieldName := genGoFieldName(e.Name, false)
-		base := getBasefromSimpleType(trimNSPrefix(e.Type), gen.ProtoTree)
+		fieldName := genGoFieldName(e.Name)
+		base := gen.baseType(trimNSPrefix(e.Type))
+		if e.Nillable {
+			checks := gen.generateRestrictionChecks("it.Value", base, fieldName, &r)
+			if len(checks) == 0 {
+				continue
+			}
+			switch {
+			case e.Plural:
+				fmt.Fprintf(&b, "\tfor _, it := range m.%s {\n", fieldName)
+			case e.Optional:
+				fmt.Fprintf(&b, "\tif it := m.%s; it != nil {\n", fieldName)
+			default:
+				fmt.Fprintf(&b, "\t{\n\t\tit := m.%s\n", fieldName)
+			}
+			fmt.Fprintf(&b, "\t\tif !it.Nil {\n%s\t\t}\n\t}\n", checks)
+			continue
+		}
 		if e.Plural {
 			checks := gen.generateRestrictionChecks("it", base, fieldName, &r)
 			if len(checks) > 0 {
@@ -867,8 +1169,10 @@ func (gen *CodeGenerator) generateComplexTypeValidator(typeName string, v *Compl
 			b.WriteString(checks)
 		}
 	}
+	// Assertions
+	gen.writeAssertions(&b, &goAssertionContext{complexType: v, node: "m"}, typeName, assertions)
 	b.WriteString("\treturn nil\n}")
-	gen.Field += b.String() + "\n"
+	gen.Field.WriteString(b.String() + "\n")
 }
 
 // generateRestrictionChecks generates the Go code snippet that enforces the
@@ -926,5 +1230,6 @@ func (gen *CodeGenerator) generateRestrictionChecks(varExpr, base, subjectName s
 			}
 		}
 	}
+	gen.writeAssertions(&b, &goAssertionContext{value: varExpr, valueType: base}, subjectName, r.Assertions)
 	return b.String()
 }
@@ -0,0 +1,414 @@
+// Copyright 2026 The xgen Authors. All rights reserved. Use of this source
+// code is governed by a BSD-style license that can be found in the LICENSE
+// file.
+//
+// Package xgen written in pure Go providing a set of functions that allow you
+// to parse XSD (XML schema files). This library needs Go version 1.10 or
+// later.
+
+package xgen
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// goXPathValue is the Go code of the value of an XPath expression.
+type goXPathValue struct {
+	Guard string // the condition under which the value exists, empty if always
+	Expr  string // the expression of the value
+	Kind  string // boolean, number or string
+}
+
+// goAssertionContext holds the context in which the XPath expressions of the
+// assertions are evaluated by the generated code.
+type goAssertionContext struct {
+	complexType *ComplexType // the type of the context node, nil for a simple value
+	node        string       // the expression of the context node
+	value       string       // the expression of the simple value
+	valueType   string       // the type of the simple value
+	counter     int          // the number of the variables declared
+}
+
+// writeAssertions writes the checks of the assertions, which return an error
+// if the expression of an assertion is not true. Assertions that cannot be
+// checked against the generated structs are reported as errors, which fail
+// the code generation.
+func (gen *CodeGenerator) writeAssertions(b *strings.Builder, ctx *goAssertionContext, subject string, assertions []Assertion) {
+	for _, assertion := range assertions {
+		cond, err := gen.goAssertion(ctx, assertion.Test)
+		if err != nil {
+			gen.report(SeverityError, CodeInvalidXPath, fmt.Errorf("assertion %q of %s: %w", assertion.Test, subject, err))
+			continue
+		}
+		fmt.Fprintf(b, "\tif !(%s) {\n\t\treturn fmt.Errorf(\"%s must satisfy assertion %%q\", %q)\n\t}\n", cond, subject, assertion.Test)
+	}
+}
+
+// assertionsOf returns the assertions of the complex type, including the
+// ones inherited from the complex types it extends.
+func (gen *CodeGenerator) assertionsOf(v *ComplexType) (assertions []Assertion) {
+	seen := map[*ComplexType]bool{}
+	for v != nil && !seen[v] {
+		seen[v] = true
+		assertions = append(append([]Assertion{}, v.Assertions...), assertions...)
+		if v.Derivation != "extension" {
+			break
+		}
+		v = gen.findComplexType(v.Base)
+	}
+	return
+}
+
+// goAssertion returns the Go condition of the XPath expression.
+func (gen *CodeGenerator) goAssertion(ctx *goAssertionContext, test string) (string, error) {
+	expr, err := compileXPath(test)
+	if err != nil {
+		return "", err
+	}
+	return gen.goXPathBoolean(ctx, expr)
+}
+
+// goXPathBoolean returns the Go condition of the effective boolean value of
+// the XPath expression.
+func (gen *CodeGenerator) goXPathBoolean(ctx *goAssertionContext, e xpathExpr) (string, error) {
+	switch e := e.(type) {
+	case *xpathBinary:
+		if e.Op != "and" && e.Op != "or" {
+			return gen.goXPathComparison(ctx, e)
+		}
+		left, err := gen.goXPathBoolean(ctx, e.Left)
+		if err != nil {
+			return "", err
+		}
+		right, err := gen.goXPathBoolean(ctx, e.Right)
+		if err != nil {
+			return "", err
+		}
+		if e.Op == "or" {
+			return left + " || " + right, nil
+		}
+		return joinXPathGuard(parenthesizeXPath(e.Left, left), parenthesizeXPath(e.Right, right)), nil
+	case *xpathCall:
+		switch e.Name {
+		case "true", "false":
+			return e.Name, nil
+		case "not":
+			arg, err := gen.goXPathBoolean(ctx, e.Args[0])
+			return negateXPath(arg), err
+		case "exists":
+			return gen.goXPathExists(ctx, e.Args[0])
+		case "empty":
+			exists, err := gen.goXPathExists(ctx, e.Args[0])
+			return negateXPath(exists), err
+		}
+	case *xpathPath:
+		return gen.goXPathExists(ctx, e)
+	}
+	v, err := gen.goXPathValue(ctx, e)
+	if err != nil {
+		return "", err
+	}
+	switch v.Kind {
+	case "number":
+		v.Expr += " != 0"
+	case "string":
+		v.Expr += ` != ""`
+	}
+	return joinXPathGuard(v.Guard, v.Expr), nil
+}
+
+// goXPathComparison returns the Go condition of the comparison, which is
+// false if any of the operands is empty.
+func (gen *CodeGenerator) goXPathComparison(ctx *goAssertionContext, e *xpathBinary) (string, error) {
+	left, err := gen.goXPathValue(ctx, e.Left)
+	if err != nil {
+		return "", err
+	}
+	right, err := gen.goXPathValue(ctx, e.Right)
+	if err != nil {
+		return "", err
+	}
+	if left.Kind != right.Kind {
+		return "", fmt.Errorf("cannot compare %s with %s", left.Kind, right.Kind)
+	}
+	op := e.Op
+	if op == "=" {
+		op = "=="
+	}
+	if left.Kind == "boolean" && op != "==" && op != "!=" {
+		return "", fmt.Errorf("cannot compare boolean values with %s", e.Op)
+	}
+	guard := joinXPathGuard(left.Guard, right.Guard)
+	if left.Kind == "boolean" && (right.Expr == "true" || right.Expr == "false") {
+		// Compare with a boolean constant without the redundant operator
+		if (right.Expr == "true") == (op == "==") {
+			return joinXPathGuard(guard, left.Expr), nil
+		}
+		return joinXPathGuard(guard, "!"+left.Expr), nil
+	}
+	return joinXPathGuard(guard, fmt.Sprintf("%s %s %s", left.Expr, op, right.Expr)), nil
+}
+
+// goXPathValue returns the Go code of the atomic value of the XPath
+// expression.
+func (gen *CodeGenerator) goXPathValue(ctx *goAssertionContext, e xpathExpr) (goXPathValue, error) {
+	switch e := e.(type) {
+	case *xpathString:
+		return goXPathValue{Expr: strconv.Quote(e.Value), Kind: "string"}, nil
+	case *xpathNumber:
+		return goXPathValue{Expr: strconv.FormatFloat(e.Value, 'g', -1, 64), Kind: "number"}, nil
+	case *xpathVariable:
+		return gen.goXPathPathValue(ctx, &xpathPath{Text: "$" + e.Name})
+	case *xpathPath:
+		return gen.goXPathPathValue(ctx, e)
+	case *xpathCall:
+		if e.Name == "count" {
+			count, err := gen.goXPathCount(ctx, e.Args[0])
+			return goXPathValue{Expr: "float64(" + count + ")", Kind: "number"}, err
+		}
+	}
+	cond, err := gen.goXPathBoolean(ctx, e)
+	if strings.Contains(cond, " ") {
+		cond = "(" + cond + ")"
+	}
+	return goXPathValue{Expr: cond, Kind: "boolean"}, err
+}
+
+// goXPathPathValue returns the Go code of the atomic value of the node
+// selected by the location path, the path must not select more than one
+// node.
+func (gen *CodeGenerator) goXPathPathValue(ctx *goAssertionContext, path *xpathPath) (goXPathValue, error) {
+	if ctx.complexType == nil {
+		if len(path.Steps) > 0 {
+			return goXPathValue{}, fmt.Errorf("%s selects a child of a simple value", path.Text)
+		}
+		return gen.goXPathScalar(ctx.value, ctx.valueType)
+	}
+	steps, err := gen.resolveAssertionPath(ctx, path)
+	if err != nil {
+		return goXPathValue{}, err
+	}
+	node := ctx.complexType
+	if len(steps) > 0 {
+		node = steps[len(steps)-1].ComplexType
+	}
+	if node != nil {
+		value, ok := gen.identityValue(node)
+		if !ok {
+			return goXPathValue{}, fmt.Errorf("%s selects an element without simple content", path.Text)
+		}
+		steps = append(steps, value...)
+	}
+	var guards []string
+	expr := ctx.node
+	for _, step := range steps {
+		if step.Slice {
+			return goXPathValue{}, fmt.Errorf("%s may select more than one node, use count() or exists()", path.Text)
+		}
+		expr += "." + step.Field
+		if presence := identityPresence(step, expr); presence != "" {
+			guards = append(guards, presence)
+		}
+		if step.Nillable {
+			expr += ".Value"
+		} else if step.Pointer && step.ComplexType == nil {
+			expr = "*" + expr
+		}
+	}
+	value, err := gen.goXPathScalar(expr, steps[len(steps)-1].Type)
+	value.Guard = strings.Join(guards, " && ")
+	if err != nil {
+		return value, fmt.Errorf("%s: %w", path.Text, err)
+	}
+	return value, nil
+}
+
+// goXPathScalar returns the Go code of the value of the expression holding a
+// value of given simple type.
+func (gen *CodeGenerator) goXPathScalar(expr, valueType string) (goXPathValue, error) {
+	switch base := gen.baseType(trimNSPrefix(valueType)); {
+	case base == "string":
+		return goXPathValue{Expr: "string(" + expr + ")", Kind: "string"}, nil
+	case base == "bool":
+		return goXPathValue{Expr: expr, Kind: "boolean"}, nil
+	case isNumericGoType(base):
+		return goXPathValue{Expr: "float64(" + expr + ")", Kind: "number"}, nil
+	default:
+		return goXPathValue{}, fmt.Errorf("values of type %s are not supported", base)
+	}
+}
+
+// goXPathExists returns the Go condition under which the location path
+// selects any node.
+func (gen *CodeGenerator) goXPathExists(ctx *goAssertionContext, e xpathExpr) (string, error) {
+	path, ok := e.(*xpathPath)
+	if !ok {
+		return "", fmt.Errorf("exists() and empty() take a location path")
+	}
+	steps, err := gen.resolveNodePath(ctx, path)
+	if err != nil {
+		return "", err
+	}
+	var guards []string
+	expr := ctx.node
+	for _, step := range steps {
+		if step.Slice {
+			count, err := gen.goXPathCount(ctx, path)
+			return count + " > 0", err
+		}
+		expr += "." + step.Field
+		if presence := identityPresence(step, expr); presence != "" {
+			guards = append(guards, presence)
+		}
+	}
+	if len(guards) == 0 {
+		return "true", nil
+	}
+	return strings.Join(guards, " && "), nil
+}
+
+// goXPathCount returns the Go expression of the number of the nodes selected
+// by the location path.
+func (gen *CodeGenerator) goXPathCount(ctx *goAssertionContext, e xpathExpr) (string, error) {
+	path, ok := e.(*xpathPath)
+	if !ok {
+		return "", fmt.Errorf("count() takes a location path")
+	}
+	steps, err := gen.resolveNodePath(ctx, path)
+	if err != nil {
+		return "", err
+	}
+	switch {
+	case len(steps) == 0:
+		return "1", nil
+	case len(steps) == 1 && steps[0].Slice:
+		return fmt.Sprintf("len(%s.%s)", ctx.node, steps[0].Field), nil
+	}
+	var b strings.Builder
+	b.WriteString("func() (n int) {\n")
+	last := steps[len(steps)-1]
+	gen.writeIdentitySteps(&b, steps[:len(steps)-1], ctx.node, "", 1, &ctx.counter, func(expr, path string, depth int) {
+		indent := strings.Repeat("\t", depth)
+		switch presence := identityPresence(last, expr+"."+last.Field); {
+		case last.Slice:
+			fmt.Fprintf(&b, "%sn += len(%s.%s)\n", indent, expr, last.Field)
+		case presence != "":
+			fmt.Fprintf(&b, "%sif %s {\n%s\tn++\n%s}\n", indent, presence, indent, indent)
+		default:
+			fmt.Fprintf(&b, "%sn++\n", indent)
+		}
+	})
+	b.WriteString("\treturn\n}()")
+	return b.String(), nil
+}
+
+// resolveNodePath resolves the location path against the generated structs,
+// and returns the steps to the selected nodes. A nilled element is selected
+// as any other element.
+func (gen *CodeGenerator) resolveNodePath(ctx *goAssertionContext, path *xpathPath) ([]goIdentityStep, error) {
+	if ctx.complexType == nil {
+		return nil, fmt.Errorf("%s does not select a node", path.Text)
+	}
+	steps, err := gen.resolveAssertionPath(ctx, path)
+	if err != nil || len(steps) == 0 {
+		return steps, err
+	}
+	steps[len(steps)-1].Nillable = false
+	return steps, nil
+}
+
+// resolveAssertionPath resolves the location path against the generated
+// structs of the context node, and returns the steps to the selected node.
+func (gen *CodeGenerator) resolveAssertionPath(ctx *goAssertionContext, path *xpathPath) ([]goIdentityStep, error) {
+	var steps []goIdentityStep
+	node := ctx.complexType
+	for _, step := range path.Steps {
+		if node == nil {
+			return nil, fmt.Errorf("%s selects a child of a simple value", path.Text)
+		}
+		var found [][]goIdentityStep
+		kind, name := "element", trimNSPrefix(step.Name)
+		if step.Attribute {
+			kind, found = "attribute", gen.identityAttribute(node, name)
+		} else {
+			found = gen.identityChildren(node, name)
+		}
+		switch len(found) {
+		case 0:
+			return nil, fmt.Errorf("%s: %s has no %s %s", path.Text, node.Name, kind, name)
+		case 1:
+		default:
+			return nil, fmt.Errorf("%s: %s has more than one %s %s", path.Text, node.Name, kind, name)
+		}
+		steps = append(steps, found[0]...)
+		node = steps[len(steps)-1].ComplexType
+	}
+	return steps, nil
+}
+
+// parenthesizeXPath encloses the Go condition of the or operation in
+// parentheses, so that it may be used as an operand.
+func parenthesizeXPath(e xpathExpr, cond string) string {
+	if b, ok := e.(*xpathBinary); ok && b.Op == "or" {
+		return "(" + cond + ")"
+	}
+	return cond
+}
+
+// negateXPath returns the negation of the Go condition.
+func negateXPath(cond string) string {
+	switch {
+	case cond == "true":
+		return "false"
+	case cond == "false":
+		return "true"
+	case len(splitXPathConjunction(cond)) == 1 && strings.HasSuffix(cond, " != nil") && !strings.ContainsAny(strings.TrimSuffix(cond, " != nil"), " ()"):
+		return strings.TrimSuffix(cond, " != nil") + " == nil"
+	}
+	return "!(" + cond + ")"
+}
+
+// joinXPathGuard returns the conjunction of the non-empty conditions, each
+// operand of the conditions occurs only once in the conjunction.
+func joinXPathGuard(conds ...string) string {
+	var operands []string
+	seen := map[string]bool{}
+	for _, cond := range conds {
+		for _, operand := range splitXPathConjunction(cond) {
+			if !seen[operand] {
+				seen[operand] = true
+				operands = append(operands, operand)
+			}
+		}
+	}
+	return strings.Join(operands, " && ")
+}
+
+// splitXPathConjunction returns the operands of the Go condition, which are
+// joined by && outside of parentheses and string literals.
+func splitXPathConjunction(cond string) (operands []string) {
+	depth, quoted, start := 0, false, 0
+	for i := 0; i < len(cond); i++ {
+		switch c := cond[i]; {
+		case quoted && c == '\\':
+			i++
+		case c == '"':
+			quoted = !quoted
+		case quoted:
+		case c == '(' || c == '{':
+			depth++
+		case c == ')' || c == '}':
+			depth--
+		case depth == 0 && strings.HasPrefix(cond[i:], " && "):
+			operands = append(operands, cond[start:i])
+			i += len(" && ") - 1
+			start = i + 1
+		}
+	}
+	if start < len(cond) {
+		operands = append(operands, cond[start:])
+	}
+	return
+}
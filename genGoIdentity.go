@@ -0,0 +1,486 @@
+// Copyright 2026 The xgen Authors. All rights reserved. Use of this source
+// code is governed by a BSD-style license that can be found in the LICENSE
+// file.
+//
+// Package xgen written in pure Go providing a set of functions that allow you
+// to parse XSD (XML schema files). This library needs Go version 1.10 or
+// later.
+
+package xgen
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// goIdentityStep is a step from a generated struct to one of its fields on
+// the way to the nodes selected by the XPath expressions of an identity
+// constraint.
+type goIdentityStep struct {
+	Field       string       // the name of the struct field
+	Name        string       // the node name, empty for an embedded base type
+	Slice       bool         // the field holds a slice of nodes
+	Pointer     bool         // the field or the slice items are pointers
+	Nillable    bool         // the field or the slice items are Nillable values
+	ComplexType *ComplexType // the type of a complex node
+	Type        string       // the type of a simple node
+}
+
+// identityConstraintsOf returns the identity constraints checked by the
+// generated struct of the complex type, and the name of the declaring
+// element.
+func (gen *CodeGenerator) identityConstraintsOf(v *ComplexType) (element string, constraints []IdentityConstraint) {
+	element, constraints = v.Name, v.IdentityConstraints
+	for _, e := range gen.constrainedElements()[v.Name] {
+		if len(constraints) == 0 {
+			element = trimNSPrefix(e.Name)
+		}
+		constraints = append(constraints, e.IdentityConstraints...)
+	}
+	return
+}
+
+// constrainedElements returns the element declarations with identity
+// constraints by the names of their types, which are indexed on the first
+// call.
+func (gen *CodeGenerator) constrainedElements() map[string][]Element {
+	if gen.identityElements != nil {
+		return gen.identityElements
+	}
+	gen.identityElements = map[string][]Element{}
+	index := func(e Element) {
+		if len(e.IdentityConstraints) > 0 {
+			name := trimNSPrefix(e.TypeRef)
+			gen.identityElements[name] = append(gen.identityElements[name], e)
+		}
+	}
+	for _, ele := range gen.ProtoTree {
+		switch c := ele.(type) {
+		case *Element:
+			index(*c)
+		case *ComplexType:
+			for _, e := range c.Elements {
+				index(e)
+			}
+		}
+	}
+	return gen.identityElements
+}
+
+// generateIdentityValidator emits the ValidateIdentity method of the struct
+// by given name, which walks the decoded tree, builds the tables of key and
+// unique values, and reports duplicate values and keyref values which do not
+// refer to a value in the referenced table.
+func (gen *CodeGenerator) generateIdentityValidator(typeName string, v *ComplexType) {
+	element, constraints := gen.identityConstraintsOf(v)
+	if len(constraints) == 0 {
+		return
+	}
+	gen.ImportErrors, gen.ImportFmt = true, true
+	var b strings.Builder
+	fmt.Fprintf(&b, "\n// ValidateIdentity checks the identity constraints of the %s element.\n", element)
+	fmt.Fprintf(&b, "func (m *%s) ValidateIdentity() error {\n\tif m == nil {\n\t\treturn nil\n\t}\n\tvar errs []error\n", typeName)
+	tables := map[string]int{}
+	// Build the tables before checking the references to them
+	for _, keyrefs := range []bool{false, true} {
+		for _, c := range constraints {
+			if (c.Category == "keyref") != keyrefs {
+				continue
+			}
+			if err := gen.writeIdentityConstraint(&b, v, element, c, tables); err != nil {
+				gen.report(gen.unsupportedSeverity(), CodeUnsupported, fmt.Errorf("%s %s of %s is not checked: %w", c.Category, c.Name, element, err))
+			}
+		}
+	}
+	b.WriteString("\treturn errors.Join(errs...)\n}\n")
+	gen.Field.WriteString(b.String())
+}
+
+// writeIdentityConstraint writes the code which checks the identity
+// constraint against the nodes of the element.
+func (gen *CodeGenerator) writeIdentityConstraint(b *strings.Builder, v *ComplexType, element string, c IdentityConstraint, tables map[string]int) error {
+	selected, err := gen.resolveIdentitySelector(v, c.Selector)
+	if err != nil {
+		return err
+	}
+	if len(c.Fields) == 0 {
+		return fmt.Errorf("no field")
+	}
+	table, ok := len(tables), true
+	if c.Category == "keyref" {
+		if table, ok = tables[trimNSPrefix(c.Refer)]; !ok {
+			return fmt.Errorf("no key or unique constraint %s", c.Refer)
+		}
+		c.Refer = trimNSPrefix(c.Refer)
+	}
+	var fields [][][]goIdentityStep
+	for _, chain := range selected {
+		var chainFields [][]goIdentityStep
+		for _, field := range c.Fields {
+			steps, err := gen.resolveIdentityField(chain, field)
+			if err != nil {
+				return err
+			}
+			chainFields = append(chainFields, steps)
+		}
+		fields = append(fields, chainFields)
+	}
+	n := len(c.Fields)
+	keyExpr := "key"
+	if n == 1 {
+		keyExpr = "key[0]"
+	}
+	if c.Category != "keyref" {
+		tables[c.Name] = table
+		fmt.Fprintf(b, "\ttable%d := map[[%d]string]string{}\n", table, n)
+	}
+	for i, chain := range selected {
+		// The variables of the nodes are scoped by the blocks of the steps, a
+		// chain without such steps has a block of its own
+		depth, block := 1, true
+		for _, step := range chain {
+			if step.Slice || identityPresence(step, "") != "" {
+				block = false
+			}
+		}
+		if block {
+			depth++
+			b.WriteString("\t{\n")
+		}
+		counter := 0
+		gen.writeIdentitySteps(b, chain, "m", fmt.Sprintf("%q", "/"+element), depth, &counter, func(expr, path string, depth int) {
+			indent := strings.Repeat("\t", depth)
+			fmt.Fprintf(b, "%svar key [%d]string\n%sfields := 0\n", indent, n, indent)
+			for j, steps := range fields[i] {
+				gen.writeIdentitySteps(b, steps, expr, "", depth, &counter, func(value, _ string, depth int) {
+					fmt.Fprintf(b, "%skey[%d] = fmt.Sprint(%s)\n%sfields++\n", strings.Repeat("\t", depth), j, value, strings.Repeat("\t", depth))
+				})
+			}
+			switch c.Category {
+			case "keyref":
+				fmt.Fprintf(b, "%sif _, ok := table%d[key]; fields == %d && !ok {\n", indent, table, n)
+				fmt.Fprintf(b, "%s\terrs = append(errs, fmt.Errorf(\"%%s: keyref %s value %%q does not refer to a %s value\", %s, %s))\n", indent, c.Name, c.Refer, path, keyExpr)
+				fmt.Fprintf(b, "%s}\n", indent)
+			case "key":
+				fmt.Fprintf(b, "%sif fields < %d {\n", indent, n)
+				fmt.Fprintf(b, "%s\terrs = append(errs, fmt.Errorf(\"%%s: key %s value is missing\", %s))\n", indent, c.Name, path)
+				fmt.Fprintf(b, "%s} else if first, ok := table%d[key]; ok {\n", indent, table)
+				fmt.Fprintf(b, "%s\terrs = append(errs, fmt.Errorf(\"%%s: duplicate %s value %%q, first used at %%s\", %s, %s, first))\n", indent, c.Name, path, keyExpr)
+				fmt.Fprintf(b, "%s} else {\n%s\ttable%d[key] = %s\n%s}\n", indent, indent, table, path, indent)
+			default:
+				fmt.Fprintf(b, "%sif first, ok := table%d[key]; fields == %d && ok {\n", indent, table, n)
+				fmt.Fprintf(b, "%s\terrs = append(errs, fmt.Errorf(\"%%s: duplicate %s value %%q, first used at %%s\", %s, %s, first))\n", indent, c.Name, path, keyExpr)
+				fmt.Fprintf(b, "%s} else if fields == %d {\n%s\ttable%d[key] = %s\n%s}\n", indent, n, indent, table, path, indent)
+			}
+		})
+		if block {
+			b.WriteString("\t}\n")
+		}
+	}
+	return nil
+}
+
+// writeIdentitySteps writes the loops and conditions which visit the nodes
+// reached by the steps from the value of given expression, and calls visit
+// with the expression of the value and the path of each node. The paths are
+// not tracked when the path expression is empty.
+func (gen *CodeGenerator) writeIdentitySteps(b *strings.Builder, steps []goIdentityStep, expr, path string, depth int, counter *int, visit func(expr, path string, depth int)) {
+	if len(steps) == 0 {
+		visit(expr, path, depth)
+		return
+	}
+	step, indent := steps[0], strings.Repeat("\t", depth)
+	presence := identityPresence(step, "it")
+	if !step.Slice && presence == "" {
+		if path != "" && step.Name != "" {
+			path = joinIdentityPath(path, step.Name)
+		}
+		gen.writeIdentitySteps(b, steps[1:], expr+"."+step.Field, path, depth, counter, visit)
+		return
+	}
+	*counter++
+	it, p := fmt.Sprintf("it%d", *counter), path
+	presence = identityPresence(step, it)
+	if step.Slice {
+		index := "i"
+		if path == "" {
+			index = "_"
+		}
+		fmt.Fprintf(b, "%sfor %s, %s := range %s.%s {\n", indent, index, it, expr, step.Field)
+		if absence := identityAbsence(step, it); absence != "" {
+			fmt.Fprintf(b, "%s\tif %s {\n%s\t\tcontinue\n%s\t}\n", indent, absence, indent, indent)
+		}
+		if path != "" {
+			p = fmt.Sprintf("p%d", *counter)
+			if literal, err := strconv.Unquote(path); err == nil {
+				fmt.Fprintf(b, "%s\t%s := fmt.Sprintf(%q, i+1)\n", indent, p, literal+"/"+step.Name+"[%d]")
+			} else {
+				fmt.Fprintf(b, "%s\t%s := fmt.Sprintf(\"%%s/%s[%%d]\", %s, i+1)\n", indent, p, step.Name, path)
+			}
+		}
+	} else {
+		fmt.Fprintf(b, "%sif %s := %s.%s; %s {\n", indent, it, expr, step.Field, presence)
+		if path != "" && step.Name != "" {
+			p = fmt.Sprintf("p%d", *counter)
+			fmt.Fprintf(b, "%s\t%s := %s\n", indent, p, joinIdentityPath(path, step.Name))
+		}
+	}
+	value := it
+	if step.Nillable {
+		value += ".Value"
+	} else if step.Pointer && step.ComplexType == nil {
+		value = "*" + value
+	}
+	gen.writeIdentitySteps(b, steps[1:], value, p, depth+1, counter, visit)
+	fmt.Fprintf(b, "%s}\n", indent)
+}
+
+// joinIdentityPath returns the expression of the path of the node by given
+// name within the node of the path expression.
+func joinIdentityPath(path, name string) string {
+	if literal, err := strconv.Unquote(path); err == nil {
+		return strconv.Quote(literal + "/" + name)
+	}
+	return fmt.Sprintf("%s + %q", path, "/"+name)
+}
+
+// identityAbsence returns the condition under which the node held by the
+// variable is absent, or an empty string if the node is always present.
+func identityAbsence(step goIdentityStep, it string) string {
+	switch {
+	case step.Nillable && step.Pointer:
+		return it + " == nil || " + it + ".Nil"
+	case step.Nillable:
+		return it + ".Nil"
+	case step.Pointer:
+		return it + " == nil"
+	}
+	return ""
+}
+
+// identityPresence returns the condition under which the node held by the
+// variable is present, or an empty string if the node is always present.
+func identityPresence(step goIdentityStep, it string) string {
+	switch {
+	case step.Nillable && step.Pointer:
+		return it + " != nil && !" + it + ".Nil"
+	case step.Nillable:
+		return "!" + it + ".Nil"
+	case step.Pointer:
+		return it + " != nil"
+	}
+	return ""
+}
+
+// identityPaths returns the location paths of the restricted XPath expression
+// of an identity constraint, and whether each path starts with the
+// descendant axis.
+func identityPaths(xpath string) (paths [][]string, descendant []bool) {
+	for _, path := range strings.Split(xpath, "|") {
+		path = strings.TrimSpace(path)
+		d := strings.HasPrefix(path, ".//")
+		path = strings.TrimPrefix(path, ".//")
+		var steps []string
+		for _, step := range strings.Split(path, "/") {
+			step = strings.TrimPrefix(strings.TrimSpace(step), "child::")
+			if strings.HasPrefix(step, "attribute::") {
+				step = "@" + strings.TrimPrefix(step, "attribute::")
+			}
+			if step != "." && step != "" {
+				steps = append(steps, step)
+			}
+		}
+		paths, descendant = append(paths, steps), append(descendant, d)
+	}
+	return
+}
+
+// resolveIdentitySelector resolves the selector of an identity constraint
+// against the generated structs, and returns the steps to each selected node.
+func (gen *CodeGenerator) resolveIdentitySelector(v *ComplexType, xpath string) (chains [][]goIdentityStep, err error) {
+	paths, descendant := identityPaths(xpath)
+	for i, path := range paths {
+		current := [][]goIdentityStep{nil}
+		for j, name := range path {
+			if strings.HasPrefix(name, "@") {
+				return nil, fmt.Errorf("selector %q selects an attribute", xpath)
+			}
+			var next [][]goIdentityStep
+			for _, chain := range current {
+				node := v
+				if len(chain) > 0 {
+					node = chain[len(chain)-1].ComplexType
+				}
+				if node == nil {
+					continue
+				}
+				var found [][]goIdentityStep
+				if j == 0 && descendant[i] {
+					found = gen.identityDescendants(node, trimNSPrefix(name), map[*ComplexType]bool{node: true})
+				} else {
+					found = gen.identityChildren(node, trimNSPrefix(name))
+				}
+				for _, steps := range found {
+					next = append(next, append(append([]goIdentityStep{}, chain...), steps...))
+				}
+			}
+			current = next
+		}
+		chains = append(chains, current...)
+	}
+	if len(chains) == 0 {
+		return nil, fmt.Errorf("selector %q selects no element", xpath)
+	}
+	return
+}
+
+// resolveIdentityField resolves the field of an identity constraint against
+// the generated structs from the node reached by the steps of the selector,
+// and returns the steps to the value of the field.
+func (gen *CodeGenerator) resolveIdentityField(chain []goIdentityStep, xpath string) ([]goIdentityStep, error) {
+	paths, descendant := identityPaths(xpath)
+	if len(paths) != 1 || descendant[0] {
+		return nil, fmt.Errorf("field %q does not select a single value", xpath)
+	}
+	var node *ComplexType
+	if len(chain) > 0 {
+		node = chain[len(chain)-1].ComplexType
+	}
+	var steps []goIdentityStep
+	for i, name := range paths[0] {
+		if node == nil {
+			return nil, fmt.Errorf("field %q selects a child of a simple value", xpath)
+		}
+		var found [][]goIdentityStep
+		if strings.HasPrefix(name, "@") {
+			if i != len(paths[0])-1 {
+				return nil, fmt.Errorf("field %q selects a child of an attribute", xpath)
+			}
+			found = gen.identityAttribute(node, trimNSPrefix(name[1:]))
+		} else {
+			found = gen.identityChildren(node, trimNSPrefix(name))
+		}
+		if len(found) != 1 {
+			return nil, fmt.Errorf("field %q does not select a single value", xpath)
+		}
+		steps = append(steps, found[0]...)
+		node = steps[len(steps)-1].ComplexType
+	}
+	if node != nil {
+		value, ok := gen.identityValue(node)
+		if !ok {
+			return nil, fmt.Errorf("field %q selects an element without simple content", xpath)
+		}
+		steps = append(steps, value...)
+	}
+	for _, step := range steps {
+		if step.Slice {
+			return nil, fmt.Errorf("field %q selects repeated values", xpath)
+		}
+	}
+	return steps, nil
+}
+
+// identityChildren returns the steps to the child elements of the complex
+// type with given name, or to all child elements for the name test *.
+func (gen *CodeGenerator) identityChildren(v *ComplexType, name string) (found [][]goIdentityStep) {
+	_, elements := gen.complexTypeContent(v)
+	for _, e := range elements {
+		if name != "*" && trimNSPrefix(e.Name) != name {
+			continue
+		}
+		if head, _ := gen.findSubstitutionGroup(e); head != nil {
+			continue
+		}
+		complexType := gen.findComplexType(e.Type)
+		if st := gen.findSimpleType(trimNSPrefix(e.TypeRef)); st != nil {
+			complexType = nil
+		}
+		found = append(found, []goIdentityStep{{
+			Field:       genGoFieldName(e.Name),
+			Name:        trimNSPrefix(e.Name),
+			Slice:       e.Plural,
+			Pointer:     !e.Nillable && complexType != nil || e.Optional && !e.Plural,
+			Nillable:    e.Nillable,
+			ComplexType: complexType,
+			Type:        e.Type,
+		}})
+	}
+	return append(found, gen.identityInherited(v, func(base *ComplexType) [][]goIdentityStep {
+		return gen.identityChildren(base, name)
+	})...)
+}
+
+// identityAttribute returns the step to the attribute of the complex type
+// with given name.
+func (gen *CodeGenerator) identityAttribute(v *ComplexType, name string) [][]goIdentityStep {
+	attributes, _ := gen.complexTypeContent(v)
+	for _, a := range attributes {
+		if trimNSPrefix(a.Name) == name {
+			return [][]goIdentityStep{{{Field: genGoFieldName(a.Name), Name: "@" + name, Pointer: a.Optional, Type: a.Type}}}
+		}
+	}
+	return gen.identityInherited(v, func(base *ComplexType) [][]goIdentityStep {
+		return gen.identityAttribute(base, name)
+	})
+}
+
+// identityValue returns the steps to the text content of the complex type
+// with simple content.
+func (gen *CodeGenerator) identityValue(v *ComplexType) ([]goIdentityStep, bool) {
+	if v.Base == "" || v.Content == "complexContent" {
+		return nil, false
+	}
+	if isContentRestriction(v) || gen.findComplexType(v.Base) == nil {
+		return []goIdentityStep{{Field: "Value", Type: gen.simpleContentBase(v)}}, true
+	}
+	found := gen.identityInherited(v, func(base *ComplexType) [][]goIdentityStep {
+		if steps, ok := gen.identityValue(base); ok {
+			return [][]goIdentityStep{steps}
+		}
+		return nil
+	})
+	if len(found) != 1 {
+		return nil, false
+	}
+	return found[0], true
+}
+
+// identityInherited returns the steps found by given function in the base
+// type embedded in the struct of the complex type derived by extension.
+func (gen *CodeGenerator) identityInherited(v *ComplexType, find func(base *ComplexType) [][]goIdentityStep) (found [][]goIdentityStep) {
+	if v.Base == "" || isContentRestriction(v) {
+		return
+	}
+	base := gen.findComplexType(v.Base)
+	if base == nil || base == v {
+		return
+	}
+	embedded := goIdentityStep{Field: strings.TrimPrefix(gen.genGoFieldType(v.Base), "*"), Pointer: true, ComplexType: base}
+	for _, steps := range find(base) {
+		found = append(found, append([]goIdentityStep{embedded}, steps...))
+	}
+	return
+}
+
+// identityDescendants returns the steps to the descendant elements of the
+// complex type with given name, the types already visited are not entered
+// again.
+func (gen *CodeGenerator) identityDescendants(v *ComplexType, name string, visited map[*ComplexType]bool) (found [][]goIdentityStep) {
+	for _, steps := range gen.identityChildren(v, "*") {
+		last := steps[len(steps)-1]
+		if name == "*" || last.Name == name {
+			found = append(found, steps)
+		}
+		if last.ComplexType == nil || visited[last.ComplexType] {
+			continue
+		}
+		visited[last.ComplexType] = true
+		for _, rest := range gen.identityDescendants(last.ComplexType, name, visited) {
+			found = append(found, append(append([]goIdentityStep{}, steps...), rest...))
+		}
+		delete(visited, last.ComplexType)
+	}
+	return
+}
@@ -0,0 +1,159 @@
+// Copyright 2026 The xgen Authors. All rights reserved. Use of this source
+// code is governed by a BSD-style license that can be found in the LICENSE
+// file.
+//
+// Package xgen written in pure Go providing a set of functions that allow you
+// to parse XSD (XML schema files). This library needs Go version 1.10 or
+// later.
+
+package xgen
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// generateMarshaler emits the MarshalXML method of the struct by given name
+// for the anonymous type of a top-level element when the namespaces are
+// written with prefixes. The encoding/xml package declares the namespace of
+// every qualified element on the element itself, the method declares each
+// namespace once on the document element instead, with the prefix of the
+// namespace in NamespacePrefixes.
+func (gen *CodeGenerator) generateMarshaler(typeName string, v *ComplexType) {
+	if !gen.PrefixNamespaces || !v.Global {
+		return
+	}
+	gen.useGoHelper(goPrefixWriterFunc, goPrefixWriterSource)
+	if !gen.sharedHelpers {
+		gen.ImportBytes, gen.ImportFmt, gen.ImportIO = true, true, true
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "\n// MarshalXML writes the %s element with the namespaces declared by prefixes.\n", v.Name)
+	fmt.Fprintf(&b, "func (m %s) MarshalXML(e *xml.Encoder, start xml.StartElement) error {\n", typeName)
+	fmt.Fprintf(&b, "\ttype plain %s\n", typeName)
+	fmt.Fprintf(&b, "\tif start.Name.Space == \"\" {\n\t\tstart.Name = xml.Name{Space: %q, Local: %q}\n\t}\n", v.Namespace, v.Name)
+	fmt.Fprintf(&b, "\treturn %s(e, start, plain(m), map[string]string{\n", goPrefixWriterFunc)
+	namespaces := make([]string, 0, len(gen.NamespacePrefixes))
+	for namespace := range gen.NamespacePrefixes {
+		namespaces = append(namespaces, namespace)
+	}
+	sort.Strings(namespaces)
+	for _, namespace := range namespaces {
+		fmt.Fprintf(&b, "\t\t%q: %q,\n", namespace, gen.NamespacePrefixes[namespace])
+	}
+	b.WriteString("\t})\n}\n")
+	gen.ImportEncodingXML = true
+	gen.Field.WriteString(b.String())
+}
+
+// goPrefixWriterFunc is the name of the function writing the namespaces with
+// prefixes declared by goPrefixWriterSource.
+const goPrefixWriterFunc = "prefixWriter"
+
+// goPrefixWriterSource is the declaration of the function used by the
+// MarshalXML methods of the structs to write the namespaces with prefixes,
+// which is a helper declared once per package like the helper types. It
+// encodes the value by the encoding/xml package, and writes the tokens again
+// with the names qualified by prefixes, declared on the first element. The
+// unprefixed elements are in the namespace they declare as the default one
+// and in no namespace otherwise, as the encoding/xml package writes them.
+var goPrefixWriterSource = `
+// prefixWriter writes the element encoded from the value with the namespaces
+// qualified by the prefixes, the namespaces without a prefix are given the
+// prefixes ns1, ns2 and so on.
+func prefixWriter(e *xml.Encoder, start xml.StartElement, v interface{}, prefixes map[string]string) error {
+	var buf bytes.Buffer
+	if err := xml.NewEncoder(&buf).EncodeElement(v, start); err != nil {
+		return err
+	}
+	var namespaces []string
+	declared, used := map[string]string{}, map[string]bool{}
+	qualify := func(space, local string) xml.Name {
+		switch space {
+		case "":
+			return xml.Name{Local: local}
+		case "http://www.w3.org/XML/1998/namespace":
+			return xml.Name{Local: "xml:" + local}
+		}
+		prefix, ok := declared[space]
+		if !ok {
+			prefix = prefixes[space]
+			for n := 1; prefix == "" || used[prefix]; n++ {
+				prefix = fmt.Sprintf("ns%d", n)
+			}
+			declared[space], used[prefix] = prefix, true
+			namespaces = append(namespaces, space)
+		}
+		return xml.Name{Local: prefix + ":" + local}
+	}
+	resolve := func(scope map[string]string, name xml.Name, space string) xml.Name {
+		if name.Space == "" {
+			return qualify(space, name.Local)
+		}
+		if name.Space == "xml" {
+			return qualify("http://www.w3.org/XML/1998/namespace", name.Local)
+		}
+		if space, ok := scope[name.Space]; ok {
+			return qualify(space, name.Local)
+		}
+		return xml.Name{Local: name.Space + ":" + name.Local}
+	}
+	var tokens []xml.Token
+	var names []xml.Name
+	scopes := []map[string]string{{}}
+	d := xml.NewDecoder(&buf)
+	for {
+		token, err := d.RawToken()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			var space string
+			scope := map[string]string{}
+			for prefix, namespace := range scopes[len(scopes)-1] {
+				scope[prefix] = namespace
+			}
+			for _, attr := range t.Attr {
+				if attr.Name.Space == "xmlns" {
+					scope[attr.Name.Local] = attr.Value
+				}
+				if attr.Name.Space == "" && attr.Name.Local == "xmlns" {
+					space = attr.Value
+				}
+			}
+			scopes = append(scopes, scope)
+			element := xml.StartElement{Name: resolve(scope, t.Name, space)}
+			for _, attr := range t.Attr {
+				if attr.Name.Space == "xmlns" || attr.Name.Space == "" && attr.Name.Local == "xmlns" {
+					continue
+				}
+				element.Attr = append(element.Attr, xml.Attr{Name: resolve(scope, attr.Name, ""), Value: attr.Value})
+			}
+			names = append(names, element.Name)
+			token = element
+		case xml.EndElement:
+			token = xml.EndElement{Name: names[len(names)-1]}
+			scopes, names = scopes[:len(scopes)-1], names[:len(names)-1]
+		default:
+			token = xml.CopyToken(token)
+		}
+		tokens = append(tokens, token)
+	}
+	root := tokens[0].(xml.StartElement)
+	for _, space := range namespaces {
+		root.Attr = append(root.Attr, xml.Attr{Name: xml.Name{Local: "xmlns:" + declared[space]}, Value: space})
+	}
+	tokens[0] = root
+	for _, token := range tokens {
+		if err := e.EncodeToken(token); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+`
@@ -0,0 +1,126 @@
+// Copyright 2026 The xgen Authors. All rights reserved. Use of this source
+// code is governed by a BSD-style license that can be found in the LICENSE
+// file.
+//
+// Package xgen written in pure Go providing a set of functions that allow you
+// to parse XSD (XML schema files). This library needs Go version 1.10 or
+// later.
+
+package xgen
+
+import (
+	"fmt"
+	"strings"
+)
+
+// goSubstitutionField holds a struct field which refers to the head element
+// of a substitution group.
+type goSubstitutionField struct {
+	Name      string
+	Plural    bool
+	Interface string
+	Members   []Element
+}
+
+// schemaSet returns the schema set the proto tree is generated from, which
+// holds the substitution groups of all schemas. A code generator used on its
+// own sees the schema of its proto tree only.
+func (gen *CodeGenerator) schemaSet() *SchemaSet {
+	if gen.set == nil {
+		gen.set = NewSchemaSet(map[string][]Component{gen.File: gen.ProtoTree})
+	}
+	return gen.set
+}
+
+// findSubstitutionGroup returns the head element declaration referred to by
+// given element and the elements which may appear in place of it. It returns
+// nil when the element is not an abstract element or the head of a
+// substitution group declared in the schema set.
+func (gen *CodeGenerator) findSubstitutionGroup(e Element) (head *Element, members []Element) {
+	head = gen.schemaSet().Element(QName{Space: e.Namespace, Local: trimNSPrefix(e.Name)})
+	if head == nil || !head.Abstract && len(head.Substitutes) == 0 {
+		return nil, nil
+	}
+	if !head.Abstract {
+		members = append(members, *head)
+	}
+	members = append(members, head.Substitutes...)
+	return
+}
+
+// goSubstitutionGroup returns the name of the interface implemented by the
+// members of the substitution group headed by the element by given name.
+func goSubstitutionGroup(head string) string {
+	return genGoFieldName(head) + "Group"
+}
+
+// goSubstitutionMember returns the name of the element type of a
+// substitution group member by given name.
+func goSubstitutionMember(member string) string {
+	return genGoFieldName(member) + "Element"
+}
+
+// goSubstitutionTypes emits the types of the substitution groups declared by
+// given top-level element: the interface of the group it is the head of, and
+// its element type implementing the interfaces of the groups it is a member
+// of. The types are declared once by the schema of the element, and the code
+// of the other schemas refers to them.
+func (gen *CodeGenerator) goSubstitutionTypes(v *Element) {
+	set := gen.schemaSet()
+	if set.Element(QName{Space: v.Namespace, Local: v.Name}) != v {
+		return
+	}
+	var groups []string
+	if v.Abstract || len(v.Substitutes) > 0 {
+		name := goSubstitutionGroup(v.Name)
+		gen.addGoHelper(name, fmt.Sprintf("\n// %s is implemented by the elements of the %s substitution group.\ntype %s interface {\n\tis%s()\n}\n",
+			name, v.Name, name, name))
+		groups = append(groups, name)
+	}
+	seen := map[QName]bool{{Space: v.Namespace, Local: v.Name}: true}
+	for name := v.SubstitutionGroup; !seen[name]; {
+		head := set.Element(name)
+		if head == nil {
+			break
+		}
+		seen[name] = true
+		groups = append(groups, goSubstitutionGroup(head.Name))
+		name = head.SubstitutionGroup
+	}
+	if v.Abstract || len(groups) == 0 {
+		return
+	}
+	gen.ImportEncodingXML = true
+	elementType := goSubstitutionMember(v.Name)
+	valueType := gen.genGoFieldType(gen.baseType(trimNSPrefix(v.Type)))
+	declaration, field := "\t%s\n", strings.TrimPrefix(valueType, "*")
+	if field == valueType {
+		declaration, field = "\tValue\t%s\n", "Value"
+	}
+	if valueType == "time.Time" {
+		gen.ImportTime = true
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "\n// %s is the %s element.\ntype %s struct {\n", elementType, v.Name, elementType)
+	fmt.Fprintf(&b, declaration, strings.TrimPrefix(valueType, "*"))
+	b.WriteString("}\n")
+	fmt.Fprintf(&b, "\n// MarshalXML writes the %s element.\n", v.Name)
+	fmt.Fprintf(&b, "func (v %s) MarshalXML(e *xml.Encoder, start xml.StartElement) error {\n", elementType)
+	fmt.Fprintf(&b, "\treturn e.EncodeElement(v.%s, xml.StartElement{Name: %s})\n}\n", field, goXMLNameLiteral(v.Namespace, v.Name))
+	fmt.Fprintf(&b, "\n// UnmarshalXML reads the %s element.\n", v.Name)
+	fmt.Fprintf(&b, "func (v *%s) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {\n", elementType)
+	fmt.Fprintf(&b, "\treturn d.DecodeElement(&v.%s, &start)\n}\n", field)
+	for _, group := range groups {
+		fmt.Fprintf(&b, "\nfunc (%s) is%s() {}\n", elementType, group)
+	}
+	gen.addGoHelper(elementType, b.String())
+}
+
+// goXMLNameLiteral returns the Go expression of the xml.Name by given
+// namespace and local name.
+func goXMLNameLiteral(space, local string) string {
+	if space == "" {
+		return fmt.Sprintf("xml.Name{Local: %q}", local)
+	}
+	return fmt.Sprintf("xml.Name{Space: %q, Local: %q}", space, local)
+}
@@ -0,0 +1,141 @@
+// Copyright 2026 The xgen Authors. All rights reserved. Use of this source
+// code is governed by a BSD-style license that can be found in the LICENSE
+// file.
+//
+// Package xgen written in pure Go providing a set of functions that allow you
+// to parse XSD (XML schema files). This library needs Go version 1.10 or
+// later.
+
+package xgen
+
+import (
+	"fmt"
+	"strings"
+)
+
+// goOccurrencesField is the name of the unexported struct field which counts
+// the occurrences of the child elements of an all group while decoding.
+const goOccurrencesField = "occurrences"
+
+// countsOccurrences reports whether the generated struct for the complex type
+// counts the occurrences of its child elements.
+func countsOccurrences(v *ComplexType) bool {
+	return v.All != nil && len(v.All.Elements) > 0
+}
+
+// generateUnmarshaler emits the UnmarshalXML method of the struct by given
+// name when its child elements need to be inspected while decoding: it
+// decodes the members of substitution groups into the element types held by
+// their fields, and counts the occurrences of the child elements of an all
+// group for validation.
+func (gen *CodeGenerator) generateUnmarshaler(typeName string, v *ComplexType, fields []goSubstitutionField) {
+	counted := countsOccurrences(v)
+	if len(fields) == 0 && !counted {
+		return
+	}
+	gen.useGoHelper(goChildReaderType, goChildReaderSource)
+	var b strings.Builder
+	fmt.Fprintf(&b, "\n// UnmarshalXML decodes the element into the fields of %s.\n", typeName)
+	fmt.Fprintf(&b, "func (m *%s) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {\n", typeName)
+	fmt.Fprintf(&b, "\ttype plain %s\n", typeName)
+	if counted {
+		fmt.Fprintf(&b, "\tm.%s = map[string]int{}\n", goOccurrencesField)
+	}
+	fmt.Fprintf(&b, "\tr := &%s{d: d, start: &start, decode: func(t xml.StartElement) (bool, error) {\n", goChildReaderType)
+	if counted {
+		fmt.Fprintf(&b, "\t\tm.%s[t.Name.Local]++\n", goOccurrencesField)
+	}
+	if len(fields) > 0 {
+		b.WriteString("\t\tswitch t.Name {\n")
+		seen := map[QName]bool{}
+		for _, field := range fields {
+			for _, member := range field.Members {
+				name := QName{Space: member.Namespace, Local: member.Name}
+				if seen[name] {
+					continue
+				}
+				seen[name] = true
+				fmt.Fprintf(&b, "\t\tcase %s:\n\t\t\tv := new(%s)\n", goXMLNameLiteral(member.Namespace, member.Name), goSubstitutionMember(member.Name))
+				if field.Plural {
+					fmt.Fprintf(&b, "\t\t\tm.%s = append(m.%s, v)\n", field.Name, field.Name)
+				} else {
+					fmt.Fprintf(&b, "\t\t\tm.%s = v\n", field.Name)
+				}
+				b.WriteString("\t\t\treturn true, d.DecodeElement(v, &t)\n")
+			}
+		}
+		b.WriteString("\t\t}\n")
+	}
+	b.WriteString("\t\treturn false, nil\n\t}}\n")
+	b.WriteString("\treturn xml.NewTokenDecoder(r).DecodeElement((*plain)(m), nil)\n}\n")
+	gen.ImportEncodingXML = true
+	gen.Field.WriteString(b.String())
+}
+
+// goChildReaderType is the name of the token reader declared by
+// goChildReaderSource.
+const goChildReaderType = "childReader"
+
+// goChildReaderSource is the declaration of the token reader used by the
+// generated UnmarshalXML methods, it hands the child elements of the element
+// being decoded to the decode function and passes the tokens of the elements
+// it does not take through.
+var goChildReaderSource = `
+// childReader reads the element which starts with start from d, its child
+// elements are handed to decode, and the ones taken by decode are left out
+// of the tokens it returns.
+type childReader struct {
+	d      *xml.Decoder
+	start  *xml.StartElement
+	depth  int
+	decode func(xml.StartElement) (bool, error)
+}
+
+// Token returns the next token of the element which is not taken by decode.
+func (r *childReader) Token() (xml.Token, error) {
+	if r.start != nil {
+		start := *r.start
+		r.start, r.depth = nil, 1
+		return start, nil
+	}
+	for {
+		tok, err := r.d.Token()
+		if err != nil {
+			return tok, err
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			if r.depth == 1 {
+				taken, err := r.decode(t)
+				if err != nil {
+					return nil, err
+				}
+				if taken {
+					continue
+				}
+			}
+			r.depth++
+		case xml.EndElement:
+			r.depth--
+		}
+		return xml.CopyToken(tok), nil
+	}
+}
+`
+
+// allElementsOnce returns the local names of the child elements of the all
+// group of the complex type which may occur at most once.
+func (gen *CodeGenerator) allElementsOnce(v *ComplexType, elements []Element) (names []string) {
+	if !countsOccurrences(v) {
+		return
+	}
+	for _, name := range v.All.Elements {
+		for _, element := range elements {
+			if element.Name == name && !element.Plural {
+				names = append(names, trimNSPrefix(name))
+				break
+			}
+		}
+	}
+	return
+}
@@ -10,8 +10,6 @@ package xgen
 
 import (
 	"fmt"
-	"os"
-	"reflect"
 	"strings"
 )
 
@@ -32,19 +30,13 @@ var javaBuildInType = map[string]bool{
 // GenJava generate Java programming language source code for XML schema
 // definition files.
 func (gen *CodeGenerator) GenJava() error {
-	fieldNameCount = make(map[string]int)
+	gen.fieldNameCount = make(map[string]int)
 	for _, ele := range gen.ProtoTree {
 		if ele == nil {
 			continue
 		}
-		funcName := fmt.Sprintf("Java%s", reflect.TypeOf(ele).String()[6:])
-		callFuncByName(gen, funcName, []reflect.Value{reflect.ValueOf(ele)})
+		ele.Accept(javaVisitor{gen})
 	}
-	f, err := os.Create(gen.FileWithExtension(".java"))
-	if err != nil {
-		return err
-	}
-	defer f.Close()
 	packageName := gen.Package
 	if packageName == "" {
 		packageName = "schema"
@@ -58,12 +50,24 @@ import javax.xml.bind.annotation.XmlElement;
 import javax.xml.bind.annotation.XmlSchemaType;
 import javax.xml.bind.annotation.XmlType;
 import javax.xml.bind.annotation.XmlValue;`
+	importPackage += gen.mappedImports(func(mapping TypeMapping) string {
+		return fmt.Sprintf("\nimport %s;", mapping.Import)
+	})
 
-	f.Write([]byte(fmt.Sprintf("%s\n\npackage %s;\n\n%s\n%s", copyright, packageName, importPackage, gen.Field)))
-	return err
+	return gen.writeFile(".java", []byte(fmt.Sprintf("%s\n\npackage %s;\n\n%s\n%s", copyright, packageName, importPackage, gen.Field.String())))
 }
 
-func genJavaFieldName(name string, unique bool) (fieldName string) {
+// javaVisitor generates Java code for the schema components it visits.
+type javaVisitor struct{ *CodeGenerator }
+
+func (v javaVisitor) VisitSimpleType(c *SimpleType)         { v.JavaSimpleType(c) }
+func (v javaVisitor) VisitComplexType(c *ComplexType)       { v.JavaComplexType(c) }
+func (v javaVisitor) VisitElement(c *Element)               { v.JavaElement(c) }
+func (v javaVisitor) VisitAttribute(c *Attribute)           { v.JavaAttribute(c) }
+func (v javaVisitor) VisitGroup(c *Group)                   { v.JavaGroup(c) }
+func (v javaVisitor) VisitAttributeGroup(c *AttributeGroup) { v.JavaAttributeGroup(c) }
+
+func genJavaFieldName(name string) (fieldName string) {
 	for _, str := range strings.Split(name, ":") {
 		fieldName += MakeFirstUpperCase(str)
 	}
@@ -73,17 +77,11 @@ func genJavaFieldName(name string, unique bool) (fieldName string) {
 	}
 	fieldName = tmp
 	fieldName = strings.Replace(fieldName, "-", "", -1)
-	if unique {
-		fieldNameCount[fieldName]++
-		if count := fieldNameCount[fieldName]; count != 1 {
-			fieldName = fmt.Sprintf("%s%d", fieldName, count)
-		}
-	}
 	return
 }
 
-func genJavaFieldType(name string) string {
-	if _, ok := javaBuildInType[name]; ok {
+func (gen *CodeGenerator) genJavaFieldType(name string) string {
+	if _, ok := javaBuildInType[name]; ok || gen.isMappedType(name) {
 		return name
 	}
 	var fieldType string
@@ -102,10 +100,10 @@ func genJavaFieldType(name string) string {
 func (gen *CodeGenerator) JavaSimpleType(v *SimpleType) {
 	if v.List {
 		if _, ok := gen.StructAST[v.Name]; !ok {
-			fieldType := genJavaFieldType(getBasefromSimpleType(trimNSPrefix(v.Base), gen.ProtoTree))
-			content := fmt.Sprintf("\tprotected List<%s> %s;\n", fieldType, genJavaFieldName(v.Name, false))
+			fieldType := gen.genJavaFieldType(gen.baseType(trimNSPrefix(v.Base)))
+			content := fmt.Sprintf("\tprotected List<%s> %s;\n", fieldType, genJavaFieldName(v.Name))
 			gen.StructAST[v.Name] = content
-			gen.Field += fmt.Sprintf("\n@XmlAccessorType(XmlAccessType.FIELD)\n@XmlAttribute(required = true, name = \"%s\")\npublic class %s {\n%s}\n", v.Name, genJavaFieldName(v.Name, true), gen.StructAST[v.Name])
+			fmt.Fprintf(&gen.Field, "\n@XmlAccessorType(XmlAccessType.FIELD)\n@XmlAttribute(required = true, name = \"%s\")\npublic class %s {\n%s}\n", v.Name, gen.uniqueName(genJavaFieldName(v.Name)), gen.StructAST[v.Name])
 			return
 		}
 	}
@@ -117,24 +115,24 @@ func (gen *CodeGenerator) JavaSimpleType(v *SimpleType) {
 				memberType := member.value
 
 				if memberType == "" { // fix order issue
-					memberType = getBasefromSimpleType(memberName, gen.ProtoTree)
+					memberType = gen.baseType(memberName)
 				}
-				fieldType := genJavaFieldType(memberType)
-				content += fmt.Sprintf("\t@XmlElement(required = true)\n\tprotected %s %s;\n", fieldType, genJavaFieldName(memberName, false))
+				fieldType := gen.genJavaFieldType(memberType)
+				content += fmt.Sprintf("\t@XmlElement(required = true)\n\tprotected %s %s;\n", fieldType, genJavaFieldName(memberName))
 			}
 			content += "}\n"
 			gen.StructAST[v.Name] = content
-			fieldName := genJavaFieldName(v.Name, true)
-			gen.Field += fmt.Sprintf("%spublic class %s%s", genFieldComment(fieldName, v.Doc, "//"), fieldName, gen.StructAST[v.Name])
+			fieldName := gen.uniqueName(genJavaFieldName(v.Name))
+			fmt.Fprintf(&gen.Field, "%spublic class %s%s", genFieldComment(fieldName, v.Doc, "//"), fieldName, gen.StructAST[v.Name])
 		}
 		return
 	}
 	if _, ok := gen.StructAST[v.Name]; !ok {
-		fieldType := genJavaFieldType(getBasefromSimpleType(trimNSPrefix(v.Base), gen.ProtoTree))
-		content := fmt.Sprintf("\tprotected %s %s;\n", fieldType, genJavaFieldName(v.Name, false))
+		fieldType := gen.genJavaFieldType(gen.baseType(trimNSPrefix(v.Base)))
+		content := fmt.Sprintf("\tprotected %s %s;\n", fieldType, genJavaFieldName(v.Name))
 		gen.StructAST[v.Name] = content
-		fieldName := genJavaFieldName(v.Name, true)
-		gen.Field += fmt.Sprintf("%s@XmlAccessorType(XmlAccessType.FIELD)\n@XmlAttribute(required = true, name = \"%s\")\npublic class %s {\n%s}\n", genFieldComment(fieldName, v.Doc, "//"), v.Name, fieldName, gen.StructAST[v.Name])
+		fieldName := gen.uniqueName(genJavaFieldName(v.Name))
+		fmt.Fprintf(&gen.Field, "%s@XmlAccessorType(XmlAccessType.FIELD)\n@XmlAttribute(required = true, name = \"%s\")\npublic class %s {\n%s}\n", genFieldComment(fieldName, v.Doc, "//"), v.Name, fieldName, gen.StructAST[v.Name])
 	}
 }
 
@@ -144,28 +142,29 @@ func (gen *CodeGenerator) JavaComplexType(v *ComplexType) {
 	if _, ok := gen.StructAST[v.Name]; !ok {
 		content := " {\n"
 		for _, attrGroup := range v.AttributeGroup {
-			fieldType := getBasefromSimpleType(trimNSPrefix(attrGroup.Ref), gen.ProtoTree)
-			content += fmt.Sprintf("\t@XmlElement(required = true)\n\tprotected %s %s;\n", genJavaFieldType(fieldType), genJavaFieldName(attrGroup.Name, false))
+			fieldType := gen.baseType(trimNSPrefix(attrGroup.Ref))
+			content += fmt.Sprintf("\t@XmlElement(required = true)\n\tprotected %s %s;\n", gen.genJavaFieldType(fieldType), genJavaFieldName(attrGroup.Name))
 		}
 
-		for _, attribute := range v.Attributes {
-			fieldType := genJavaFieldType(getBasefromSimpleType(trimNSPrefix(attribute.Type), gen.ProtoTree))
+		attributes, elements := gen.complexTypeContent(v)
+		for _, attribute := range attributes {
+			fieldType := gen.genJavaFieldType(gen.baseType(trimNSPrefix(attribute.Type)))
 			required := `required = true, `
 			if attribute.Optional {
 				required = ""
 			}
-			content += fmt.Sprintf("\t@XmlAttribute(%sname = \"%s\")\n\tprotected %s %sAttr;\n", required, attribute.Name, fieldType, genJavaFieldName(attribute.Name, false))
+			content += fmt.Sprintf("\t@XmlAttribute(%sname = \"%s\")\n\tprotected %s %sAttr;\n", required, attribute.Name, fieldType, genJavaFieldName(attribute.Name))
 		}
 		for _, group := range v.Groups {
-			fieldType := genJavaFieldType(getBasefromSimpleType(trimNSPrefix(group.Ref), gen.ProtoTree))
+			fieldType := gen.genJavaFieldType(gen.baseType(trimNSPrefix(group.Ref)))
 			if group.Plural {
 				fieldType = fmt.Sprintf("List<%s>", fieldType)
 			}
-			content += fmt.Sprintf("\tprotected %s %s;\n", fieldType, genJavaFieldName(group.Name, false))
+			content += fmt.Sprintf("\tprotected %s %s;\n", fieldType, genJavaFieldName(group.Name))
 		}
 
-		for _, element := range v.Elements {
-			fieldType := genJavaFieldType(getBasefromSimpleType(trimNSPrefix(element.Type), gen.ProtoTree))
+		for _, element := range elements {
+			fieldType := gen.genJavaFieldType(gen.baseType(trimNSPrefix(element.Type)))
 			if element.Plural {
 				fieldType = fmt.Sprintf("List<%s>", fieldType)
 			}
@@ -173,31 +172,39 @@ func (gen *CodeGenerator) JavaComplexType(v *ComplexType) {
 			if element.Optional {
 				required = ""
 			}
-			content += fmt.Sprintf("\t@XmlElement(%sname = \"%s\")\n\tprotected %s %s;\n", required, element.Name, fieldType, genJavaFieldName(element.Name, false))
+			if element.Nillable {
+				required += `nillable = true, `
+			}
+			content += fmt.Sprintf("\t@XmlElement(%sname = \"%s\")\n\tprotected %s %s;\n", required, element.Name, fieldType, genJavaFieldName(element.Name))
 		}
 
-		if len(v.Base) > 0 && isBuiltInJavaType(v.Base) {
-			fieldType := genJavaFieldType(getBasefromSimpleType(trimNSPrefix(v.Base), gen.ProtoTree))
+		if isContentRestriction(v) {
+			if v.Content == "simpleContent" {
+				fieldType := gen.genJavaFieldType(gen.baseType(trimNSPrefix(gen.simpleContentBase(v))))
+				content += fmt.Sprintf("\t@XmlValue\n\tprotected %s value;\n", fieldType)
+			}
+		} else if len(v.Base) > 0 && gen.isBuiltInJavaType(v.Base) && v.Content != "complexContent" {
+			fieldType := gen.genJavaFieldType(gen.baseType(trimNSPrefix(v.Base)))
 			content += fmt.Sprintf("\t@XmlValue\n\tprotected %s value;\n", fieldType)
 		}
 
 		content += "}\n"
 		gen.StructAST[v.Name] = content
-		fieldName := genJavaFieldName(v.Name, true)
+		fieldName := gen.uniqueName(genJavaFieldName(v.Name))
 
 		typeExtension := ""
-		if len(v.Base) > 0 && !isBuiltInJavaType(v.Base) {
-			fieldType := genJavaFieldType(getBasefromSimpleType(trimNSPrefix(v.Base), gen.ProtoTree))
+		if len(v.Base) > 0 && !gen.isBuiltInJavaType(v.Base) && !isContentRestriction(v) {
+			fieldType := gen.genJavaFieldType(gen.baseType(trimNSPrefix(v.Base)))
 			typeExtension = fmt.Sprintf(" extends %s ", fieldType)
 		}
 
-		gen.Field += fmt.Sprintf("%spublic class %s%s%s", genFieldComment(fieldName, v.Doc, "//"), fieldName, typeExtension, gen.StructAST[v.Name])
+		fmt.Fprintf(&gen.Field, "%spublic class %s%s%s", genFieldComment(fieldName, v.Doc, "//"), fieldName, typeExtension, gen.StructAST[v.Name])
 	}
 }
 
-func isBuiltInJavaType(typeName string) bool {
+func (gen *CodeGenerator) isBuiltInJavaType(typeName string) bool {
 	_, builtIn := javaBuildInType[typeName]
-	return builtIn
+	return builtIn || gen.isMappedType(typeName)
 }
 
 // JavaGroup generates code for group XML schema in Java language syntax.
@@ -205,25 +212,25 @@ func (gen *CodeGenerator) JavaGroup(v *Group) {
 	if _, ok := gen.StructAST[v.Name]; !ok {
 		content := " {\n"
 		for _, element := range v.Elements {
-			fieldType := genJavaFieldType(getBasefromSimpleType(trimNSPrefix(element.Type), gen.ProtoTree))
+			fieldType := gen.genJavaFieldType(gen.baseType(trimNSPrefix(element.Type)))
 			if element.Plural {
 				fieldType = fmt.Sprintf("List<%s>", fieldType)
 			}
-			content += fmt.Sprintf("\t@XmlElement(required = true, name = \"%s\")\n\tprotected %s %s;\n", element.Name, fieldType, genJavaFieldName(element.Name, false))
+			content += fmt.Sprintf("\t@XmlElement(required = true, name = \"%s\")\n\tprotected %s %s;\n", element.Name, fieldType, genJavaFieldName(element.Name))
 		}
 
 		for _, group := range v.Groups {
-			fieldType := genJavaFieldType(getBasefromSimpleType(trimNSPrefix(group.Ref), gen.ProtoTree))
+			fieldType := gen.genJavaFieldType(gen.baseType(trimNSPrefix(group.Ref)))
 			if group.Plural {
 				fieldType = fmt.Sprintf("List<%s>", fieldType)
 			}
-			content += fmt.Sprintf("\tprotected %s %s;\n", fieldType, genJavaFieldName(group.Name, false))
+			content += fmt.Sprintf("\tprotected %s %s;\n", fieldType, genJavaFieldName(group.Name))
 		}
 
 		content += "}\n"
 		gen.StructAST[v.Name] = content
-		fieldName := genJavaFieldName(v.Name, true)
-		gen.Field += fmt.Sprintf("%spublic class %s%s", genFieldComment(fieldName, v.Doc, "//"), fieldName, gen.StructAST[v.Name])
+		fieldName := gen.uniqueName(genJavaFieldName(v.Name))
+		fmt.Fprintf(&gen.Field, "%spublic class %s%s", genFieldComment(fieldName, v.Doc, "//"), fieldName, gen.StructAST[v.Name])
 	}
 }
 
@@ -237,38 +244,38 @@ func (gen *CodeGenerator) JavaAttributeGroup(v *AttributeGroup) {
 			if attribute.Optional {
 				required = ""
 			}
-			fieldType := genJavaFieldType(getBasefromSimpleType(trimNSPrefix(attribute.Type), gen.ProtoTree))
-			content += fmt.Sprintf("\t@XmlAttribute(name = \"%s\"%s)\n\tprotected %sAttr %s;\n", attribute.Name, required, fieldType, genJavaFieldName(attribute.Name, false))
+			fieldType := gen.genJavaFieldType(gen.baseType(trimNSPrefix(attribute.Type)))
+			content += fmt.Sprintf("\t@XmlAttribute(name = \"%s\"%s)\n\tprotected %sAttr %s;\n", attribute.Name, required, fieldType, genJavaFieldName(attribute.Name))
 		}
 		content += "}\n"
 		gen.StructAST[v.Name] = content
-		fieldName := genJavaFieldName(v.Name, true)
-		gen.Field += fmt.Sprintf("%spublic class %s%s", genFieldComment(fieldName, v.Doc, "//"), fieldName, gen.StructAST[v.Name])
+		fieldName := gen.uniqueName(genJavaFieldName(v.Name))
+		fmt.Fprintf(&gen.Field, "%spublic class %s%s", genFieldComment(fieldName, v.Doc, "//"), fieldName, gen.StructAST[v.Name])
 	}
 }
 
 // JavaElement generates code for element XML schema in Java language syntax.
 func (gen *CodeGenerator) JavaElement(v *Element) {
 	if _, ok := gen.StructAST[v.Name]; !ok {
-		fieldType := genJavaFieldType(getBasefromSimpleType(trimNSPrefix(v.Type), gen.ProtoTree))
+		fieldType := gen.genJavaFieldType(gen.baseType(trimNSPrefix(v.Type)))
 		if v.Plural {
 			fieldType = fmt.Sprintf("List<%s>", fieldType)
 		}
-		content := fmt.Sprintf("\tprotected %s %s;\n", fieldType, genJavaFieldName(v.Name, false))
+		content := fmt.Sprintf("\tprotected %s %s;\n", fieldType, genJavaFieldName(v.Name))
 		gen.StructAST[v.Name] = content
-		gen.Field += fmt.Sprintf("\n@XmlAccessorType(XmlAccessType.FIELD)\n@XmlElement(required = true, name = \"%s\")\npublic class %s {\n%s}\n", v.Name, genJavaFieldName(v.Name, true), gen.StructAST[v.Name])
+		fmt.Fprintf(&gen.Field, "\n@XmlAccessorType(XmlAccessType.FIELD)\n@XmlElement(required = true, name = \"%s\")\npublic class %s {\n%s}\n", v.Name, gen.uniqueName(genJavaFieldName(v.Name)), gen.StructAST[v.Name])
 	}
 }
 
 // JavaAttribute generates code for attribute XML schema in Java language syntax.
 func (gen *CodeGenerator) JavaAttribute(v *Attribute) {
 	if _, ok := gen.StructAST[v.Name]; !ok {
-		fieldType := genJavaFieldType(getBasefromSimpleType(trimNSPrefix(v.Type), gen.ProtoTree))
+		fieldType := gen.genJavaFieldType(gen.baseType(trimNSPrefix(v.Type)))
 		if v.Plural {
 			fieldType = fmt.Sprintf("List<%s>", fieldType)
 		}
-		content := fmt.Sprintf("\tprotected %s %s;\n", fieldType, genJavaFieldName(v.Name, false))
+		content := fmt.Sprintf("\tprotected %s %s;\n", fieldType, genJavaFieldName(v.Name))
 		gen.StructAST[v.Name] = content
-		gen.Field += fmt.Sprintf("\n@XmlAccessorType(XmlAccessType.FIELD)\n@XmlAttribute(required = true, name = \"%s\")\npublic class %s {\n%s}\n", v.Name, genJavaFieldName(v.Name, true), gen.StructAST[v.Name])
+		fmt.Fprintf(&gen.Field, "\n@XmlAccessorType(XmlAccessType.FIELD)\n@XmlAttribute(required = true, name = \"%s\")\npublic class %s {\n%s}\n", v.Name, gen.uniqueName(genJavaFieldName(v.Name)), gen.StructAST[v.Name])
 	}
 }
@@ -10,8 +10,6 @@ package xgen
 
 import (
 	"fmt"
-	"os"
-	"reflect"
 	"strings"
 )
 
@@ -96,28 +94,34 @@ var (
 // GenRust generate Go programming language source code for XML schema
 // definition files.
 func (gen *CodeGenerator) GenRust() error {
-	fieldNameCount = make(map[string]int)
+	gen.fieldNameCount = make(map[string]int)
 	for _, ele := range gen.ProtoTree {
 		if ele == nil {
 			continue
 		}
-		funcName := fmt.Sprintf("Rust%s", reflect.TypeOf(ele).String()[6:])
-		callFuncByName(gen, funcName, []reflect.Value{reflect.ValueOf(ele)})
+		ele.Accept(rustVisitor{gen})
 	}
-	f, err := os.Create(gen.FileWithExtension(".rs"))
-	if err != nil {
-		return err
-	}
-	defer f.Close()
 	var extern = `use serde::Serialize;
 use serde::Deserialize;
 
 use serde_xml_rs::from_reader;`
-	source := []byte(fmt.Sprintf("%s\n\n%s\n%s", copyright, extern, gen.Field))
-	f.Write(source)
-	return err
+	extern += gen.mappedImports(func(mapping TypeMapping) string {
+		return fmt.Sprintf("\nuse %s;", mapping.Import)
+	})
+	source := []byte(fmt.Sprintf("%s\n\n%s\n%s", copyright, extern, gen.Field.String()))
+	return gen.writeFile(".rs", source)
 }
 
+// rustVisitor generates Rust code for the schema components it visits.
+type rustVisitor struct{ *CodeGenerator }
+
+func (v rustVisitor) VisitSimpleType(c *SimpleType)         { v.RustSimpleType(c) }
+func (v rustVisitor) VisitComplexType(c *ComplexType)       { v.RustComplexType(c) }
+func (v rustVisitor) VisitElement(c *Element)               { v.RustElement(c) }
+func (v rustVisitor) VisitAttribute(c *Attribute)           { v.RustAttribute(c) }
+func (v rustVisitor) VisitGroup(c *Group)                   { v.RustGroup(c) }
+func (v rustVisitor) VisitAttributeGroup(c *AttributeGroup) { v.RustAttributeGroup(c) }
+
 // genRustFieldName generate struct field name for Rust code.
 func genRustFieldName(name string) (fieldName string) {
 	for _, str := range strings.Split(name, ":") {
@@ -136,7 +140,7 @@ func genRustFieldName(name string) (fieldName string) {
 }
 
 // genRustStructName generate struct name for Rust code.
-func genRustStructName(name string, unique bool) (structName string) {
+func genRustStructName(name string) (structName string) {
 	for _, str := range strings.Split(name, ":") {
 		structName += MakeFirstUpperCase(str)
 	}
@@ -146,21 +150,15 @@ func genRustStructName(name string, unique bool) (structName string) {
 	}
 	structName = tmp
 	structName = strings.NewReplacer("-", "", "_", "").Replace(structName)
-	if unique {
-		fieldNameCount[structName]++
-		if count := fieldNameCount[structName]; count != 1 {
-			structName = fmt.Sprintf("%s%d", structName, count)
-		}
-	}
 	return
 }
 
 // genRustFieldType generate struct field type for Rust code.
-func genRustFieldType(name string) string {
-	if _, ok := rustBuildinType[name]; ok {
+func (gen *CodeGenerator) genRustFieldType(name string) string {
+	if _, ok := rustBuildinType[name]; ok || gen.isMappedType(name) {
 		return name
 	}
-	fieldType := genRustStructName(name, false)
+	fieldType := genRustStructName(name)
 	if fieldType != "" {
 		return fieldType
 	}
@@ -172,11 +170,11 @@ func genRustFieldType(name string) string {
 func (gen *CodeGenerator) RustSimpleType(v *SimpleType) {
 	if v.List {
 		if _, ok := gen.StructAST[v.Name]; !ok {
-			fieldType := genRustFieldType(getBasefromSimpleType(trimNSPrefix(v.Base), gen.ProtoTree))
+			fieldType := gen.genRustFieldType(gen.baseType(trimNSPrefix(v.Base)))
 			content := fmt.Sprintf("\t#[serde(rename = \"%s\")]\n\tpub %s: Vec<%s>,\n", v.Name, genRustFieldName(v.Name), fieldType)
 			gen.StructAST[v.Name] = content
-			fieldName := genRustStructName(v.Name, true)
-			gen.Field += fmt.Sprintf("\n%s#[derive(Debug, Deserialize, Serialize, PartialEq)]\npub struct %s {\n%s}\n", genFieldComment(fieldName, v.Doc, "//"), fieldName, gen.StructAST[v.Name])
+			fieldName := gen.uniqueName(genRustStructName(v.Name))
+			fmt.Fprintf(&gen.Field, "\n%s#[derive(Debug, Deserialize, Serialize, PartialEq)]\npub struct %s {\n%s}\n", genFieldComment(fieldName, v.Doc, "//"), fieldName, gen.StructAST[v.Name])
 			return
 		}
 	}
@@ -188,21 +186,21 @@ func (gen *CodeGenerator) RustSimpleType(v *SimpleType) {
 				memberType := member.value
 
 				if memberType == "" { // fix order issue
-					memberType = getBasefromSimpleType(memberName, gen.ProtoTree)
+					memberType = gen.baseType(memberName)
 				}
-				content += fmt.Sprintf("\t#[serde(rename = \"%s\")]\n\tpub %s: %s,\n", v.Name, genRustFieldName(memberName), genRustFieldType(memberType))
+				content += fmt.Sprintf("\t#[serde(rename = \"%s\")]\n\tpub %s: %s,\n", v.Name, genRustFieldName(memberName), gen.genRustFieldType(memberType))
 			}
 			gen.StructAST[v.Name] = content
-			gen.Field += fmt.Sprintf("\n#[derive(Debug, Deserialize, Serialize, PartialEq)]\npub struct %s {\n%s}\n", genRustStructName(v.Name, true), gen.StructAST[v.Name])
+			fmt.Fprintf(&gen.Field, "\n#[derive(Debug, Deserialize, Serialize, PartialEq)]\npub struct %s {\n%s}\n", gen.uniqueName(genRustStructName(v.Name)), gen.StructAST[v.Name])
 		}
 		return
 	}
 	if _, ok := gen.StructAST[v.Name]; !ok {
-		fieldType := genRustFieldType(getBasefromSimpleType(trimNSPrefix(v.Base), gen.ProtoTree))
+		fieldType := gen.genRustFieldType(gen.baseType(trimNSPrefix(v.Base)))
 		content := fmt.Sprintf("\t#[serde(rename = \"%s\")]\n\tpub %s: %s,\n", v.Name, genRustFieldName(v.Name), fieldType)
 		gen.StructAST[v.Name] = content
-		fieldName := genRustStructName(v.Name, true)
-		gen.Field += fmt.Sprintf("\n%s#[derive(Debug, Deserialize, Serialize, PartialEq)]\npub struct %s {\n%s}\n", genFieldComment(fieldName, v.Doc, "//"), fieldName, gen.StructAST[v.Name])
+		fieldName := gen.uniqueName(genRustStructName(v.Name))
+		fmt.Fprintf(&gen.Field, "\n%s#[derive(Debug, Deserialize, Serialize, PartialEq)]\npub struct %s {\n%s}\n", genFieldComment(fieldName, v.Doc, "//"), fieldName, gen.StructAST[v.Name])
 	}
 }
 
@@ -212,11 +210,12 @@ func (gen *CodeGenerator) RustComplexType(v *ComplexType) {
 	if _, ok := gen.StructAST[v.Name]; !ok {
 		var content string
 		for _, attrGroup := range v.AttributeGroup {
-			fieldType := getBasefromSimpleType(trimNSPrefix(attrGroup.Ref), gen.ProtoTree)
-			content += fmt.Sprintf("\t#[serde(rename = \"%s\")]\n\tpub %s: Vec<%s>,\n", attrGroup.Name, genRustFieldName(attrGroup.Name), genRustFieldType(fieldType))
+			fieldType := gen.baseType(trimNSPrefix(attrGroup.Ref))
+			content += fmt.Sprintf("\t#[serde(rename = \"%s\")]\n\tpub %s: Vec<%s>,\n", attrGroup.Name, genRustFieldName(attrGroup.Name), gen.genRustFieldType(fieldType))
 		}
-		for _, attribute := range v.Attributes {
-			fieldType := genRustFieldType(getBasefromSimpleType(trimNSPrefix(attribute.Type), gen.ProtoTree))
+		attributes, elements := gen.complexTypeContent(v)
+		for _, attribute := range attributes {
+			fieldType := gen.genRustFieldType(gen.baseType(trimNSPrefix(attribute.Type)))
 			if attribute.Optional {
 				content += fmt.Sprintf("\t#[serde(rename = \"%s\")]\n\tpub %s: Option<%s>,\n", attribute.Name, genRustFieldName(attribute.Name), fieldType)
 			} else {
@@ -224,7 +223,7 @@ func (gen *CodeGenerator) RustComplexType(v *ComplexType) {
 			}
 		}
 		for _, group := range v.Groups {
-			fieldType := genRustFieldType(getBasefromSimpleType(trimNSPrefix(group.Ref), gen.ProtoTree))
+			fieldType := gen.genRustFieldType(gen.baseType(trimNSPrefix(group.Ref)))
 			fieldName := genRustFieldName(group.Name)
 			if group.Plural {
 				content += fmt.Sprintf("\t#[serde(rename = \"%s\")]\n\tpub %s: Vec<%s>,\n", group.Name, fieldName, fieldType)
@@ -232,9 +231,12 @@ func (gen *CodeGenerator) RustComplexType(v *ComplexType) {
 				content += fmt.Sprintf("\t#[serde(rename = \"%s\")]\n\tpub %s: %s,\n", group.Name, fieldName, fieldType)
 			}
 		}
-		for _, element := range v.Elements {
-			fieldType := genRustFieldType(getBasefromSimpleType(trimNSPrefix(element.Type), gen.ProtoTree))
+		for _, element := range elements {
+			fieldType := gen.genRustFieldType(gen.baseType(trimNSPrefix(element.Type)))
 			fieldName := genRustFieldName(element.Name)
+			if element.Nillable {
+				fieldType = fmt.Sprintf("Option<%s>", fieldType)
+			}
 			if element.Plural {
 				content += fmt.Sprintf("\t#[serde(rename = \"%s\")]\n\tpub %s: Vec<%s>,\n", element.Name, fieldName, fieldType)
 			} else {
@@ -246,9 +248,16 @@ func (gen *CodeGenerator) RustComplexType(v *ComplexType) {
 			}
 		}
 		if len(v.Base) > 0 {
-			fieldType := genRustFieldType(getBasefromSimpleType(trimNSPrefix(v.Base), gen.ProtoTree))
-			if isRustBuiltInType(v.Base) {
-				content += fmt.Sprintf("\t#[serde(rename = \"$value\")]\n\tpub value: %s,\n", fieldType)
+			fieldType := gen.genRustFieldType(gen.baseType(trimNSPrefix(v.Base)))
+			if isContentRestriction(v) {
+				if v.Content == "simpleContent" {
+					fieldType = gen.genRustFieldType(gen.baseType(trimNSPrefix(gen.simpleContentBase(v))))
+					content += fmt.Sprintf("\t#[serde(rename = \"$value\")]\n\tpub value: %s,\n", fieldType)
+				}
+			} else if gen.isRustBuiltInType(v.Base) {
+				if v.Content != "complexContent" {
+					content += fmt.Sprintf("\t#[serde(rename = \"$value\")]\n\tpub value: %s,\n", fieldType)
+				}
 			} else {
 				fieldName := genRustFieldName(fieldType)
 				// If the type is not a built-in one, add the base type as a nested field tagged with flatten
@@ -256,14 +265,14 @@ func (gen *CodeGenerator) RustComplexType(v *ComplexType) {
 			}
 		}
 		gen.StructAST[v.Name] = content
-		fieldName := genRustStructName(v.Name, true)
-		gen.Field += fmt.Sprintf("\n%s#[derive(Debug, Deserialize, Serialize, PartialEq)]\npub struct %s {\n%s}\n", genFieldComment(fieldName, v.Doc, "//"), fieldName, gen.StructAST[v.Name])
+		fieldName := gen.uniqueName(genRustStructName(v.Name))
+		fmt.Fprintf(&gen.Field, "\n%s#[derive(Debug, Deserialize, Serialize, PartialEq)]\npub struct %s {\n%s}\n", genFieldComment(fieldName, v.Doc, "//"), fieldName, gen.StructAST[v.Name])
 	}
 }
 
-func isRustBuiltInType(typeName string) bool {
+func (gen *CodeGenerator) isRustBuiltInType(typeName string) bool {
 	_, builtIn := rustBuildinType[typeName]
-	return builtIn
+	return builtIn || gen.isMappedType(typeName)
 }
 
 // RustGroup generates code for group XML schema in Rust language syntax.
@@ -271,7 +280,7 @@ func (gen *CodeGenerator) RustGroup(v *Group) {
 	if _, ok := gen.StructAST[v.Name]; !ok {
 		var content string
 		for _, element := range v.Elements {
-			fieldType := genRustFieldType(getBasefromSimpleType(trimNSPrefix(element.Type), gen.ProtoTree))
+			fieldType := gen.genRustFieldType(gen.baseType(trimNSPrefix(element.Type)))
 			fieldName := genRustFieldName(element.Name)
 			if v.Plural {
 				content += fmt.Sprintf("\t#[serde(rename = \"%s\")]\n\tpub %s: Vec<%s>,\n", element.Name, fieldName, fieldType)
@@ -280,7 +289,7 @@ func (gen *CodeGenerator) RustGroup(v *Group) {
 			}
 		}
 		for _, group := range v.Groups {
-			fieldType := genRustFieldType(getBasefromSimpleType(trimNSPrefix(group.Ref), gen.ProtoTree))
+			fieldType := gen.genRustFieldType(gen.baseType(trimNSPrefix(group.Ref)))
 			fieldName := genRustFieldName(group.Name)
 			if v.Plural {
 				content += fmt.Sprintf("\t#[serde(rename = \"%s\")]\n\tpub %s: Vec<%s>,\n", group.Name, fieldName, fieldType)
@@ -289,8 +298,8 @@ func (gen *CodeGenerator) RustGroup(v *Group) {
 			}
 		}
 		gen.StructAST[v.Name] = content
-		fieldName := genRustStructName(v.Name, true)
-		gen.Field += fmt.Sprintf("\n%s#[derive(Debug, Deserialize, Serialize, PartialEq)]\npub struct %s {\n%s}\n", genFieldComment(fieldName, v.Doc, "//"), fieldName, gen.StructAST[v.Name])
+		fieldName := gen.uniqueName(genRustStructName(v.Name))
+		fmt.Fprintf(&gen.Field, "\n%s#[derive(Debug, Deserialize, Serialize, PartialEq)]\npub struct %s {\n%s}\n", genFieldComment(fieldName, v.Doc, "//"), fieldName, gen.StructAST[v.Name])
 	}
 }
 
@@ -301,41 +310,41 @@ func (gen *CodeGenerator) RustAttributeGroup(v *AttributeGroup) {
 		var content string
 		for _, attribute := range v.Attributes {
 			if attribute.Optional {
-				content += fmt.Sprintf("\t#[serde(rename = \"%s\")]\n\tpub %s: Option<%s>,\n", attribute.Name, genRustFieldName(attribute.Name), genRustFieldType(getBasefromSimpleType(trimNSPrefix(attribute.Type), gen.ProtoTree)))
+				content += fmt.Sprintf("\t#[serde(rename = \"%s\")]\n\tpub %s: Option<%s>,\n", attribute.Name, genRustFieldName(attribute.Name), gen.genRustFieldType(gen.baseType(trimNSPrefix(attribute.Type))))
 			} else {
-				content += fmt.Sprintf("\t#[serde(rename = \"%s\")]\n\tpub %s: Vec<%s>,\n", attribute.Name, genRustFieldName(attribute.Name), genRustFieldType(getBasefromSimpleType(trimNSPrefix(attribute.Type), gen.ProtoTree)))
+				content += fmt.Sprintf("\t#[serde(rename = \"%s\")]\n\tpub %s: Vec<%s>,\n", attribute.Name, genRustFieldName(attribute.Name), gen.genRustFieldType(gen.baseType(trimNSPrefix(attribute.Type))))
 			}
 		}
 		gen.StructAST[v.Name] = content
-		fieldName := genRustStructName(v.Name, true)
-		gen.Field += fmt.Sprintf("\n%s#[derive(Debug, Deserialize, Serialize, PartialEq)]\npub struct %s {\n%s}\n", genFieldComment(fieldName, v.Doc, "//"), fieldName, gen.StructAST[v.Name])
+		fieldName := gen.uniqueName(genRustStructName(v.Name))
+		fmt.Fprintf(&gen.Field, "\n%s#[derive(Debug, Deserialize, Serialize, PartialEq)]\npub struct %s {\n%s}\n", genFieldComment(fieldName, v.Doc, "//"), fieldName, gen.StructAST[v.Name])
 	}
 }
 
 // RustElement generates code for element XML schema in Rust language syntax.
 func (gen *CodeGenerator) RustElement(v *Element) {
 	if _, ok := gen.StructAST[v.Name]; !ok {
-		fieldType := genRustFieldType(getBasefromSimpleType(trimNSPrefix(v.Type), gen.ProtoTree))
+		fieldType := gen.genRustFieldType(gen.baseType(trimNSPrefix(v.Type)))
 		fieldName := genRustFieldName(v.Name)
 		if v.Plural {
 			gen.StructAST[v.Name] = fmt.Sprintf("\t#[serde(rename = \"%s\")]\n\tpub %s: Vec<%s>,\n", v.Name, fieldName, fieldType)
 		} else {
 			gen.StructAST[v.Name] = fmt.Sprintf("\t#[serde(rename = \"%s\")]\n\tpub %s: %s,\n", v.Name, fieldName, fieldType)
 		}
-		gen.Field += fmt.Sprintf("\n%s#[derive(Debug, Deserialize, Serialize, PartialEq)]\npub struct %s {\n%s}\n", genFieldComment(fieldName, v.Doc, "//"), fieldName, gen.StructAST[v.Name])
+		fmt.Fprintf(&gen.Field, "\n%s#[derive(Debug, Deserialize, Serialize, PartialEq)]\npub struct %s {\n%s}\n", genFieldComment(fieldName, v.Doc, "//"), fieldName, gen.StructAST[v.Name])
 	}
 }
 
 // RustAttribute generates code for attribute XML schema in Rust language syntax.
 func (gen *CodeGenerator) RustAttribute(v *Attribute) {
 	if _, ok := gen.StructAST[v.Name]; !ok {
-		fieldType := genRustFieldType(getBasefromSimpleType(trimNSPrefix(v.Type), gen.ProtoTree))
+		fieldType := gen.genRustFieldType(gen.baseType(trimNSPrefix(v.Type)))
 		fieldName := genRustFieldName(v.Name)
 		if v.Plural {
 			gen.StructAST[v.Name] = fmt.Sprintf("\t#[serde(rename = \"%s\")]\n\tpub %s: Vec<%s>,\n", v.Name, fieldName, fieldType)
 		} else {
 			gen.StructAST[v.Name] = fmt.Sprintf("\t#[serde(rename = \"%s\")]\n\tpub %s: %s,\n", v.Name, fieldName, fieldType)
 		}
-		gen.Field += fmt.Sprintf("\n%s#[derive(Debug, Deserialize, Serialize, PartialEq)]\npub struct %s {\n%s}\n", genFieldComment(fieldName, v.Doc, "//"), fieldName, gen.StructAST[v.Name])
+		fmt.Fprintf(&gen.Field, "\n%s#[derive(Debug, Deserialize, Serialize, PartialEq)]\npub struct %s {\n%s}\n", genFieldComment(fieldName, v.Doc, "//"), fieldName, gen.StructAST[v.Name])
 	}
 }
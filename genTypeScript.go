@@ -10,8 +10,6 @@ package xgen
 
 import (
 	"fmt"
-	"os"
-	"reflect"
 	"strings"
 )
 
@@ -28,25 +26,31 @@ var typeScriptBuildInType = map[string]bool{
 // GenTypeScript generate TypeScript programming language source code for XML
 // schema definition files.
 func (gen *CodeGenerator) GenTypeScript() error {
-	fieldNameCount = make(map[string]int)
+	gen.fieldNameCount = make(map[string]int)
 	for _, ele := range gen.ProtoTree {
 		if ele == nil {
 			continue
 		}
-		funcName := fmt.Sprintf("TypeScript%s", reflect.TypeOf(ele).String()[6:])
-		callFuncByName(gen, funcName, []reflect.Value{reflect.ValueOf(ele)})
+		ele.Accept(typeScriptVisitor{gen})
 	}
-	f, err := os.Create(gen.FileWithExtension(".ts"))
-	if err != nil {
-		return err
-	}
-	defer f.Close()
-	source := []byte(fmt.Sprintf("%s\n%s", copyright, gen.Field))
-	f.Write(source)
-	return err
+	imports := gen.mappedImports(func(mapping TypeMapping) string {
+		return fmt.Sprintf("import { %s } from %q;\n", mapping.Type, mapping.Import)
+	})
+	source := []byte(fmt.Sprintf("%s\n%s%s", copyright, imports, gen.Field.String()))
+	return gen.writeFile(".ts", source)
 }
 
-func genTypeScriptFieldName(name string, unique bool) (fieldName string) {
+// typeScriptVisitor generates TypeScript code for the schema components it visits.
+type typeScriptVisitor struct{ *CodeGenerator }
+
+func (v typeScriptVisitor) VisitSimpleType(c *SimpleType)         { v.TypeScriptSimpleType(c) }
+func (v typeScriptVisitor) VisitComplexType(c *ComplexType)       { v.TypeScriptComplexType(c) }
+func (v typeScriptVisitor) VisitElement(c *Element)               { v.TypeScriptElement(c) }
+func (v typeScriptVisitor) VisitAttribute(c *Attribute)           { v.TypeScriptAttribute(c) }
+func (v typeScriptVisitor) VisitGroup(c *Group)                   { v.TypeScriptGroup(c) }
+func (v typeScriptVisitor) VisitAttributeGroup(c *AttributeGroup) { v.TypeScriptAttributeGroup(c) }
+
+func genTypeScriptFieldName(name string) (fieldName string) {
 	for _, str := range strings.Split(name, ":") {
 		fieldName += MakeFirstUpperCase(str)
 	}
@@ -56,24 +60,22 @@ func genTypeScriptFieldName(name string, unique bool) (fieldName string) {
 	}
 	fieldName = tmp
 	fieldName = strings.Replace(fieldName, "-", "", -1)
-	if unique {
-		fieldNameCount[fieldName]++
-		if count := fieldNameCount[fieldName]; count != 1 {
-			fieldName = fmt.Sprintf("%s%d", fieldName, count)
-		}
-	}
 	return
 }
 
-func genTypeScriptFieldType(name string, plural bool) (fieldType string) {
+func (gen *CodeGenerator) genTypeScriptFieldType(name string, plural bool) (fieldType string) {
 	if _, ok := typeScriptBuildInType[name]; ok {
 		fieldType = name
 		return
 	}
-	for _, str := range strings.Split(name, ".") {
-		fieldType += MakeFirstUpperCase(str)
+	if gen.isMappedType(name) {
+		fieldType = name
+	} else {
+		for _, str := range strings.Split(name, ".") {
+			fieldType += MakeFirstUpperCase(str)
+		}
+		fieldType = MakeFirstUpperCase(strings.Replace(fieldType, "-", "", -1))
 	}
-	fieldType = MakeFirstUpperCase(strings.Replace(fieldType, "-", "", -1))
 	if fieldType == "" || fieldType == "Any" {
 		fieldType = "any"
 	}
@@ -88,11 +90,11 @@ func genTypeScriptFieldType(name string, plural bool) (fieldType string) {
 func (gen *CodeGenerator) TypeScriptSimpleType(v *SimpleType) {
 	if v.List {
 		if _, ok := gen.StructAST[v.Name]; !ok {
-			fieldType := genTypeScriptFieldType(getBasefromSimpleType(trimNSPrefix(v.Base), gen.ProtoTree), true)
+			fieldType := gen.genTypeScriptFieldType(gen.baseType(trimNSPrefix(v.Base)), true)
 			content := fmt.Sprintf(" = %s;\n", fieldType)
 			gen.StructAST[v.Name] = content
-			fieldName := genTypeScriptFieldName(v.Name, true)
-			gen.Field += fmt.Sprintf("%sexport type %s%s", genFieldComment(fieldName, v.Doc, "//"), fieldName, gen.StructAST[v.Name])
+			fieldName := gen.uniqueName(genTypeScriptFieldName(v.Name))
+			fmt.Fprintf(&gen.Field, "%sexport type %s%s", genFieldComment(fieldName, v.Doc, "//"), fieldName, gen.StructAST[v.Name])
 			return
 		}
 	}
@@ -104,20 +106,20 @@ func (gen *CodeGenerator) TypeScriptSimpleType(v *SimpleType) {
 				memberType := member.value
 
 				if memberType == "" { // fix order issue
-					memberType = getBasefromSimpleType(memberName, gen.ProtoTree)
+					memberType = gen.baseType(memberName)
 				}
-				content += fmt.Sprintf("\t%s: %s;\n", genTypeScriptFieldName(memberName, false), genTypeScriptFieldType(memberType, false))
+				content += fmt.Sprintf("\t%s: %s;\n", genTypeScriptFieldName(memberName), gen.genTypeScriptFieldType(memberType, false))
 			}
 			content += "}\n"
 			gen.StructAST[v.Name] = content
-			fieldName := genTypeScriptFieldName(v.Name, true)
-			gen.Field += fmt.Sprintf("%sexport class %s%s", genFieldComment(fieldName, v.Doc, "//"), fieldName, gen.StructAST[v.Name])
+			fieldName := gen.uniqueName(genTypeScriptFieldName(v.Name))
+			fmt.Fprintf(&gen.Field, "%sexport class %s%s", genFieldComment(fieldName, v.Doc, "//"), fieldName, gen.StructAST[v.Name])
 		}
 		return
 	}
 	if len(v.Restriction.Enum) > 0 {
 		var content string
-		baseType := genTypeScriptFieldType(getBasefromSimpleType(trimNSPrefix(v.Base), gen.ProtoTree), false)
+		baseType := gen.genTypeScriptFieldType(gen.baseType(trimNSPrefix(v.Base)), false)
 		for _, enum := range v.Restriction.Enum {
 			switch baseType {
 			case "string":
@@ -128,15 +130,15 @@ func (gen *CodeGenerator) TypeScriptSimpleType(v *SimpleType) {
 				content += fmt.Sprintf("\tEnum%s = '%s',\n", enum, enum)
 			}
 		}
-		fieldName := genTypeScriptFieldName(v.Name, true)
-		gen.Field += fmt.Sprintf("%sexport enum %s {\n%s}\n", genFieldComment(fieldName, v.Doc, "//"), fieldName, content)
+		fieldName := gen.uniqueName(genTypeScriptFieldName(v.Name))
+		fmt.Fprintf(&gen.Field, "%sexport enum %s {\n%s}\n", genFieldComment(fieldName, v.Doc, "//"), fieldName, content)
 		return
 	}
 	if _, ok := gen.StructAST[v.Name]; !ok {
-		content := fmt.Sprintf(" %s;\n", genTypeScriptFieldType(getBasefromSimpleType(trimNSPrefix(v.Base), gen.ProtoTree), false))
+		content := fmt.Sprintf(" %s;\n", gen.genTypeScriptFieldType(gen.baseType(trimNSPrefix(v.Base)), false))
 		gen.StructAST[v.Name] = content
-		fieldName := genTypeScriptFieldName(v.Name, true)
-		gen.Field += fmt.Sprintf("%sexport type %s =%s", genFieldComment(fieldName, v.Doc, "//"), fieldName, gen.StructAST[v.Name])
+		fieldName := gen.uniqueName(genTypeScriptFieldName(v.Name))
+		fmt.Fprintf(&gen.Field, "%sexport type %s =%s", genFieldComment(fieldName, v.Doc, "//"), fieldName, gen.StructAST[v.Name])
 	}
 }
 
@@ -146,55 +148,67 @@ func (gen *CodeGenerator) TypeScriptComplexType(v *ComplexType) {
 	if _, ok := gen.StructAST[v.Name]; !ok {
 		content := " {\n"
 		for _, attrGroup := range v.AttributeGroup {
-			fieldType := getBasefromSimpleType(trimNSPrefix(attrGroup.Ref), gen.ProtoTree)
-			content += fmt.Sprintf("\t%s: %s;\n", genTypeScriptFieldName(attrGroup.Name, false), genTypeScriptFieldType(fieldType, false))
+			fieldType := gen.baseType(trimNSPrefix(attrGroup.Ref))
+			content += fmt.Sprintf("\t%s: %s;\n", genTypeScriptFieldName(attrGroup.Name), gen.genTypeScriptFieldType(fieldType, false))
 		}
 
-		for _, attribute := range v.Attributes {
-			fieldType := genTypeScriptFieldType(
-				getBasefromSimpleType(trimNSPrefix(attribute.Type), gen.ProtoTree),
+		attributes, elements := gen.complexTypeContent(v)
+		for _, attribute := range attributes {
+			fieldType := gen.genTypeScriptFieldType(
+				gen.baseType(trimNSPrefix(attribute.Type)),
 				attribute.Plural,
 			)
-			fieldName := genTypeScriptFieldName(attribute.Name, false) + "Attr"
+			fieldName := genTypeScriptFieldName(attribute.Name) + "Attr"
 			if attribute.Optional {
 				fieldName += "?"
 			}
 			content += fmt.Sprintf("\t%s: %s;\n", fieldName, fieldType)
 		}
 		for _, group := range v.Groups {
-			content += fmt.Sprintf("\t%s: %s;\n", genTypeScriptFieldName(group.Name, false), genTypeScriptFieldType(getBasefromSimpleType(trimNSPrefix(group.Ref), gen.ProtoTree), group.Plural))
+			content += fmt.Sprintf("\t%s: %s;\n", genTypeScriptFieldName(group.Name), gen.genTypeScriptFieldType(gen.baseType(trimNSPrefix(group.Ref)), group.Plural))
 		}
 
-		for _, element := range v.Elements {
-			fieldType := genTypeScriptFieldType(getBasefromSimpleType(trimNSPrefix(element.Type), gen.ProtoTree), element.Plural)
-			fieldName := genTypeScriptFieldName(element.Name, false)
+		for _, element := range elements {
+			fieldType := gen.genTypeScriptFieldType(gen.baseType(trimNSPrefix(element.Type)), element.Plural && !element.Nillable)
+			if element.Nillable {
+				fieldType += " | null"
+				if element.Plural {
+					fieldType = fmt.Sprintf("Array<%s>", fieldType)
+				}
+			}
+			fieldName := genTypeScriptFieldName(element.Name)
 			if element.Optional {
 				fieldName += `?`
 			}
 			content += fmt.Sprintf("\t%s: %s;\n", fieldName, fieldType)
 		}
 
-		if len(v.Base) > 0 && isBuiltInTypeScriptType(v.Base) {
-			fieldType := genTypeScriptFieldType(getBasefromSimpleType(trimNSPrefix(v.Base), gen.ProtoTree), false)
+		if isContentRestriction(v) {
+			if v.Content == "simpleContent" {
+				fieldType := gen.genTypeScriptFieldType(gen.baseType(trimNSPrefix(gen.simpleContentBase(v))), false)
+				content += fmt.Sprintf("\tValue: %s;\n", fieldType)
+			}
+		} else if len(v.Base) > 0 && gen.isBuiltInTypeScriptType(v.Base) && v.Content != "complexContent" {
+			fieldType := gen.genTypeScriptFieldType(gen.baseType(trimNSPrefix(v.Base)), false)
 			content += fmt.Sprintf("\tValue: %s;\n", fieldType)
 		}
 		content += "}\n"
 		gen.StructAST[v.Name] = content
-		fieldName := genTypeScriptFieldName(v.Name, true)
+		fieldName := gen.uniqueName(genTypeScriptFieldName(v.Name))
 		typeExtension := ""
-		if len(v.Base) > 0 && !isBuiltInTypeScriptType(v.Base) {
-			fieldType := genTypeScriptFieldType(getBasefromSimpleType(trimNSPrefix(v.Base), gen.ProtoTree), false)
+		if len(v.Base) > 0 && !gen.isBuiltInTypeScriptType(v.Base) && !isContentRestriction(v) {
+			fieldType := gen.genTypeScriptFieldType(gen.baseType(trimNSPrefix(v.Base)), false)
 			content += fmt.Sprintf("\tValue: %s;\n", fieldType)
 			typeExtension = fmt.Sprintf(" extends %s ", fieldType)
 		}
 
-		gen.Field += fmt.Sprintf("%sexport class %s%s%s", genFieldComment(fieldName, v.Doc, "//"), fieldName, typeExtension, gen.StructAST[v.Name])
+		fmt.Fprintf(&gen.Field, "%sexport class %s%s%s", genFieldComment(fieldName, v.Doc, "//"), fieldName, typeExtension, gen.StructAST[v.Name])
 	}
 }
 
-func isBuiltInTypeScriptType(typeName string) bool {
+func (gen *CodeGenerator) isBuiltInTypeScriptType(typeName string) bool {
 	_, builtIn := typeScriptBuildInType[typeName]
-	return builtIn
+	return builtIn || gen.isMappedType(typeName)
 }
 
 // TypeScriptGroup generates code for group XML schema in TypeScript language syntax.
@@ -202,17 +216,17 @@ func (gen *CodeGenerator) TypeScriptGroup(v *Group) {
 	if _, ok := gen.StructAST[v.Name]; !ok {
 		content := " {\n"
 		for _, element := range v.Elements {
-			content += fmt.Sprintf("\t%s: %s;\n", genTypeScriptFieldName(element.Name, false), genTypeScriptFieldType(getBasefromSimpleType(trimNSPrefix(element.Type), gen.ProtoTree), element.Plural))
+			content += fmt.Sprintf("\t%s: %s;\n", genTypeScriptFieldName(element.Name), gen.genTypeScriptFieldType(gen.baseType(trimNSPrefix(element.Type)), element.Plural))
 		}
 
 		for _, group := range v.Groups {
-			content += fmt.Sprintf("\t%s: %s;\n", genTypeScriptFieldName(group.Name, false), genTypeScriptFieldType(getBasefromSimpleType(trimNSPrefix(group.Ref), gen.ProtoTree), group.Plural))
+			content += fmt.Sprintf("\t%s: %s;\n", genTypeScriptFieldName(group.Name), gen.genTypeScriptFieldType(gen.baseType(trimNSPrefix(group.Ref)), group.Plural))
 		}
 
 		content += "}\n"
 		gen.StructAST[v.Name] = content
-		fieldName := genTypeScriptFieldName(v.Name, true)
-		gen.Field += fmt.Sprintf("%sexport class %s%s", genFieldComment(fieldName, v.Doc, "//"), fieldName, gen.StructAST[v.Name])
+		fieldName := gen.uniqueName(genTypeScriptFieldName(v.Name))
+		fmt.Fprintf(&gen.Field, "%sexport class %s%s", genFieldComment(fieldName, v.Doc, "//"), fieldName, gen.StructAST[v.Name])
 	}
 }
 
@@ -226,29 +240,29 @@ func (gen *CodeGenerator) TypeScriptAttributeGroup(v *AttributeGroup) {
 			if attribute.Optional {
 				optional = ` | null`
 			}
-			content += fmt.Sprintf("\t%sAttr: %s%s;\n", genTypeScriptFieldName(attribute.Name, false), genTypeScriptFieldType(getBasefromSimpleType(trimNSPrefix(attribute.Type), gen.ProtoTree), attribute.Plural), optional)
+			content += fmt.Sprintf("\t%sAttr: %s%s;\n", genTypeScriptFieldName(attribute.Name), gen.genTypeScriptFieldType(gen.baseType(trimNSPrefix(attribute.Type)), attribute.Plural), optional)
 		}
 		content += "}\n"
 		gen.StructAST[v.Name] = content
-		fieldName := genTypeScriptFieldName(v.Name, true)
-		gen.Field += fmt.Sprintf("%sexport class %s%s", genFieldComment(fieldName, v.Doc, "//"), fieldName, gen.StructAST[v.Name])
+		fieldName := gen.uniqueName(genTypeScriptFieldName(v.Name))
+		fmt.Fprintf(&gen.Field, "%sexport class %s%s", genFieldComment(fieldName, v.Doc, "//"), fieldName, gen.StructAST[v.Name])
 	}
 }
 
 // TypeScriptElement generates code for element XML schema in TypeScript language syntax.
 func (gen *CodeGenerator) TypeScriptElement(v *Element) {
 	if _, ok := gen.StructAST[v.Name]; !ok {
-		gen.StructAST[v.Name] = fmt.Sprintf(" %s;\n", genTypeScriptFieldType(getBasefromSimpleType(trimNSPrefix(v.Type), gen.ProtoTree), v.Plural))
-		fieldName := genTypeScriptFieldName(v.Name, true)
-		gen.Field += fmt.Sprintf("%sexport type %s =%s", genFieldComment(fieldName, v.Doc, "//"), fieldName, gen.StructAST[v.Name])
+		gen.StructAST[v.Name] = fmt.Sprintf(" %s;\n", gen.genTypeScriptFieldType(gen.baseType(trimNSPrefix(v.Type)), v.Plural))
+		fieldName := gen.uniqueName(genTypeScriptFieldName(v.Name))
+		fmt.Fprintf(&gen.Field, "%sexport type %s =%s", genFieldComment(fieldName, v.Doc, "//"), fieldName, gen.StructAST[v.Name])
 	}
 }
 
 // TypeScriptAttribute generates code for attribute XML schema in TypeScript language syntax.
 func (gen *CodeGenerator) TypeScriptAttribute(v *Attribute) {
 	if _, ok := gen.StructAST[v.Name]; !ok {
-		gen.StructAST[v.Name] = fmt.Sprintf(" %s;\n", genTypeScriptFieldType(getBasefromSimpleType(trimNSPrefix(v.Type), gen.ProtoTree), v.Plural))
-		fieldName := genTypeScriptFieldName(v.Name, true)
-		gen.Field += fmt.Sprintf("%sexport type %s =%s", genFieldComment(fieldName, v.Doc, "//"), fieldName, gen.StructAST[v.Name])
+		gen.StructAST[v.Name] = fmt.Sprintf(" %s;\n", gen.genTypeScriptFieldType(gen.baseType(trimNSPrefix(v.Type)), v.Plural))
+		fieldName := gen.uniqueName(genTypeScriptFieldName(v.Name))
+		fmt.Fprintf(&gen.Field, "%sexport type %s =%s", genFieldComment(fieldName, v.Doc, "//"), fieldName, gen.StructAST[v.Name])
 	}
 }
@@ -0,0 +1,175 @@
+// Copyright 2026 The xgen Authors. All rights reserved. Use of this source
+// code is governed by a BSD-style license that can be found in the LICENSE
+// file.
+//
+// Package xgen written in pure Go providing a set of functions that allow you
+// to parse XSD (XML schema files). This library needs Go version 1.10 or
+// later.
+
+package xgen
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"path"
+	"path/filepath"
+	"reflect"
+	"strings"
+)
+
+// Generate renders the code of the schemas in the set in the Lang of the set,
+// and writes the files into the sink. Up to Jobs schemas of the options the set is loaded by are generated at the
+// same time, the files are written into the sink one at a time. The warnings
+// found while generating are added to the Diagnostics of the set, and the
+// errors are returned as Diagnostics.
+func Generate(ctx context.Context, set *SchemaSet, sink Sink) error {
+	lang, cfg := set.Lang, set.config
+	if cfg == nil {
+		cfg = &Options{Lang: lang}
+	}
+	funcName := fmt.Sprintf("Gen%s", MakeFirstUpperCase(lang))
+	if !reflect.ValueOf(&CodeGenerator{}).MethodByName(funcName).IsValid() {
+		return fmt.Errorf("unsupported language %s", lang)
+	}
+	if cfg.Jobs > 1 {
+		sink = &syncSink{sink: sink}
+	}
+	generators := make([]*CodeGenerator, len(set.Schemas))
+	outputs := make(map[string]map[string]string, len(set.Schemas))
+	for _, schema := range set.Schemas {
+		outputs[schema.Location] = map[string]string{}
+	}
+	err := runJobs(ctx, cfg.Jobs, len(set.Schemas), func(i int) error {
+		schema := set.Schemas[i]
+		generator := &CodeGenerator{
+			Lang:           lang,
+			Package:        cfg.Package,
+			File:           schema.output,
+			Sink:           &outputSink{Sink: sink, hashes: outputs[schema.Location]},
+			ProtoTree:      schema.Components,
+			StructAST:      map[string]string{},
+			ValidatedTypes: map[string]bool{},
+			EmitXMLName:    !cfg.OmitXMLName,
+
+			PrefixNamespaces:  cfg.PrefixNamespaces,
+			NamespacePrefixes: schema.prefixes,
+			TypeMappings:      cfg.TypeMappings,
+
+			location:      schema.Location,
+			strict:        cfg.Strict,
+			set:           set,
+			sharedHelpers: schema.output != "",
+		}
+		generators[i] = generator
+		return callFuncByName(generator, funcName, []reflect.Value{})
+	})
+	if err != nil {
+		return err
+	}
+	if err = ctx.Err(); err != nil {
+		return err
+	}
+	// The warnings found while generating are added to the ones found when
+	// loading, the errors are returned by the generators
+	for _, generator := range generators {
+		set.Diagnostics = set.Diagnostics.add(generator.diagnostics...)
+	}
+	if err = writeHelpers(cfg, generators); err != nil {
+		return err
+	}
+	if cfg.Manifest != nil {
+		cfg.Manifest.recordOutputs(outputs)
+	}
+	return nil
+}
+
+// writeHelpers writes the helpers file of the Go code into each directory of
+// the generated files which use the helpers. The file is written along
+// with the code of the first schema using it in the directory.
+func writeHelpers(cfg *Options, generators []*CodeGenerator) error {
+	written := map[string]bool{}
+	for _, generator := range generators {
+		name := path.Join(path.Dir(generator.File), goHelpersFile)
+		if !generator.usesHelpers || written[name] {
+			continue
+		}
+		written[name] = true
+		source, err := goHelpers(cfg.Package)
+		if err != nil {
+			return err
+		}
+		if err = generator.Sink.WriteFile(name, source); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// newSchemaSet creates the schema set of the schemas parsed by the options,
+// which is generated by the config. A schema parsed by several options is
+// taken from the first one.
+func newSchemaSet(cfg *Options, parsed ...*Options) *SchemaSet {
+	trees := map[string][]Component{}
+	outputs := map[string]string{}
+	prefixes := map[string]map[string]string{}
+	for _, opt := range parsed {
+		namespacePrefixes := opt.namespacePrefixes()
+		for location, tree := range opt.ParseFileMap {
+			if _, ok := trees[location]; ok {
+				continue
+			}
+			trees[location], outputs[location], prefixes[location] = tree, opt.outputName(location), namespacePrefixes
+		}
+	}
+	set := NewSchemaSet(trees)
+	set.config, set.Lang = cfg, cfg.Lang
+	for _, schema := range set.Schemas {
+		schema.output, schema.prefixes = outputs[schema.Location], prefixes[schema.Location]
+	}
+	return set
+}
+
+// outputName returns the slash-separated path of the code of the schema file
+// relative to the output, which is the path of the schema relative to the
+// input directory. The code of a remote schema is generated by its host and
+// path.
+func (opt *Options) outputName(file string) string {
+	if location := opt.Fetcher.location(file); location != "" {
+		if u, err := url.Parse(location); err == nil {
+			return remoteSchemaPath(u)
+		}
+	}
+	if opt.InputDir != "." {
+		file = strings.TrimPrefix(file, opt.InputDir)
+	}
+	return strings.TrimPrefix(filepath.ToSlash(file), "/")
+}
+
+// writeFile writes the file of the generated code by given extension into
+// the sink, or into the path of the file if there is no sink.
+func (gen *CodeGenerator) writeFile(extension string, data []byte) error {
+	sink := gen.Sink
+	if sink == nil {
+		sink = DirSink("")
+	}
+	return sink.WriteFile(gen.FileWithExtension(extension), data)
+}
+
+// uniqueName returns the name of a type generated by the code generator, which
+// is suffixed by the number of the types generated by the same name so far if
+// the name is taken.
+func (gen *CodeGenerator) uniqueName(name string) string {
+	if gen.fieldNameCount == nil {
+		gen.fieldNameCount = make(map[string]int)
+	}
+	if gen.typeNames == nil {
+		gen.typeNames = make(map[string]bool)
+	}
+	gen.fieldNameCount[name]++
+	if count := gen.fieldNameCount[name]; count != 1 {
+		name = fmt.Sprintf("%s%d", name, count)
+	}
+	gen.typeNames[name] = true
+	return name
+}
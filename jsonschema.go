@@ -0,0 +1,261 @@
+// Copyright 2026 The xgen Authors. All rights reserved. Use of this source
+// code is governed by a BSD-style license that can be found in the LICENSE
+// file.
+//
+// Package xgen written in pure Go providing a set of functions that allow you
+// to parse XSD (XML schema files). This library needs Go version 1.10 or
+// later.
+
+package xgen
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// jsonSchema is a JSON Schema, of which the keywords used by the schema of
+// the project config are supported.
+type jsonSchema struct {
+	Ref                  string                 `json:"$ref"`
+	Defs                 map[string]*jsonSchema `json:"$defs"`
+	Type                 string                 `json:"type"`
+	Const                json.RawMessage        `json:"const"`
+	Enum                 []json.RawMessage      `json:"enum"`
+	Properties           map[string]*jsonSchema `json:"properties"`
+	Required             []string               `json:"required"`
+	AdditionalProperties json.RawMessage        `json:"additionalProperties"`
+	PropertyNames        *jsonSchema            `json:"propertyNames"`
+	Items                *jsonSchema            `json:"items"`
+	MinItems             int                    `json:"minItems"`
+	MinLength            int                    `json:"minLength"`
+	Minimum              *float64               `json:"minimum"`
+	Pattern              string                 `json:"pattern"`
+
+	root       *jsonSchema // the schema resolving the references
+	additional *jsonSchema // the schema of the additional properties
+	closed     bool        // whether the additional properties are forbidden
+	pattern    *regexp.Regexp
+}
+
+// jsonTypes are the descriptions of the JSON types in the diagnostics.
+var jsonTypes = map[string]string{
+	"object":  "an object",
+	"array":   "an array",
+	"string":  "a string",
+	"integer": "an integer",
+	"number":  "a number",
+	"boolean": "a boolean",
+}
+
+// parseJSONSchema parses the JSON Schema document.
+func parseJSONSchema(data []byte) (*jsonSchema, error) {
+	var schema jsonSchema
+	if err := json.Unmarshal(data, &schema); err != nil {
+		return nil, err
+	}
+	if err := schema.compile(&schema); err != nil {
+		return nil, err
+	}
+	return &schema, nil
+}
+
+// compile prepares the schema and its subschemas for validating.
+func (s *jsonSchema) compile(root *jsonSchema) (err error) {
+	s.root = root
+	if s.Pattern != "" {
+		if s.pattern, err = regexp.Compile(s.Pattern); err != nil {
+			return
+		}
+	}
+	switch additional := strings.TrimSpace(string(s.AdditionalProperties)); additional {
+	case "", "true":
+	case "false":
+		s.closed = true
+	default:
+		s.additional = &jsonSchema{}
+		if err = json.Unmarshal(s.AdditionalProperties, s.additional); err != nil {
+			return
+		}
+	}
+	subschemas := []*jsonSchema{s.Items, s.additional, s.PropertyNames}
+	for _, name := range sortedSchemaKeys(s.Defs) {
+		subschemas = append(subschemas, s.Defs[name])
+	}
+	for _, name := range sortedSchemaKeys(s.Properties) {
+		subschemas = append(subschemas, s.Properties[name])
+	}
+	for _, subschema := range subschemas {
+		if subschema != nil {
+			if err = subschema.compile(root); err != nil {
+				return
+			}
+		}
+	}
+	return
+}
+
+// resolve returns the schema referenced by the schema, or the schema itself
+// if it has no reference. Only the definitions of the root schema can be
+// referenced.
+func (s *jsonSchema) resolve() *jsonSchema {
+	if name, ok := strings.CutPrefix(s.Ref, "#/$defs/"); ok {
+		if def, ok := s.root.Defs[name]; ok {
+			return def
+		}
+	}
+	return s
+}
+
+// validate returns the diagnostics of the values of the YAML node violating
+// the schema, the values are located by their JSON pointers.
+func (s *jsonSchema) validate(file string, node *yaml.Node) (diagnostics Diagnostics) {
+	s.validateNode(node, "", func(node *yaml.Node, path, format string, args ...interface{}) {
+		diagnostics = append(diagnostics, Diagnostic{
+			Severity: SeverityError,
+			Code:     CodeInvalidConfig,
+			File:     file,
+			Line:     node.Line,
+			Column:   node.Column,
+			Path:     path,
+			Message:  fmt.Sprintf(format, args...),
+		})
+	})
+	return
+}
+
+// validateNode validates the YAML node at the path, the violations are
+// reported by the function.
+func (s *jsonSchema) validateNode(node *yaml.Node, path string, report func(node *yaml.Node, path, format string, args ...interface{})) {
+	s = s.resolve()
+	for node.Kind == yaml.AliasNode {
+		node = node.Alias
+	}
+	if s.Type != "" && !isJSONType(node, s.Type) {
+		report(node, path, "must be %s", jsonTypes[s.Type])
+		return
+	}
+	if s.Const != nil && !jsonEqual(node, s.Const) {
+		report(node, path, "must be %s", s.Const)
+		return
+	}
+	if s.Enum != nil {
+		valid, values := false, make([]string, len(s.Enum))
+		for i, value := range s.Enum {
+			valid = valid || jsonEqual(node, value)
+			values[i] = strings.Trim(string(value), `"`)
+		}
+		if !valid {
+			report(node, path, "must be one of %s", strings.Join(values, ", "))
+			return
+		}
+	}
+	switch node.Kind {
+	case yaml.MappingNode:
+		s.validateObject(node, path, report)
+	case yaml.SequenceNode:
+		if len(node.Content) < s.MinItems {
+			report(node, path, "must have at least %d items", s.MinItems)
+		}
+		if s.Items != nil {
+			for i, item := range node.Content {
+				s.Items.validateNode(item, path+"/"+strconv.Itoa(i), report)
+			}
+		}
+	case yaml.ScalarNode:
+		if s.MinLength > 0 && len([]rune(node.Value)) < s.MinLength {
+			report(node, path, "must not be shorter than %d characters", s.MinLength)
+		}
+		if s.pattern != nil && !s.pattern.MatchString(node.Value) {
+			report(node, path, "must match %s", s.Pattern)
+		}
+		if s.Minimum != nil {
+			if value, err := strconv.ParseFloat(node.Value, 64); err == nil && value < *s.Minimum {
+				report(node, path, "must be at least %v", *s.Minimum)
+			}
+		}
+	}
+}
+
+// validateObject validates the properties of the YAML mapping node at the
+// path.
+func (s *jsonSchema) validateObject(node *yaml.Node, path string, report func(node *yaml.Node, path, format string, args ...interface{})) {
+	properties := map[string]bool{}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		propertyPath := path + "/" + strings.NewReplacer("~", "~0", "/", "~1").Replace(key.Value)
+		if properties[key.Value] {
+			report(key, propertyPath, "duplicate property %q", key.Value)
+			continue
+		}
+		properties[key.Value] = true
+		if s.PropertyNames != nil {
+			s.PropertyNames.validateNode(key, propertyPath, report)
+		}
+		if property, ok := s.Properties[key.Value]; ok {
+			property.validateNode(value, propertyPath, report)
+		} else if s.additional != nil {
+			s.additional.validateNode(value, propertyPath, report)
+		} else if s.closed {
+			report(key, propertyPath, "unknown property %q", key.Value)
+		}
+	}
+	for _, name := range s.Required {
+		if !properties[name] {
+			report(node, path, "missing property %q", name)
+		}
+	}
+}
+
+// isJSONType reports whether the YAML node is a value of the JSON type.
+func isJSONType(node *yaml.Node, typ string) bool {
+	switch typ {
+	case "object":
+		return node.Kind == yaml.MappingNode
+	case "array":
+		return node.Kind == yaml.SequenceNode
+	}
+	if node.Kind != yaml.ScalarNode {
+		return false
+	}
+	switch tag := node.ShortTag(); typ {
+	case "string":
+		return tag == "!!str"
+	case "integer":
+		return tag == "!!int"
+	case "number":
+		return tag == "!!int" || tag == "!!float"
+	case "boolean":
+		return tag == "!!bool"
+	}
+	return false
+}
+
+// jsonEqual reports whether the value of the YAML node equals the JSON value.
+func jsonEqual(node *yaml.Node, value json.RawMessage) bool {
+	var v, expected interface{}
+	if node.Decode(&v) != nil || json.Unmarshal(value, &expected) != nil {
+		return false
+	}
+	actual, err := json.Marshal(v)
+	if err != nil {
+		return false
+	}
+	normalized, _ := json.Marshal(expected)
+	return string(actual) == string(normalized)
+}
+
+// sortedSchemaKeys returns the names of the schemas in ascending order.
+func sortedSchemaKeys(schemas map[string]*jsonSchema) []string {
+	keys := make([]string, 0, len(schemas))
+	for key := range schemas {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,240 @@
+// Copyright 2026 The xgen Authors. All rights reserved. Use of this source
+// code is governed by a BSD-style license that can be found in the LICENSE
+// file.
+//
+// Package xgen written in pure Go providing a set of functions that allow you
+// to parse XSD (XML schema files). This library needs Go version 1.10 or
+// later.
+
+package xgen
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// ManifestFile is the name of the manifest in the output directory.
+const ManifestFile = ".xgen-manifest.json"
+
+// Manifest records the hashes of the files each schema file of the input
+// depends on, which are the schema file itself, the schemas read when
+// resolving it through its imports, includes, redefines and overrides and the
+// directories looked up, along with the hash of the config the code is
+// generated by and the hashes of the generated files. A schema file is
+// skipped by Load if none of the files it depends on has changed since it's
+// recorded and its generated files are left as they were written, as its code
+// is up to date.
+type Manifest struct {
+	Config  string                     `json:"config"`
+	Schemas map[string]*ManifestSchema `json:"schemas"`
+
+	dir string // the output directory of the generated files
+}
+
+// ManifestSchema records a schema file of the input in the manifest.
+type ManifestSchema struct {
+	// Inputs are the hashes of the files the schema file depends on by
+	// their paths, the hash of a directory is the one of its entry names.
+	Inputs map[string]string `json:"inputs"`
+	// Locations are the schemas the code is generated for along with the
+	// schema file, such as the imported schemas.
+	Locations []string `json:"locations"`
+	// Outputs are the hashes of the files generated for the schemas by their
+	// slash-separated paths relative to the output directory.
+	Outputs map[string]string `json:"outputs"`
+}
+
+// NewManifest creates an empty manifest of the config hash, which is kept in
+// memory, so the generated files aren't checked against it.
+func NewManifest(config string) *Manifest {
+	return &Manifest{Config: config, Schemas: map[string]*ManifestSchema{}}
+}
+
+// ReadManifest reads the manifest in the output directory, against which the
+// generated files are checked. An empty manifest is returned if there is no
+// manifest, or if it's recorded by another config.
+func ReadManifest(dir, config string) (*Manifest, error) {
+	manifest := NewManifest(config)
+	manifest.dir = dir
+	data, err := os.ReadFile(filepath.Join(dir, ManifestFile))
+	if errors.Is(err, fs.ErrNotExist) {
+		return manifest, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(data, manifest); err != nil {
+		return nil, err
+	}
+	if manifest.Config != config || manifest.Schemas == nil {
+		manifest.Config, manifest.Schemas = config, map[string]*ManifestSchema{}
+	}
+	return manifest, nil
+}
+
+// Write writes the manifest into the directory.
+func (m *Manifest) Write(dir string) error {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err = PrepareOutputDir(dir); err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, ManifestFile), append(data, '\n'), 0o644)
+}
+
+// check reports whether each schema file is up to date, the schema files
+// which are no longer in the input are dropped from the manifest.
+func (m *Manifest) check(opt *Options, files []string) []bool {
+	upToDate := make([]bool, len(files))
+	input := map[string]bool{}
+	for i, file := range files {
+		input[file], upToDate[i] = true, m.upToDate(opt, file)
+	}
+	for file := range m.Schemas {
+		if !input[file] {
+			delete(m.Schemas, file)
+		}
+	}
+	return upToDate
+}
+
+// upToDate reports whether none of the files the schema file depends on has
+// changed since it's recorded in the manifest, and the files generated for it
+// are unchanged in the output directory of the manifest read from it.
+func (m *Manifest) upToDate(opt *Options, file string) bool {
+	schema, ok := m.Schemas[file]
+	if !ok || schema == nil {
+		return false
+	}
+	for dependency, hash := range schema.Inputs {
+		if opt.fileHash(dependency) != hash {
+			return false
+		}
+	}
+	if m.dir == "" {
+		return true
+	}
+	if len(schema.Outputs) == 0 {
+		return false
+	}
+	for output, hash := range schema.Outputs {
+		data, err := os.ReadFile(DirSink(m.dir).path(output))
+		if err != nil || dataHash(data) != hash {
+			return false
+		}
+	}
+	return true
+}
+
+// record records the hashes of the files the schema file depends on, and the
+// locations of the schemas parsed along with it. The schema file is dropped
+// from the manifest if there is no such file, such as when the schema file
+// fails to be parsed.
+func (m *Manifest) record(file string, dependencies map[string]string, parsed map[string][]Component) {
+	if len(dependencies) == 0 {
+		delete(m.Schemas, file)
+		return
+	}
+	locations := make([]string, 0, len(parsed))
+	for location := range parsed {
+		locations = append(locations, location)
+	}
+	sort.Strings(locations)
+	m.Schemas[file] = &ManifestSchema{Inputs: dependencies, Locations: locations}
+}
+
+// locations returns the locations of the schemas recorded along with the
+// schema file, or nil if the schema file isn't recorded.
+func (m *Manifest) locations(file string) []string {
+	if m == nil {
+		return nil
+	}
+	if schema := m.Schemas[file]; schema != nil {
+		return schema.Locations
+	}
+	return nil
+}
+
+// recordOutputs records the hashes of the files generated for the schemas by
+// their locations on the schema files the schemas are generated along with.
+func (m *Manifest) recordOutputs(outputs map[string]map[string]string) {
+	for _, schema := range m.Schemas {
+		for _, location := range schema.Locations {
+			for output, hash := range outputs[location] {
+				if schema.Outputs == nil {
+					schema.Outputs = map[string]string{}
+				}
+				schema.Outputs[output] = hash
+			}
+		}
+	}
+}
+
+// dependencies returns the hashes of the files and the directories the schema
+// file parsed by the options depends on.
+func (opt *Options) dependencies() map[string]string {
+	files := map[string]string{}
+	for _, file := range sortedKeys(opt.symbols.files) {
+		files[file] = opt.fileHash(file)
+	}
+	return files
+}
+
+// fileHash returns the SHA-256 hash of the file, or of the names of the
+// entries of a directory, so that a file added to the directory changes it.
+// An empty string is returned if the file can't be read.
+func (opt *Options) fileHash(file string) string {
+	if fi, err := opt.stat(file); err == nil && fi.IsDir() {
+		entries, err := opt.readDir(file)
+		if err != nil {
+			return ""
+		}
+		var names strings.Builder
+		for _, entry := range entries {
+			names.WriteString(entry.Name())
+			if entry.IsDir() {
+				names.WriteByte('/')
+			}
+			names.WriteByte('\n')
+		}
+		return dataHash([]byte(names.String()))
+	}
+	f, err := opt.open(file)
+	if err != nil {
+		return ""
+	}
+	defer f.Close()
+	h := sha256.New()
+	if _, err = io.Copy(h, f); err != nil {
+		return ""
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// dataHash returns the SHA-256 hash of the data.
+func dataHash(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// outputSink records the hashes of the files written into the sink.
+type outputSink struct {
+	Sink
+	hashes map[string]string
+}
+
+// WriteFile writes the file into the sink and records its hash.
+func (s *outputSink) WriteFile(name string, data []byte) error {
+	s.hashes[name] = dataHash(data)
+	return s.Sink.WriteFile(name, data)
+}
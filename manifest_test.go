@@ -0,0 +1,162 @@
+// Copyright 2026 The xgen Authors. All rights reserved. Use of this source
+// code is governed by a BSD-style license that can be found in the LICENSE
+// file.
+//
+// Package xgen written in pure Go providing a set of functions that allow you
+// to parse XSD (XML schema files). This library needs Go version 1.10 or
+// later.
+
+package xgen
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setLocations returns the locations of the schemas in the set.
+func setLocations(set *SchemaSet) (locations []string) {
+	for _, schema := range set.Schemas {
+		locations = append(locations, schema.Location)
+	}
+	return
+}
+
+func TestManifest(t *testing.T) {
+	fsys := fstest.MapFS{
+		"common.xsd": {Data: []byte(`<xs:schema xmlns:xs="http://www.w3.org/2001/XMLSchema" targetNamespace="urn:common">
+	<xs:simpleType name="Code">
+		<xs:restriction base="xs:string"/>
+	</xs:simpleType>
+</xs:schema>`)},
+		"note.xsd": {Data: []byte(`<xs:schema xmlns:xs="http://www.w3.org/2001/XMLSchema">
+	<xs:element name="note" type="xs:string"/>
+</xs:schema>`)},
+		"order.xsd": {Data: []byte(`<xs:schema xmlns:xs="http://www.w3.org/2001/XMLSchema" xmlns:c="urn:common">
+	<xs:import namespace="urn:common" schemaLocation="common.xsd"/>
+	<xs:complexType name="Order">
+		<xs:attribute name="code" type="c:Code"/>
+	</xs:complexType>
+</xs:schema>`)},
+		"part.xsd": {Data: []byte(`<xs:schema xmlns:xs="http://www.w3.org/2001/XMLSchema" xmlns:c="urn:common">
+	<xs:import namespace="urn:common"/>
+	<xs:complexType name="Part">
+		<xs:attribute name="code" type="c:Code"/>
+	</xs:complexType>
+</xs:schema>`)},
+	}
+	out := t.TempDir()
+	manifest, err := ReadManifest(out, "config")
+	require.NoError(t, err)
+	load := func() *SchemaSet {
+		set, err := Load(context.Background(), &Options{FS: fsys, Lang: "Go", Manifest: manifest})
+		require.NoError(t, err)
+		require.NoError(t, Generate(context.Background(), set, DirSink(out)))
+		return set
+	}
+	assert.Equal(t, []string{"common.xsd", "note.xsd", "order.xsd", "part.xsd"}, setLocations(load()))
+	require.Contains(t, manifest.Schemas, "order.xsd")
+	assert.Equal(t, []string{"common.xsd", "order.xsd"}, manifest.Schemas["order.xsd"].Locations)
+	assert.Contains(t, manifest.Schemas["order.xsd"].Inputs, "common.xsd")
+	assert.Contains(t, manifest.Schemas["part.xsd"].Inputs, ".")
+	assert.Contains(t, manifest.Schemas["order.xsd"].Outputs, "common.xsd.go")
+	assert.Contains(t, manifest.Schemas["order.xsd"].Outputs, "order.xsd.go")
+	assert.Empty(t, setLocations(load()))
+
+	// The imported schema is generated along with the schema importing it
+	fsys["common.xsd"].Data = bytes.ReplaceAll(fsys["common.xsd"].Data, []byte("xs:string"), []byte("xs:int"))
+	set := load()
+	assert.Equal(t, []string{"common.xsd", "order.xsd"}, setLocations(set))
+	full, err := Load(context.Background(), &Options{FS: fsys, Lang: "Go"})
+	require.NoError(t, err)
+	expected := MapSink{}
+	require.NoError(t, Generate(context.Background(), full, expected))
+	for _, name := range []string{"common.xsd.go", "order.xsd.go"} {
+		data, err := os.ReadFile(filepath.Join(out, name))
+		require.NoError(t, err)
+		assert.Equal(t, string(expected[name]), string(data), name)
+	}
+	assert.Contains(t, string(expected["order.xsd.go"]), "Code *int")
+
+	// The code of the imported schema is generated by the first schema file,
+	// and the components of the one up to date stay in the index of the set
+	fsys["order.xsd"].Data = append(fsys["order.xsd"].Data, '\n')
+	set = load()
+	assert.Equal(t, []string{"order.xsd"}, setLocations(set))
+	assert.NotNil(t, set.Type(QName{Space: "urn:common", Local: "Code"}))
+
+	// The generated files which are deleted or edited are generated again
+	require.NoError(t, os.Remove(filepath.Join(out, "note.xsd.go")))
+	assert.Equal(t, []string{"note.xsd"}, setLocations(load()))
+	require.NoError(t, os.WriteFile(filepath.Join(out, "order.xsd.go"), []byte("package schema\n"), 0o644))
+	assert.Equal(t, []string{"order.xsd"}, setLocations(load()))
+	assert.Empty(t, setLocations(load()))
+
+	// A file added to the directory looked up is a change of the directory
+	fsys["extra.xsd"] = &fstest.MapFile{Data: fsys["note.xsd"].Data}
+	assert.Equal(t, []string{"extra.xsd", "part.xsd"}, setLocations(load()))
+
+	delete(fsys, "note.xsd")
+	delete(fsys, "extra.xsd")
+	load()
+	assert.Empty(t, setLocations(load()))
+	assert.NotContains(t, manifest.Schemas, "note.xsd")
+
+	require.NoError(t, manifest.Write(out))
+	read, err := ReadManifest(out, "config")
+	require.NoError(t, err)
+	assert.Equal(t, manifest, read)
+	read, err = ReadManifest(out, "other")
+	require.NoError(t, err)
+	assert.Empty(t, read.Schemas)
+	assert.Equal(t, "other", read.Config)
+	read, err = ReadManifest(filepath.Join(out, "missing"), "config")
+	require.NoError(t, err)
+	assert.Empty(t, read.Schemas)
+
+	// A schema file recorded without its entry is parsed again
+	require.NoError(t, os.WriteFile(filepath.Join(out, ManifestFile), []byte(`{"config": "config", "schemas": {"part.xsd": null}}`), 0o644))
+	manifest, err = ReadManifest(out, "config")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"common.xsd", "order.xsd", "part.xsd"}, setLocations(load()))
+}
+
+func TestDirSinkDiff(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "a.go"), []byte("package a\n\n// A ...\ntype A string\n\n// B ...\ntype B int\n"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "b.go"), []byte("package b\n"), 0o644))
+
+	diff, err := DirSink(dir).Diff(MapSink{"b.go": []byte("package b\n")})
+	require.NoError(t, err)
+	assert.Empty(t, diff)
+
+	diff, err = DirSink(dir).Diff(MapSink{
+		"a.go": []byte("package a\n\n// A ...\ntype A string\n\n// B ...\ntype B int64"),
+		"b.go": []byte("package b\n"),
+		"c.go": []byte("package c\n"),
+	})
+	require.NoError(t, err)
+	a, c := filepath.ToSlash(filepath.Join(dir, "a.go")), filepath.ToSlash(filepath.Join(dir, "c.go"))
+	assert.Equal(t, "--- "+a+"\n+++ "+a+"\n"+
+		"@@ -4,4 +4,4 @@\n type A string\n \n // B ...\n-type B int\n+type B int64\n\\ No newline at end of file\n"+
+		"--- /dev/null\n+++ "+c+"\n@@ -0,0 +1,1 @@\n+package c\n", diff)
+}
+
+func TestUnifiedDiff(t *testing.T) {
+	old := []byte("a\nb\nc\nd\ne\nf\ng\nh\ni\nj\nk\nl\nm\nn\n")
+	new := []byte("a\nB\nc\nd\ne\nf\ng\nh\ni\nj\nk\nl\nm\n")
+	assert.Equal(t, "--- old\n+++ new\n"+
+		"@@ -1,5 +1,5 @@\n a\n-b\n+B\n c\n d\n e\n"+
+		"@@ -11,4 +11,3 @@\n k\n l\n m\n-n\n", unifiedDiff("old", "new", old, new))
+	new = []byte("a\nB\nc\nd\ne\nf\ng\nh\ni\nj\nk\nl\nm\nn\n")
+	old = []byte("a\nb\nc\nd\ne\nf\ng\nH\ni\nj\nk\nl\nm\nn\n")
+	assert.Equal(t, "--- old\n+++ new\n"+
+		"@@ -1,11 +1,11 @@\n a\n-b\n+B\n c\n d\n e\n f\n g\n-H\n+h\n i\n j\n k\n", unifiedDiff("old", "new", old, new))
+	assert.Empty(t, unifiedDiff("old", "new", old, old))
+}
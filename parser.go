@@ -9,12 +9,13 @@
 package xgen
 
 import (
+	"context"
 	"encoding/xml"
 	"fmt"
+	"io"
+	"io/fs"
 	"os"
-	"path/filepath"
 	"reflect"
-	"strings"
 
 	"golang.org/x/net/html/charset"
 )
@@ -33,12 +34,45 @@ type Options struct {
 	LocalNameNSMap      map[string]string
 	NSSchemaLocationMap map[string]string
 	ParseFileList       map[string]bool
-	ParseFileMap        map[string][]interface{}
-	ProtoTree           []interface{}
+	ParseFileMap        map[string][]Component
+	ProtoTree           []Component
 	RemoteSchema        map[string][]byte
+	Fetcher             *SchemaFetcher
+	Catalog             *Catalog
+	// Strict fails the parsing on the malformed XML and on the schema
+	// elements which are not supported, which are reported as warnings and
+	// ignored otherwise.
+	Strict bool
+	// FS is the file system the schemas are read from, in which the
+	// FilePath, the InputDir and the schemaLocations are slash-separated
+	// paths. The files of the operating system are read if it's nil.
+	FS fs.FS
+	// Jobs is the number of the schema files parsed by Load, and generated
+	// by Generate, at the same time. The files are processed one at a time
+	// if it's less than 2, the result is the same either way.
+	Jobs int
+	// Manifest skips the schema files which are up to date in Load, and
+	// records the files the other schema files depend on if it's not nil.
+	Manifest *Manifest
+	// Exclude are the glob patterns of the schema files left out of the
+	// input directory by Load, matched by path.Match against the
+	// slash-separated paths relative to the input directory and their
+	// parent directories. The excluded schemas are still read when the
+	// other ones reference them.
+	Exclude []string
 
 	// Generation options
 	OmitXMLName bool
+	// PrefixNamespaces generates the MarshalXML methods of the document
+	// elements, which write the namespaces with prefixes. The prefixes are
+	// the ones declared in the schema unless the NamespacePrefixes maps the
+	// namespaces to other prefixes.
+	PrefixNamespaces  bool
+	NamespacePrefixes map[string]string
+	// TypeMappings override the types of the generated code by the QNames
+	// of the XSD types, which are the built-in types or the user-defined
+	// simple types, in the language of the options.
+	TypeMappings map[QName]TypeMapping
 
 	InElement        string
 	CurrentEle       string
@@ -47,6 +81,23 @@ type Options struct {
 	InAttributeGroup bool
 	InPluralSequence []bool
 
+	ContentRestriction *SimpleType
+	IdentityConstraint *IdentityConstraint
+
+	ctx          context.Context
+	diagnostics  *Diagnostics // shared with the parsers of the referenced schemas
+	symbols      *symbolTable // shared with the parsers of the referenced schemas
+	schemas      *schemaCache // the referenced schemas reused by the loads of a watcher
+	declarations symbolScope  // the index of the proto tree
+	referenced   bool         // parsed on behalf of another schema
+	redefinition *redefinition
+	schemaURL    string
+	scopes       []*elementScope // the element declarations being parsed
+
+	targetNamespace      string
+	elementFormDefault   string
+	attributeFormDefault string
+
 	SimpleType     *Stack
 	ComplexType    *Stack
 	Element        *Stack
@@ -54,6 +105,7 @@ type Options struct {
 	Group          *Stack
 	AttributeGroup *Stack
 	Choice         *Stack
+	All            *Stack
 }
 
 // NewParser creates a new parser options for the Parse. Useful for XML schema
@@ -64,34 +116,204 @@ func NewParser(options *Options) *Options {
 
 // Parse reads XML documents and return proto tree for every element in the
 // documents by given options. If value of the property extract is false,
-// parse will fetch schema used in <import> or <include> statements.
+// parse will fetch schema used in <import> or <include> statements, and
+// generate the code of the schemas into the output directory. Parse is kept
+// for compatibility, use Load and Generate to parse the schemas and generate
+// the code separately.
 func (opt *Options) Parse() (err error) {
-	opt.FileDir = filepath.Dir(opt.FilePath)
+	if err = opt.parse(); err != nil || opt.Extract {
+		return
+	}
+	return Generate(opt.ctx, newSchemaSet(opt, opt), DirSink(opt.OutputDir))
+}
+
+// Load parses the schema file by given FilePath of the options, or all schema
+// files in the InputDir if the FilePath is empty, along with the schemas they
+// import, include, redefine or override, and returns the schema set without
+// generating code. The problems found in all schema files are returned as
+// Diagnostics, the warnings are held by the set if there is no error. Each
+// schema file is parsed by a copy of the options with the runtime data reset,
+// up to Jobs files at the same time, while the schemas the type references
+// are resolved against are parsed and indexed once for the set. The types of
+// the components are resolved in the Lang of the options, which the code of
+// the set is generated in. The schema files which are up to date in the
+// Manifest of the options are left out of the set.
+func Load(ctx context.Context, cfg *Options) (*SchemaSet, error) {
+	input := cfg.input()
+	files, err := cfg.inputFiles(input)
+	if err != nil {
+		return nil, Diagnostics{newDiagnostic(input, err)}
+	}
+	var upToDate []bool
+	if cfg.Manifest != nil {
+		upToDate = cfg.Manifest.check(cfg, files)
+	}
+	results := make([]*Options, len(files))
+	errs := make([]error, len(files))
+	dependencies := make([]map[string]string, len(files))
+	schemas := cfg.schemas
+	if schemas == nil {
+		schemas = newSchemaCache()
+	}
+	runJobs(ctx, cfg.Jobs, len(files), func(i int) error {
+		if upToDate != nil && upToDate[i] {
+			return nil
+		}
+		opt := *cfg
+		opt.FilePath = files[i]
+		if opt.InputDir == "" {
+			opt.InputDir = input
+		}
+		opt.IncludeMap = make(map[string]bool)
+		opt.LocalNameNSMap = make(map[string]string)
+		opt.NSSchemaLocationMap = make(map[string]string)
+		opt.ParseFileList = make(map[string]bool)
+		opt.ParseFileMap = make(map[string][]Component)
+		opt.RemoteSchema = make(map[string][]byte)
+		opt.ctx, opt.diagnostics, opt.symbols = ctx, nil, newSymbolTable(schemas)
+		results[i], errs[i] = &opt, opt.parse()
+		if cfg.Manifest != nil && errs[i] == nil {
+			dependencies[i] = opt.dependencies()
+		}
+		return nil
+	})
+	if err = ctx.Err(); err != nil {
+		return nil, err
+	}
+	parsed := make([]*Options, 0, len(files))
+	var diagnostics, warnings Diagnostics
+	// The code of a schema parsed on behalf of several schema files is
+	// generated from the first one, which is left out if it's up to date
+	claimed := map[string]bool{} // whether the first one is up to date
+	for i, opt := range results {
+		if opt == nil {
+			for _, location := range cfg.Manifest.locations(files[i]) {
+				if _, ok := claimed[location]; !ok {
+					claimed[location] = true
+				}
+			}
+			continue
+		}
+		if cfg.Manifest != nil {
+			cfg.Manifest.record(files[i], dependencies[i], opt.ParseFileMap)
+		}
+		if errs[i] != nil {
+			if opt.diagnostics == nil || !opt.diagnostics.HasErrors() {
+				opt.diagnostics = &Diagnostics{newDiagnostic(files[i], errs[i])}
+			}
+			diagnostics = diagnostics.add(*opt.diagnostics...)
+			continue
+		}
+		if opt.diagnostics != nil {
+			warnings = warnings.add(*opt.diagnostics...)
+		}
+		for location := range opt.ParseFileMap {
+			if _, ok := claimed[location]; !ok {
+				claimed[location] = false
+			}
+		}
+		parsed = append(parsed, opt)
+	}
+	if len(diagnostics) > 0 {
+		return nil, diagnostics.add(warnings...)
+	}
+	set := newSchemaSet(cfg, parsed...)
+	set.Diagnostics = warnings
+	// The schemas up to date stay in the index of the set, as the components
+	// of the others may refer to them, such as the substitution groups
+	generated := set.Schemas[:0]
+	for _, schema := range set.Schemas {
+		if !claimed[schema.Location] {
+			generated = append(generated, schema)
+		}
+	}
+	set.Schemas = generated
+	return set, nil
+}
+
+// input returns the path of the schema file or the directory loaded by the
+// options, which is the root of the file system if neither is given.
+func (cfg *Options) input() string {
+	input := cfg.FilePath
+	if input == "" {
+		input = cfg.InputDir
+	}
+	if input == "" && cfg.FS != nil {
+		input = "."
+	}
+	return input
+}
+
+// LoadReader parses the schema document read from the reader like Load, the
+// document is named by the FilePath of the options, against which the
+// schemaLocations in the document are resolved.
+func LoadReader(ctx context.Context, r io.Reader, cfg *Options) (*SchemaSet, error) {
+	opt := *cfg
+	opt.IncludeMap = make(map[string]bool)
+	opt.LocalNameNSMap = make(map[string]string)
+	opt.NSSchemaLocationMap = make(map[string]string)
+	opt.ParseFileList = make(map[string]bool)
+	opt.ParseFileMap = make(map[string][]Component)
+	opt.RemoteSchema = make(map[string][]byte)
+	opt.ctx, opt.diagnostics = ctx, nil
+	if err := opt.parseReader(r); err != nil {
+		return nil, err
+	}
+	set := newSchemaSet(cfg, &opt)
+	set.Diagnostics = *opt.diagnostics
+	return set, nil
+}
+
+// parse reads the XML document by given options into the proto tree.
+func (opt *Options) parse() (err error) {
 	var fi os.FileInfo
-	fi, err = os.Stat(opt.FilePath)
+	fi, err = opt.stat(opt.FilePath)
 	if err != nil {
 		return
 	}
 	if fi.IsDir() {
 		return
 	}
-	var xmlFile *os.File
-	xmlFile, err = os.Open(opt.FilePath)
+	var xmlFile fs.File
+	xmlFile, err = opt.open(opt.FilePath)
 	if err != nil {
 		return
 	}
 	defer xmlFile.Close()
+	return opt.parseReader(xmlFile)
+}
+
+// parseReader reads the XML document named by the FilePath of the options
+// from the reader into the proto tree.
+func (opt *Options) parseReader(r io.Reader) (err error) {
+	if opt.ctx == nil {
+		opt.ctx = context.Background()
+	}
+	opt.FileDir = opt.fileDir(opt.FilePath)
+	if opt.diagnostics == nil {
+		opt.diagnostics = &Diagnostics{}
+	}
+	if opt.symbols == nil {
+		opt.symbols = newSymbolTable(nil)
+	}
+	reported := len(*opt.diagnostics)
+	opt.schemaURL = opt.Fetcher.location(opt.FilePath)
 	if !opt.Extract {
 		opt.ParseFileList[opt.FilePath] = true
 		opt.ParseFileMap[opt.FilePath] = opt.ProtoTree
 	}
-	opt.ProtoTree = make([]interface{}, 0)
+	opt.ProtoTree = make([]Component, 0)
+	opt.declarations = symbolScope{}
 
 	opt.InElement = ""
 	opt.CurrentEle = ""
 	opt.InGroup = 0
 	opt.InUnion = false
 	opt.InAttributeGroup = false
+	opt.ContentRestriction = nil
+	opt.IdentityConstraint = nil
+	opt.redefinition = nil
+	opt.scopes = nil
 
 	opt.SimpleType = NewStack()
 	opt.ComplexType = NewStack()
@@ -100,33 +322,88 @@ func (opt *Options) Parse() (err error) {
 	opt.Group = NewStack()
 	opt.AttributeGroup = NewStack()
 	opt.Choice = NewStack()
+	opt.All = NewStack()
 
-	decoder := xml.NewDecoder(xmlFile)
+	decoder := xml.NewDecoder(r)
 	decoder.CharsetReader = charset.NewReaderLabel
-	decoder.Strict = false
+	decoder.Strict = opt.Strict
+	// The element failed to be handled is skipped along with its content, so
+	// that the rest of the schema is parsed to report more diagnostics. The
+	// errors of a referenced schema are returned to the schema referencing it
+	// as they are, which may ignore them when looking up a type
+	var path []string
+	var skip, opaque int
 	for {
-		token, _ := decoder.Token()
+		if err = opt.ctx.Err(); err != nil {
+			return
+		}
+		line, column := decoder.InputPos()
+		token, tokenErr := decoder.Token()
 		if token == nil {
+			if tokenErr != nil && tokenErr != io.EOF {
+				line, column = decoder.InputPos()
+				opt.report(tokenErr, line, column, path)
+			}
 			break
 		}
 
 		switch element := token.(type) {
 		case xml.StartElement:
-
+			path = append(path, componentStep(element))
+			if skip > 0 {
+				continue
+			}
+			// The content of the documentation and appinfo is not a part of
+			// the schema
+			if opaque == 0 {
+				switch element.Name.Local {
+				case "documentation", "appinfo":
+					opaque = len(path)
+				case "annotation":
+				default:
+					if !supportedElements[element.Name.Local] {
+						opt.reportUnsupported(element.Name.Local, line, column, path)
+					}
+				}
+			}
 			opt.InElement = element.Name.Local
 			funcName := fmt.Sprintf("On%s", MakeFirstUpperCase(opt.InElement))
 			if err = callFuncByName(opt, funcName, []reflect.Value{reflect.ValueOf(element), reflect.ValueOf(opt.ProtoTree)}); err != nil {
-				return
+				if opt.referenced {
+					return
+				}
+				opt.report(err, line, column, path)
+				skip = len(path)
 			}
 
 		case xml.EndElement:
-			funcName := fmt.Sprintf("End%s", MakeFirstUpperCase(element.Name.Local))
-			if err = callFuncByName(opt, funcName, []reflect.Value{reflect.ValueOf(element), reflect.ValueOf(opt.ProtoTree)}); err != nil {
-				return
+			if skip == 0 {
+				funcName := fmt.Sprintf("End%s", MakeFirstUpperCase(element.Name.Local))
+				if err = callFuncByName(opt, funcName, []reflect.Value{reflect.ValueOf(element), reflect.ValueOf(opt.ProtoTree)}); err != nil {
+					if opt.referenced {
+						return
+					}
+					opt.report(err, line, column, path)
+				}
+			}
+			if skip == len(path) {
+				skip = 0
+			}
+			if opaque == len(path) {
+				opaque = 0
+			}
+			if len(path) > 0 {
+				path = path[:len(path)-1]
 			}
 		case xml.CharData:
+			if skip > 0 {
+				continue
+			}
 			if err = opt.OnCharData(string(element), opt.ProtoTree); err != nil {
-				return
+				if opt.referenced {
+					return
+				}
+				opt.report(err, line, column, path)
 			}
 		default:
 		}
@@ -136,79 +413,50 @@ func (opt *Options) Parse() (err error) {
 	if !opt.Extract {
 		opt.ParseFileList[opt.FilePath] = true
 		opt.ParseFileMap[opt.FilePath] = opt.ProtoTree
-		path := filepath.Join(opt.OutputDir, strings.TrimPrefix(opt.FilePath, opt.InputDir))
-		if err := PrepareOutputDir(filepath.Dir(path)); err != nil {
-			fmt.Println(err)
-			os.Exit(1)
-		}
-		generator := &CodeGenerator{
-			Lang:           opt.Lang,
-			Package:        opt.Package,
-			File:           path,
-			ProtoTree:      opt.ProtoTree,
-			StructAST:      map[string]string{},
-			ValidatedTypes: map[string]bool{},
-			EmitXMLName:    !opt.OmitXMLName,
-		}
-		funcName := fmt.Sprintf("Gen%s", MakeFirstUpperCase(opt.Lang))
-		if err = callFuncByName(generator, funcName, []reflect.Value{}); err != nil {
-			return
-		}
 	}
-	return
+	if diagnostics := (*opt.diagnostics)[reported:]; diagnostics.HasErrors() {
+		return diagnostics
+	}
+	return nil
 }
 
 // GetValueType convert XSD schema value type to the build-in type for the
-// given value and proto tree.
-func (opt *Options) GetValueType(value string, XSDSchema []interface{}) (valueType string, err error) {
-	if buildType, ok := getBuildInTypeByLang(trimNSPrefix(value), opt.Lang); ok {
+// given value and proto tree. The value is resolved against the symbol table
+// of the schema set, so that each referenced schema is parsed once.
+func (opt *Options) GetValueType(value string, XSDSchema []Component) (valueType string, err error) {
+	name := trimNSPrefix(value)
+	if mappedType, ok := opt.mappedType(value); ok {
+		valueType = mappedType
+		return
+	}
+	if buildType, ok := getBuildInTypeByLang(name, opt.Lang); ok {
 		valueType = buildType
 		return
 	}
-	valueType = getBasefromSimpleType(trimNSPrefix(value), XSDSchema)
-	if valueType != trimNSPrefix(value) && valueType != "" {
+	valueType = opt.declarations.base(name, XSDSchema)
+	if valueType != name && valueType != "" {
 		return
 	}
 	if opt.Extract {
 		return
 	}
-	schemaLocation := opt.NSSchemaLocationMap[opt.parseNS(value)]
-	if isValidURL(schemaLocation) {
+	if opt.parseNS(value) == xmlNamespace {
+		// The attributes of the xml namespace: lang, space, base and id
+		valueType, _ = getBuildInTypeByLang("string", opt.Lang)
+		return
+	}
+	var xsdFile string
+	if xsdFile, err = opt.schemaFile(opt.NSSchemaLocationMap[opt.parseNS(value)], opt.parseNS(value)); err != nil || xsdFile == "" {
 		return
 	}
-	xsdFile := filepath.Join(opt.FileDir, schemaLocation)
 	var fi os.FileInfo
-	fi, err = os.Stat(xsdFile)
+	fi, err = opt.stat(xsdFile)
 	if err != nil {
 		return
 	}
 	if fi.IsDir() {
 		// extract type of value from include schema.
-		valueType = ""
-		for include := range opt.IncludeMap {
-			parser := NewParser(&Options{
-				FilePath:            filepath.Join(opt.FileDir, include),
-				OutputDir:           opt.OutputDir,
-				Extract:             true,
-				Lang:                opt.Lang,
-				IncludeMap:          opt.IncludeMap,
-				LocalNameNSMap:      opt.LocalNameNSMap,
-				NSSchemaLocationMap: opt.NSSchemaLocationMap,
-				ParseFileList:       opt.ParseFileList,
-				ParseFileMap:        opt.ParseFileMap,
-				ProtoTree:           make([]interface{}, 0),
-			})
-			if parser.Parse() != nil {
-				return
-			}
-			if vt := getBasefromSimpleType(trimNSPrefix(value), parser.ProtoTree); vt != trimNSPrefix(value) {
-				valueType = vt
-			}
-		}
-		if valueType == "" {
-			valueType = trimNSPrefix(value)
-		}
-		return
+		return opt.includedBase(name)
 	}
 
 	depXSDSchema, ok := opt.ParseFileMap[xsdFile]
@@ -223,32 +471,30 @@ func (opt *Options) GetValueType(value string, XSDSchema []interface{}) (valueTy
 			NSSchemaLocationMap: opt.NSSchemaLocationMap,
 			ParseFileList:       opt.ParseFileList,
 			ParseFileMap:        opt.ParseFileMap,
-			ProtoTree:           make([]interface{}, 0),
+			ProtoTree:           make([]Component, 0),
+			RemoteSchema:        opt.RemoteSchema,
+			Fetcher:             opt.Fetcher,
+			Catalog:             opt.Catalog,
+			FS:                  opt.FS,
+			Strict:              opt.Strict,
+			TypeMappings:        opt.TypeMappings,
+			diagnostics:         opt.diagnostics,
+			symbols:             opt.symbols,
+			referenced:          true,
+			ctx:                 opt.ctx,
 		})
-		if parser.Parse() != nil {
+		if parser.parse() != nil {
 			return
 		}
 		depXSDSchema = parser.ProtoTree
 	}
-	valueType = getBasefromSimpleType(trimNSPrefix(value), depXSDSchema)
-	if valueType != trimNSPrefix(value) && valueType != "" {
+	valueType = opt.symbols.scope(xsdFile).base(name, depXSDSchema)
+	if valueType != name && valueType != "" {
 		return
 	}
-	parser := NewParser(&Options{
-		FilePath:            xsdFile,
-		OutputDir:           opt.OutputDir,
-		Extract:             true,
-		Lang:                opt.Lang,
-		IncludeMap:          opt.IncludeMap,
-		LocalNameNSMap:      opt.LocalNameNSMap,
-		NSSchemaLocationMap: opt.NSSchemaLocationMap,
-		ParseFileList:       opt.ParseFileList,
-		ParseFileMap:        opt.ParseFileMap,
-		ProtoTree:           make([]interface{}, 0),
-	})
-	if parser.Parse() != nil {
-		return
+	// The schema may still be being parsed on behalf of this one
+	if schema := opt.referencedSchema(xsdFile); schema.err == nil {
+		valueType = schema.symbols.base(name, schema.tree)
 	}
-	valueType = getBasefromSimpleType(trimNSPrefix(value), parser.ProtoTree)
 	return
 }
@@ -9,12 +9,18 @@
 package xgen
 
 import (
+	"bytes"
+	"context"
 	"fmt"
+	"io/fs"
 	"io/ioutil"
 	"os"
+	"os/exec"
 	"path/filepath"
 	"strings"
+	"sync"
 	"testing"
+	"testing/fstest"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -84,8 +90,8 @@ func testParseForSource(t *testing.T, lang string, fileExt string, langDirName s
 					LocalNameNSMap:      make(map[string]string),
 					NSSchemaLocationMap: make(map[string]string),
 					ParseFileList:       make(map[string]bool),
-					ParseFileMap:        make(map[string][]interface{}),
-					ProtoTree:           make([]interface{}, 0),
+					ParseFileMap:        make(map[string][]Component),
+					ProtoTree:           make([]Component, 0),
 				})
 				err = parser.Parse()
 				assert.NoError(t, err, file)
@@ -103,6 +109,12 @@ func testParseForSource(t *testing.T, lang string, fileExt string, langDirName s
 			})
 		}
 	}
+	// The helper types of the Go code are declared once by the helpers file
+	if expectedHelpers, err := ioutil.ReadFile(filepath.Join(codeDir, goHelpersFile)); err == nil && lang == "Go" {
+		actualHelpers, err := ioutil.ReadFile(filepath.Join(outputDir, goHelpersFile))
+		require.NoError(t, err)
+		assert.Equal(t, string(expectedHelpers), string(actualHelpers))
+	}
 }
 
 func TestParseTypeScript(t *testing.T) {
@@ -136,3 +148,865 @@ func TestParseRust(t *testing.T) {
 func TestParseRustExternal(t *testing.T) {
 	testParseForSource(t, "Rust", "rs", "rs", externalFixtureDir, true)
 }
+
+func TestParseGoAssertErrors(t *testing.T) {
+	testCases := []struct {
+		test     string
+		expected string
+	}{
+		{test: "matches(@code, '[A-Z]+')", expected: `unsupported function matches() at offset 0`},
+		{test: "item[1] = 'a'", expected: `predicates are not supported at offset 4`},
+		{test: "/order/item", expected: `absolute paths are not supported at offset 0`},
+		{test: "@from + 1 le @to", expected: `arithmetic operators are not supported at offset 6`},
+		{test: "@from le @to le 3", expected: `comparisons cannot be chained at offset 13`},
+		{test: "@to = 'a'", expected: `cannot compare number with string`},
+		{test: "@from le @until", expected: `@until: Range has no attribute until`},
+		{test: "item = 'a'", expected: `item may select more than one node, use count() or exists()`},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			dir := t.TempDir()
+			file := filepath.Join(dir, "assert.xsd")
+			require.NoError(t, ioutil.WriteFile(file, []byte(`<schema xmlns="http://www.w3.org/2001/XMLSchema">
+  <complexType name="Range">
+    <sequence>
+      <element name="item" type="string" maxOccurs="unbounded"/>
+    </sequence>
+    <attribute name="from" type="int"/>
+    <attribute name="to" type="int"/>
+    <assert test="`+strings.NewReplacer("<", "&lt;", `"`, "&quot;").Replace(tc.test)+`"/>
+  </complexType>
+</schema>`), 0644))
+			err := NewParser(&Options{
+				FilePath:            file,
+				InputDir:            dir,
+				OutputDir:           dir,
+				Lang:                "Go",
+				IncludeMap:          make(map[string]bool),
+				LocalNameNSMap:      make(map[string]string),
+				NSSchemaLocationMap: make(map[string]string),
+				ParseFileList:       make(map[string]bool),
+				ParseFileMap:        make(map[string][]Component),
+				ProtoTree:           make([]Component, 0),
+			}).Parse()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.expected)
+		})
+	}
+}
+
+func TestParseCatalog(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"root.xsd": `<schema xmlns="http://www.w3.org/2001/XMLSchema" xmlns:c="urn:common" xmlns:u="urn:units" xmlns:a="urn:address">
+  <import namespace="urn:common" schemaLocation="http://standards.example.org/common/1.0/common.xsd"/>
+  <import namespace="urn:units"/>
+  <import namespace="urn:address" schemaLocation="urn:address:1.0"/>
+  <element name="Parcel">
+    <complexType>
+      <sequence>
+        <element name="code" type="c:Code"/>
+        <element name="weight" type="u:Kilograms"/>
+        <element name="zip" type="a:Zip"/>
+      </sequence>
+    </complexType>
+  </element>
+</schema>`,
+		"vendor/common/1.0/common.xsd": `<schema xmlns="http://www.w3.org/2001/XMLSchema" targetNamespace="urn:common">
+  <simpleType name="Code">
+    <restriction base="int"/>
+  </simpleType>
+</schema>`,
+		"units/units.xsd": `<schema xmlns="http://www.w3.org/2001/XMLSchema" targetNamespace="urn:units">
+  <simpleType name="Kilograms">
+    <restriction base="decimal"/>
+  </simpleType>
+</schema>`,
+		"address.xsd": `<schema xmlns="http://www.w3.org/2001/XMLSchema" targetNamespace="urn:address">
+  <simpleType name="Zip">
+    <restriction base="boolean"/>
+  </simpleType>
+</schema>`,
+		"catalog.xml": `<catalog xmlns="urn:oasis:names:tc:entity:xmlns:xml:catalog">
+  <rewriteSystem systemIdStartString="http://standards.example.org/" rewritePrefix="vendor/"/>
+  <rewriteSystem systemIdStartString="http://standards.example.org/common/" rewritePrefix="vendor/common/"/>
+  <nextCatalog catalog="units/catalog.xml"/>
+</catalog>`,
+		"units/catalog.xml": `<catalog xmlns="urn:oasis:names:tc:entity:xmlns:xml:catalog">
+  <group xml:base="../">
+    <system systemId="urn:address:1.0" uri="address.xsd"/>
+  </group>
+  <uri name="urn:units" uri="units.xsd"/>
+  <nextCatalog catalog="../catalog.xml"/>
+</catalog>`,
+	}
+	for name, content := range files {
+		file := filepath.Join(dir, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(file), 0o755))
+		require.NoError(t, ioutil.WriteFile(file, []byte(content), 0o644))
+	}
+	catalog, err := LoadCatalog(filepath.Join(dir, "catalog.xml"))
+	require.NoError(t, err)
+	resolved, ok := catalog.ResolveSystem("http://standards.example.org/common/1.0/common.xsd")
+	assert.True(t, ok)
+	assert.Equal(t, filepath.ToSlash(filepath.Join(dir, "vendor", "common", "1.0", "common.xsd")), resolved)
+	_, ok = catalog.ResolveURI("urn:unknown")
+	assert.False(t, ok)
+
+	file := filepath.Join(dir, "root.xsd")
+	require.NoError(t, NewParser(&Options{
+		FilePath:            file,
+		InputDir:            dir,
+		OutputDir:           dir,
+		Lang:                "Go",
+		IncludeMap:          make(map[string]bool),
+		LocalNameNSMap:      make(map[string]string),
+		NSSchemaLocationMap: make(map[string]string),
+		ParseFileList:       make(map[string]bool),
+		ParseFileMap:        make(map[string][]Component),
+		ProtoTree:           make([]Component, 0),
+		RemoteSchema:        make(map[string][]byte),
+		Fetcher:             &SchemaFetcher{CacheDir: t.TempDir(), Offline: true},
+		Catalog:             catalog,
+	}).Parse())
+	code, err := ioutil.ReadFile(file + ".go")
+	require.NoError(t, err)
+	assert.Contains(t, string(code), "type Parcel struct {\n\tCode   int     `xml:\"code\"`\n\tWeight float64 `xml:\"weight\"`\n\tZip    bool    `xml:\"zip\"`\n}")
+}
+
+func TestParseGoPrefixNamespaces(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, NewParser(&Options{
+		FilePath:            filepath.Join(testFixtureDir, "xsd", "namespace.xsd"),
+		InputDir:            filepath.Join(testFixtureDir, "xsd"),
+		OutputDir:           dir,
+		Lang:                "Go",
+		Package:             "main",
+		IncludeMap:          make(map[string]bool),
+		LocalNameNSMap:      make(map[string]string),
+		NSSchemaLocationMap: make(map[string]string),
+		ParseFileList:       make(map[string]bool),
+		ParseFileMap:        make(map[string][]Component),
+		ProtoTree:           make([]Component, 0),
+		PrefixNamespaces:    true,
+		NamespacePrefixes:   map[string]string{"urn:example:orders": "o"},
+	}).Parse())
+	output := runGoProgram(t, dir, `package main
+
+import (
+	"encoding/xml"
+	"fmt"
+)
+
+func main() {
+	var order Order
+	if err := xml.Unmarshal([]byte(`+"`"+`<Order xmlns="urn:example:orders" xmlns:ord="urn:example:orders" id="o1" ord:revision="2" xml:lang="en"><customer>Ann</customer><note xmlns="">rush</note><entry ord:quantity="3"><sku>A1</sku></entry></Order>`+"`"+`), &order); err != nil {
+		panic(err)
+	}
+	output, err := xml.Marshal(order)
+	if err != nil {
+		panic(err)
+	}
+	fmt.Print(string(output))
+}
+`)
+	assert.Equal(t, `<o:Order id="o1" o:revision="2" xml:lang="en" xmlns:o="urn:example:orders"><o:customer>Ann</o:customer><note>rush</note><o:entry o:quantity="3"><o:sku>A1</o:sku></o:entry></o:Order>`, string(output))
+	code, err := os.ReadFile(filepath.Join(dir, "namespace.xsd.go"))
+	require.NoError(t, err)
+	assert.NotContains(t, string(code), "func prefixWriter")
+	assert.FileExists(t, filepath.Join(dir, goHelpersFile))
+
+	// A single file declares the function writing the prefixes once
+	fsys := fstest.MapFS{"library.xsd": {Data: []byte(`<xs:schema xmlns:xs="http://www.w3.org/2001/XMLSchema" targetNamespace="urn:library" elementFormDefault="qualified">
+	<xs:element name="Book">
+		<xs:complexType>
+			<xs:sequence>
+				<xs:element name="title" type="xs:string"/>
+			</xs:sequence>
+		</xs:complexType>
+	</xs:element>
+	<xs:element name="Shelf">
+		<xs:complexType>
+			<xs:sequence>
+				<xs:element name="label" type="xs:string"/>
+			</xs:sequence>
+		</xs:complexType>
+	</xs:element>
+</xs:schema>`)}}
+	set, err := Load(context.Background(), &Options{FS: fsys, FilePath: "library.xsd", Lang: "Go", Package: "main", PrefixNamespaces: true, NamespacePrefixes: map[string]string{"urn:library": "l"}})
+	require.NoError(t, err)
+	var buf strings.Builder
+	require.NoError(t, Generate(context.Background(), set, &WriterSink{W: &buf}))
+	assert.Equal(t, 1, strings.Count(buf.String(), "func prefixWriter("))
+	dir = t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "library.go"), []byte(buf.String()), 0o644))
+	output = runGoProgram(t, dir, `package main
+
+import (
+	"encoding/xml"
+	"fmt"
+)
+
+func main() {
+	book, _ := xml.Marshal(Book{Title: "Go"})
+	shelf, _ := xml.Marshal(Shelf{Label: "A"})
+	fmt.Print(string(book), string(shelf))
+}
+`)
+	assert.Equal(t, `<l:Book xmlns:l="urn:library"><l:title>Go</l:title></l:Book><l:Shelf xmlns:l="urn:library"><l:label>A</l:label></l:Shelf>`, output)
+}
+
+// TestGenerateGoHelpers generates two schemas using the helper types into a
+// package, which must build as the helper types are declared once.
+func TestGenerateGoHelpers(t *testing.T) {
+	inputDir, dir := t.TempDir(), t.TempDir()
+	for name, schema := range map[string]string{
+		"invoice.xsd": `<xs:schema xmlns:xs="http://www.w3.org/2001/XMLSchema" targetNamespace="urn:invoice" elementFormDefault="qualified">
+	<xs:element name="Invoice">
+		<xs:complexType>
+			<xs:sequence>
+				<xs:element name="total" type="xs:decimal" nillable="true"/>
+				<xs:any namespace="##other" processContents="lax" minOccurs="0" maxOccurs="unbounded"/>
+			</xs:sequence>
+		</xs:complexType>
+	</xs:element>
+	<xs:complexType name="Party">
+		<xs:all>
+			<xs:element name="name" type="xs:string"/>
+		</xs:all>
+	</xs:complexType>
+</xs:schema>`,
+		"order.xsd": `<xs:schema xmlns:xs="http://www.w3.org/2001/XMLSchema" targetNamespace="urn:order" elementFormDefault="qualified">
+	<xs:element name="Order">
+		<xs:complexType>
+			<xs:sequence>
+				<xs:element name="note" type="xs:string" nillable="true" minOccurs="0"/>
+				<xs:any namespace="##any" processContents="skip" minOccurs="0"/>
+			</xs:sequence>
+		</xs:complexType>
+	</xs:element>
+	<xs:complexType name="Contact">
+		<xs:all>
+			<xs:element name="email" type="xs:string"/>
+		</xs:all>
+	</xs:complexType>
+	<xs:complexType name="Address">
+		<xs:all>
+			<xs:element name="city" type="xs:string"/>
+		</xs:all>
+	</xs:complexType>
+</xs:schema>`,
+	} {
+		require.NoError(t, os.WriteFile(filepath.Join(inputDir, name), []byte(schema), 0o644))
+	}
+	set, err := Load(context.Background(), &Options{InputDir: inputDir, Lang: "Go", Package: "main"})
+	require.NoError(t, err)
+	sink := MapSink{}
+	require.NoError(t, Generate(context.Background(), set, sink))
+	assert.Len(t, sink, 3)
+	for name, code := range sink {
+		if name != goHelpersFile {
+			assert.NotContains(t, string(code), "type Nillable", name)
+			assert.NotContains(t, string(code), "type AnyElement", name)
+			assert.NotContains(t, string(code), "type childReader", name)
+		}
+		require.NoError(t, DirSink(dir).WriteFile(name, code))
+	}
+	output := runGoProgram(t, dir, `package main
+
+import (
+	"encoding/xml"
+	"fmt"
+)
+
+func main() {
+	_ = Invoice{Total: Nillable[float64]{Nil: true}, Any: []AnyElement{{}}}
+	_ = Order{Note: &Nillable[string]{Value: "rush"}}
+	var party Party
+	var contact Contact
+	var address Address
+	for v, data := range map[any]string{
+		&party:   "<Party xmlns=\"urn:invoice\"><name>Acme</name></Party>",
+		&contact: "<Contact xmlns=\"urn:order\"><email>sales@example.com</email></Contact>",
+		&address: "<Address xmlns=\"urn:order\"><city>Oslo</city></Address>",
+	} {
+		if err := xml.Unmarshal([]byte(data), v); err != nil {
+			panic(err)
+		}
+	}
+	fmt.Print(party.Name, " ", contact.Email, " ", address.City)
+}
+`)
+	assert.Equal(t, "Acme sales@example.com Oslo", output)
+
+	// A single file declares the helpers used by its structs once
+	set, err = Load(context.Background(), &Options{FilePath: filepath.Join(inputDir, "order.xsd"), Lang: "Go"})
+	require.NoError(t, err)
+	sink = MapSink{}
+	require.NoError(t, Generate(context.Background(), set, sink))
+	require.Len(t, sink, 1)
+	for _, code := range sink {
+		assert.Equal(t, 1, strings.Count(string(code), "type childReader struct"))
+	}
+}
+
+// TestGenerateGoSubstitutionGroups generates a substitution group with the
+// members declared by different schemas into a package. The types of the
+// group are declared once, and the members are told apart by their
+// namespaces.
+func TestGenerateGoSubstitutionGroups(t *testing.T) {
+	inputDir, dir := t.TempDir(), t.TempDir()
+	for name, schema := range map[string]string{
+		"gml.xsd": `<xs:schema xmlns:xs="http://www.w3.org/2001/XMLSchema" xmlns:gml="urn:gml" targetNamespace="urn:gml" elementFormDefault="qualified">
+	<xs:complexType name="GeometryType">
+		<xs:attribute name="id" type="xs:string"/>
+	</xs:complexType>
+	<xs:complexType name="PointType">
+		<xs:complexContent>
+			<xs:extension base="gml:GeometryType">
+				<xs:sequence>
+					<xs:element name="pos" type="xs:string"/>
+				</xs:sequence>
+			</xs:extension>
+		</xs:complexContent>
+	</xs:complexType>
+	<xs:element name="AbstractGeometry" type="gml:GeometryType" abstract="true"/>
+	<xs:element name="Point" type="gml:PointType" substitutionGroup="gml:AbstractGeometry"/>
+</xs:schema>`,
+		"app.xsd": `<xs:schema xmlns:xs="http://www.w3.org/2001/XMLSchema" xmlns:gml="urn:gml" targetNamespace="urn:app" elementFormDefault="qualified">
+	<xs:import namespace="urn:gml" schemaLocation="gml.xsd"/>
+	<xs:complexType name="LineType">
+		<xs:complexContent>
+			<xs:extension base="gml:GeometryType">
+				<xs:sequence>
+					<xs:element name="posList" type="xs:string"/>
+				</xs:sequence>
+			</xs:extension>
+		</xs:complexContent>
+	</xs:complexType>
+	<xs:element name="Line" type="LineType" substitutionGroup="gml:AbstractGeometry"/>
+	<xs:element name="Feature">
+		<xs:complexType>
+			<xs:sequence>
+				<xs:element ref="gml:AbstractGeometry" maxOccurs="unbounded"/>
+				<xs:element name="Point" type="xs:string" minOccurs="0"/>
+			</xs:sequence>
+		</xs:complexType>
+	</xs:element>
+</xs:schema>`,
+	} {
+		require.NoError(t, os.WriteFile(filepath.Join(inputDir, name), []byte(schema), 0o644))
+	}
+	set, err := Load(context.Background(), &Options{InputDir: inputDir, Lang: "Go", Package: "main"})
+	require.NoError(t, err)
+	assert.Len(t, set.Element(QName{Space: "urn:gml", Local: "AbstractGeometry"}).Substitutes, 2)
+	sink := MapSink{}
+	require.NoError(t, Generate(context.Background(), set, sink))
+	assert.Contains(t, string(sink["gml.xsd.go"]), "type AbstractGeometryGroup interface")
+	assert.NotContains(t, string(sink["app.xsd.go"]), "type AbstractGeometryGroup interface")
+	assert.NotContains(t, string(sink["app.xsd.go"]), "type PointElement struct")
+	for name, code := range sink {
+		require.NoError(t, DirSink(dir).WriteFile(name, code))
+	}
+	output := runGoProgram(t, dir, `package main
+
+import (
+	"encoding/xml"
+	"fmt"
+)
+
+func main() {
+	var feature Feature
+	if err := xml.Unmarshal([]byte(`+"`"+`<Feature xmlns="urn:app" xmlns:gml="urn:gml"><gml:Point id="p1"><gml:pos>1 2</gml:pos></gml:Point><Line id="l1"><posList>1 2 3 4</posList></Line><Point>app</Point></Feature>`+"`"+`), &feature); err != nil {
+		panic(err)
+	}
+	for _, geometry := range feature.GmlAbstractGeometry {
+		fmt.Printf("%T ", geometry)
+	}
+	fmt.Println(feature.GmlAbstractGeometry[0].(*PointElement).Pos, *feature.Point)
+	output, err := xml.Marshal(feature)
+	if err != nil {
+		panic(err)
+	}
+	fmt.Print(string(output))
+}
+`)
+	assert.Equal(t, "*main.PointElement *main.LineElement 1 2 app\n"+
+		`<Feature xmlns="urn:app"><Point xmlns="urn:gml" id="p1"><pos xmlns="urn:gml">1 2</pos></Point><Line xmlns="urn:app" id="l1"><posList xmlns="urn:app">1 2 3 4</posList></Line><Point xmlns="urn:app">app</Point></Feature>`, output)
+}
+
+// runGoProgram runs the Go program by given main package source in the
+// directory of the generated code, and returns its output.
+func runGoProgram(t *testing.T, dir, main string) string {
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module generated\n\ngo 1.21\n"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "main.go"), []byte(main), 0o644))
+	cmd := exec.Command("go", "run", ".")
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GOWORK=off", "GOFLAGS=-mod=mod")
+	output, err := cmd.CombinedOutput()
+	require.NoError(t, err, string(output))
+	return string(output)
+}
+
+// componentCounter counts the visited schema components by their kinds.
+type componentCounter map[string]int
+
+func (c componentCounter) VisitSimpleType(*SimpleType)         { c["simpleType"]++ }
+func (c componentCounter) VisitComplexType(*ComplexType)       { c["complexType"]++ }
+func (c componentCounter) VisitElement(*Element)               { c["element"]++ }
+func (c componentCounter) VisitAttribute(*Attribute)           { c["attribute"]++ }
+func (c componentCounter) VisitGroup(*Group)                   { c["group"]++ }
+func (c componentCounter) VisitAttributeGroup(*AttributeGroup) { c["attributeGroup"]++ }
+
+func TestParseIdentityConstraints(t *testing.T) {
+	fsys := fstest.MapFS{"library.xsd": {Data: []byte(`<xs:schema xmlns:xs="http://www.w3.org/2001/XMLSchema" xmlns:l="urn:library" targetNamespace="urn:library">
+	<xs:complexType name="Book">
+		<xs:attribute name="isbn" type="xs:string"/>
+	</xs:complexType>
+	<xs:complexType name="Shelf">
+		<xs:sequence>
+			<xs:element name="book" type="l:Book" maxOccurs="unbounded"/>
+		</xs:sequence>
+	</xs:complexType>
+	<xs:element name="Library">
+		<xs:complexType>
+			<xs:sequence>
+				<xs:element name="section">
+					<xs:complexType>
+						<xs:sequence>
+							<xs:element name="book" type="l:Book" maxOccurs="unbounded"/>
+						</xs:sequence>
+					</xs:complexType>
+					<xs:key name="sectionBook">
+						<xs:selector xpath="l:book"/>
+						<xs:field xpath="@isbn"/>
+					</xs:key>
+				</xs:element>
+				<xs:element name="shelf" type="l:Shelf" maxOccurs="unbounded">
+					<xs:unique name="shelfBook">
+						<xs:selector xpath="l:book"/>
+						<xs:field xpath="@isbn"/>
+					</xs:unique>
+				</xs:element>
+			</xs:sequence>
+		</xs:complexType>
+		<xs:key name="libraryBook">
+			<xs:selector xpath=".//l:book"/>
+			<xs:field xpath="@isbn"/>
+		</xs:key>
+	</xs:element>
+	<xs:element name="Archive" type="l:Shelf">
+		<xs:key name="archiveBook">
+			<xs:selector xpath="l:book"/>
+			<xs:field xpath="@isbn"/>
+		</xs:key>
+	</xs:element>
+</xs:schema>`)}}
+	set, err := Load(context.Background(), &Options{FS: fsys, Lang: "Go"})
+	require.NoError(t, err)
+	names := func(constraints []IdentityConstraint) (names []string) {
+		for _, c := range constraints {
+			names = append(names, c.Name)
+		}
+		return
+	}
+	// The constraints are declared by the enclosing element, an element of an
+	// anonymous complex type holds them in its type
+	library, ok := set.Type(QName{Space: "urn:library", Local: "Library"}).(*ComplexType)
+	require.True(t, ok)
+	assert.Equal(t, []string{"libraryBook"}, names(library.IdentityConstraints))
+	section, ok := set.Type(QName{Space: "urn:library", Local: "section"}).(*ComplexType)
+	require.True(t, ok)
+	assert.Equal(t, []string{"sectionBook"}, names(section.IdentityConstraints))
+	shelf, i := findElement(&Element{Name: "shelf"}, library.Elements)
+	require.True(t, i >= 0)
+	assert.Equal(t, []string{"shelfBook"}, names(shelf.IdentityConstraints))
+	assert.Empty(t, set.Type(QName{Space: "urn:library", Local: "Shelf"}).(*ComplexType).IdentityConstraints)
+	archive := set.Element(QName{Space: "urn:library", Local: "Archive"})
+	require.NotNil(t, archive)
+	assert.Equal(t, []string{"archiveBook"}, names(archive.IdentityConstraints))
+}
+
+// redefineBase is the schema redefined and overridden by the tests.
+const redefineBase = `<xs:schema xmlns:xs="http://www.w3.org/2001/XMLSchema" xmlns:l="urn:library" targetNamespace="urn:library">
+	<xs:simpleType name="Code">
+		<xs:restriction base="xs:string">
+			<xs:maxLength value="10"/>
+		</xs:restriction>
+	</xs:simpleType>
+	<xs:complexType name="Address">
+		<xs:sequence>
+			<xs:element name="street" type="xs:string"/>
+		</xs:sequence>
+		<xs:attribute name="kind" type="xs:string"/>
+	</xs:complexType>
+	<xs:group name="Names">
+		<xs:sequence>
+			<xs:element name="given" type="xs:string"/>
+		</xs:sequence>
+	</xs:group>
+	<xs:attributeGroup name="Audit">
+		<xs:attribute name="created" type="xs:string"/>
+	</xs:attributeGroup>
+	<xs:complexType name="Member">
+		<xs:sequence>
+			<xs:group ref="l:Names"/>
+			<xs:element name="address" type="l:Address"/>
+			<xs:element name="code" type="l:Code"/>
+			<xs:element name="mentor" type="l:Member" minOccurs="0"/>
+		</xs:sequence>
+		<xs:attributeGroup ref="l:Audit"/>
+	</xs:complexType>
+</xs:schema>`
+
+// elementNames returns the names of the elements.
+func elementNames(elements []Element) (names []string) {
+	for _, e := range elements {
+		names = append(names, e.Name)
+	}
+	return
+}
+
+// attributeNames returns the names of the attributes.
+func attributeNames(attributes []Attribute) (names []string) {
+	for _, a := range attributes {
+		names = append(names, a.Name)
+	}
+	return
+}
+
+func TestRedefineSelfReferences(t *testing.T) {
+	fsys := fstest.MapFS{
+		"base.xsd": {Data: []byte(redefineBase)},
+		"library.xsd": {Data: []byte(`<xs:schema xmlns:xs="http://www.w3.org/2001/XMLSchema" xmlns:l="urn:library" targetNamespace="urn:library">
+	<xs:redefine schemaLocation="base.xsd">
+		<xs:simpleType name="Code">
+			<xs:restriction base="l:Code">
+				<xs:pattern value="[A-Z]+"/>
+			</xs:restriction>
+		</xs:simpleType>
+		<xs:complexType name="Address">
+			<xs:complexContent>
+				<xs:extension base="l:Address">
+					<xs:sequence>
+						<xs:element name="country" type="xs:string"/>
+					</xs:sequence>
+				</xs:extension>
+			</xs:complexContent>
+		</xs:complexType>
+		<xs:group name="Names">
+			<xs:sequence>
+				<xs:group ref="l:Names"/>
+				<xs:element name="family" type="xs:string"/>
+			</xs:sequence>
+		</xs:group>
+		<xs:attributeGroup name="Audit">
+			<xs:attributeGroup ref="l:Audit"/>
+			<xs:attribute name="modified" type="xs:string"/>
+		</xs:attributeGroup>
+	</xs:redefine>
+	<xs:complexType name="PostalAddress">
+		<xs:complexContent>
+			<xs:extension base="l:Address">
+				<xs:sequence>
+					<xs:element name="postCode" type="l:Code"/>
+				</xs:sequence>
+			</xs:extension>
+		</xs:complexContent>
+	</xs:complexType>
+</xs:schema>`)},
+	}
+	set, err := Load(context.Background(), &Options{FS: fsys, FilePath: "library.xsd", Lang: "Go", Package: "main"})
+	require.NoError(t, err)
+	name := func(local string) QName { return QName{Space: "urn:library", Local: local} }
+
+	// The redefinitions referring to themselves are merged with the originals
+	code, ok := set.Type(name("Code")).(*SimpleType)
+	require.True(t, ok)
+	assert.Equal(t, "string", code.Base)
+	assert.Equal(t, 10, code.Restriction.MaxLength)
+	assert.Equal(t, "[A-Z]+", code.Restriction.PatternStr)
+	address, ok := set.Type(name("Address")).(*ComplexType)
+	require.True(t, ok)
+	assert.Empty(t, address.Base)
+	assert.Equal(t, []string{"street", "country"}, elementNames(address.Elements))
+	assert.Equal(t, []string{"kind"}, attributeNames(address.Attributes))
+	names := set.Group(name("Names"))
+	require.NotNil(t, names)
+	assert.Empty(t, names.Groups)
+	assert.Equal(t, []string{"given", "family"}, elementNames(names.Elements))
+	audit := set.AttributeGroup(name("Audit"))
+	require.NotNil(t, audit)
+	assert.Equal(t, []string{"created", "modified"}, attributeNames(audit.Attributes))
+
+	// The types referring to the redefined components get the redefinitions
+	var buf strings.Builder
+	require.NoError(t, Generate(context.Background(), set, &WriterSink{W: &buf}))
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "library.go"), []byte(buf.String()), 0o644))
+	output := runGoProgram(t, dir, `package main
+
+import (
+	"encoding/xml"
+	"fmt"
+)
+
+func main() {
+	var member Member
+	if err := xml.Unmarshal([]byte(`+"`"+`<Member><address kind="home"><street>Main</street><country>UK</country></address><code>AB</code></Member>`+"`"+`), &member); err != nil {
+		panic(err)
+	}
+	address := PostalAddress{PostCode: "XY", Address: &Address{Street: "High", Country: "IE"}}
+	fmt.Println(member.Address.Street, member.Address.Country, member.Code.Validate() == nil, Code("ab").Validate() != nil)
+	fmt.Print(address.Street, " ", address.Country, " ", address.PostCode)
+}
+`)
+	assert.Equal(t, "Main UK true true\nHigh IE XY", output)
+}
+
+func TestOverrideSelfReferences(t *testing.T) {
+	fsys := fstest.MapFS{
+		"base.xsd": {Data: []byte(redefineBase)},
+		"library.xsd": {Data: []byte(`<xs:schema xmlns:xs="http://www.w3.org/2001/XMLSchema" xmlns:l="urn:library" targetNamespace="urn:library">
+	<xs:override schemaLocation="base.xsd">
+		<xs:complexType name="Address">
+			<xs:sequence>
+				<xs:element name="line" type="xs:string" maxOccurs="unbounded"/>
+				<xs:element name="previous" type="l:Address" minOccurs="0"/>
+			</xs:sequence>
+		</xs:complexType>
+		<xs:group name="Names">
+			<xs:sequence>
+				<xs:element name="full" type="xs:string"/>
+			</xs:sequence>
+		</xs:group>
+		<xs:complexType name="Extra">
+			<xs:sequence>
+				<xs:element name="note" type="xs:string"/>
+			</xs:sequence>
+		</xs:complexType>
+	</xs:override>
+</xs:schema>`)},
+	}
+	set, err := Load(context.Background(), &Options{FS: fsys, FilePath: "library.xsd", Lang: "Go", Package: "main"})
+	require.NoError(t, err)
+	name := func(local string) QName { return QName{Space: "urn:library", Local: local} }
+
+	// The overrides replace the originals, a reference by the own name refers
+	// to the override itself
+	address, ok := set.Type(name("Address")).(*ComplexType)
+	require.True(t, ok)
+	assert.Equal(t, []string{"line", "previous"}, elementNames(address.Elements))
+	assert.Empty(t, address.Attributes)
+	names := set.Group(name("Names"))
+	require.NotNil(t, names)
+	assert.Equal(t, []string{"full"}, elementNames(names.Elements))
+	assert.Nil(t, set.Type(name("Extra")))
+	audit := set.AttributeGroup(name("Audit"))
+	require.NotNil(t, audit)
+	assert.Equal(t, []string{"created"}, attributeNames(audit.Attributes))
+
+	var buf strings.Builder
+	require.NoError(t, Generate(context.Background(), set, &WriterSink{W: &buf}))
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "library.go"), []byte(buf.String()), 0o644))
+	output := runGoProgram(t, dir, `package main
+
+import (
+	"encoding/xml"
+	"fmt"
+)
+
+func main() {
+	var member Member
+	if err := xml.Unmarshal([]byte(`+"`"+`<Member><address><line>1 Main</line><previous><line>2 High</line></previous></address><code>AB</code></Member>`+"`"+`), &member); err != nil {
+		panic(err)
+	}
+	fmt.Print(member.Address.Line, " ", member.Address.Previous.Line)
+}
+`)
+	assert.Equal(t, "[1 Main] [2 High]", output)
+}
+
+func TestSchemaSet(t *testing.T) {
+	opt := &Options{
+		FilePath:            filepath.Join(testFixtureDir, "xsd", "namespace.xsd"),
+		InputDir:            filepath.Join(testFixtureDir, "xsd"),
+		OutputDir:           t.TempDir(),
+		Lang:                "Go",
+		IncludeMap:          make(map[string]bool),
+		LocalNameNSMap:      make(map[string]string),
+		NSSchemaLocationMap: make(map[string]string),
+		ParseFileList:       make(map[string]bool),
+		ParseFileMap:        make(map[string][]Component),
+		ProtoTree:           make([]Component, 0),
+	}
+	require.NoError(t, NewParser(opt).Parse())
+	set := NewSchemaSet(opt.ParseFileMap)
+	require.Len(t, set.Schemas, 1)
+	assert.Equal(t, opt.FilePath, set.Schemas[0].Location)
+	assert.Equal(t, "urn:example:orders", set.Schemas[0].TargetNamespace)
+
+	order, ok := set.Type(QName{Space: "urn:example:orders", Local: "Order"}).(*ComplexType)
+	require.True(t, ok)
+	assert.True(t, order.Global)
+	entry, ok := set.Type(QName{Space: "urn:example:orders", Local: "OrderEntry"}).(*ComplexType)
+	require.True(t, ok)
+	assert.False(t, entry.Global)
+	assert.Nil(t, set.Type(QName{Local: "OrderEntry"}))
+	revision := set.Attribute(QName{Space: "urn:example:orders", Local: "revision"})
+	require.NotNil(t, revision)
+	assert.Equal(t, "int", revision.Type)
+	assert.Nil(t, set.Element(QName{Space: "urn:example:orders", Local: "Order"}))
+
+	counter := componentCounter{}
+	set.Walk(counter)
+	assert.Equal(t, componentCounter{"complexType": 2, "attribute": 1}, counter)
+}
+
+func TestLoadGenerate(t *testing.T) {
+	inputDir := filepath.Join(testFixtureDir, "xsd")
+	set, err := Load(context.Background(), &Options{InputDir: inputDir, Lang: "TypeScript"})
+	require.NoError(t, err)
+	require.NotNil(t, set.Type(QName{Space: "urn:example:orders", Local: "OrderEntry"}))
+
+	sink := MapSink{}
+	require.NoError(t, Generate(context.Background(), set, sink))
+	assert.Len(t, sink, len(set.Schemas))
+	for _, schema := range set.Schemas {
+		name := strings.TrimPrefix(filepath.ToSlash(strings.TrimPrefix(schema.Location, inputDir)), "/") + ".ts"
+		expected, err := os.ReadFile(filepath.Join(testFixtureDir, "ts", filepath.FromSlash(name)))
+		require.NoError(t, err)
+		assert.Equal(t, string(expected), string(sink[name]), name)
+	}
+
+	assert.Equal(t, "TypeScript", set.Lang)
+	set, err = Load(context.Background(), &Options{InputDir: inputDir, Lang: "Kotlin"})
+	require.NoError(t, err)
+	assert.EqualError(t, Generate(context.Background(), set, sink), "unsupported language Kotlin")
+
+	file := filepath.Join(inputDir, "base64.xsd")
+	set, err = Load(context.Background(), &Options{FilePath: file, Lang: "Go"})
+	require.NoError(t, err)
+	require.Len(t, set.Schemas, 1)
+	output := filepath.Join(t.TempDir(), "base64")
+	require.NoError(t, Generate(context.Background(), set, DirSink(output)))
+	expected, err := os.ReadFile(filepath.Join(testFixtureDir, "go", "base64.xsd.go"))
+	require.NoError(t, err)
+	actual, err := os.ReadFile(output + ".go")
+	require.NoError(t, err)
+	assert.Equal(t, string(expected), string(actual))
+}
+
+func TestLoadGenerateCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := Load(ctx, &Options{InputDir: filepath.Join(testFixtureDir, "xsd"), Lang: "Go"})
+	assert.ErrorIs(t, err, context.Canceled)
+
+	set, err := Load(context.Background(), &Options{FilePath: filepath.Join(testFixtureDir, "xsd", "base64.xsd"), Lang: "Go"})
+	require.NoError(t, err)
+	sink := MapSink{}
+	assert.ErrorIs(t, Generate(ctx, set, sink), context.Canceled)
+	assert.Empty(t, sink)
+}
+
+func TestLoadGenerateConcurrently(t *testing.T) {
+	inputDir := filepath.Join(testFixtureDir, "xsd")
+	generate := func(lang string, jobs int) (MapSink, Diagnostics) {
+		set, err := Load(context.Background(), &Options{InputDir: inputDir, Lang: lang, Jobs: jobs})
+		require.NoError(t, err)
+		sink := MapSink{}
+		require.NoError(t, Generate(context.Background(), set, sink))
+		return sink, set.Diagnostics
+	}
+	for _, lang := range []string{"Go", "C", "Java", "Rust", "TypeScript"} {
+		t.Run(lang, func(t *testing.T) {
+			t.Parallel()
+			expected, expectedDiagnostics := generate(lang, 1)
+			actual, diagnostics := generate(lang, 8)
+			assert.Equal(t, expected, actual)
+			assert.Equal(t, expectedDiagnostics, diagnostics)
+		})
+	}
+
+	var started []int
+	var mu sync.Mutex
+	err := runJobs(context.Background(), 4, 100, func(i int) error {
+		mu.Lock()
+		started = append(started, i)
+		mu.Unlock()
+		if i == 7 || i == 9 {
+			return fmt.Errorf("job %d failed", i)
+		}
+		return nil
+	})
+	assert.EqualError(t, err, "job 7 failed")
+	assert.Less(t, len(started), 100)
+}
+
+// TestGenerateDeterministic generates the schemas in the test directory
+// repeatedly, the generated code must be the same byte for byte every time.
+func TestGenerateDeterministic(t *testing.T) {
+	inputDir := filepath.Join(testFixtureDir, "xsd")
+	for _, lang := range []string{"Go", "C", "Java", "Rust", "TypeScript"} {
+		t.Run(lang, func(t *testing.T) {
+			t.Parallel()
+			var expected MapSink
+			for i := 0; i < 20; i++ {
+				set, err := Load(context.Background(), &Options{InputDir: inputDir, Lang: lang})
+				require.NoError(t, err)
+				sink := MapSink{}
+				require.NoError(t, Generate(context.Background(), set, sink))
+				if expected == nil {
+					expected = sink
+					continue
+				}
+				require.Len(t, sink, len(expected))
+				for name, data := range expected {
+					if !bytes.Equal(data, sink[name]) {
+						t.Fatalf("the code of %s differs in run %d:\n%s\nfrom:\n%s", name, i+1, sink[name], data)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestWriterSink(t *testing.T) {
+	set, err := Load(context.Background(), &Options{FilePath: filepath.Join(testFixtureDir, "xsd", "base64.xsd"), Lang: "Go"})
+	require.NoError(t, err)
+	var buf strings.Builder
+	sink := &WriterSink{W: &buf}
+	require.NoError(t, Generate(context.Background(), set, sink))
+	expected, err := os.ReadFile(filepath.Join(testFixtureDir, "go", "base64.xsd.go"))
+	require.NoError(t, err)
+	assert.Equal(t, string(expected), buf.String())
+	assert.EqualError(t, sink.WriteFile("other.xsd.go", nil), "can not write other.xsd.go after .go into a single stream")
+}
+
+// FuzzParse checks that no schema panics the parser or the code generators,
+// seeded by the schemas in the test directory. The schemas referenced by the
+// seeds are resolved from the test directory, and the remote schemas are
+// disabled.
+func FuzzParse(f *testing.F) {
+	fsys, err := EmbedFS(testSchemas, "test/xsd")
+	require.NoError(f, err)
+	require.NoError(f, fs.WalkDir(fsys, ".", func(file string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		data, err := fs.ReadFile(fsys, file)
+		f.Add(data)
+		return err
+	}))
+	f.Fuzz(func(t *testing.T, data []byte) {
+		for _, lang := range []string{"Go", "C", "Java", "Rust", "TypeScript"} {
+			set, err := LoadReader(context.Background(), bytes.NewReader(data), &Options{FilePath: "fuzz.xsd", FS: fsys, Lang: lang})
+			if err != nil {
+				continue
+			}
+			_ = Generate(context.Background(), set, MapSink{})
+		}
+	})
+}
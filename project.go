@@ -0,0 +1,155 @@
+// Copyright 2026 The xgen Authors. All rights reserved. Use of this source
+// code is governed by a BSD-style license that can be found in the LICENSE
+// file.
+//
+// Package xgen written in pure Go providing a set of functions that allow you
+// to parse XSD (XML schema files). This library needs Go version 1.10 or
+// later.
+
+package xgen
+
+import (
+	_ "embed"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ProjectFiles are the names of the project config files looked up in the
+// working directory, in order.
+var ProjectFiles = []string{"xgen.yaml", "xgen.yml", "xgen.json"}
+
+// projectSchemaData is the published schema of the project config.
+//
+//go:embed xgen.schema.json
+var projectSchemaData []byte
+
+// projectSchema returns the parsed schema of the project config.
+var projectSchema = sync.OnceValues(func() (*jsonSchema, error) {
+	return parseJSONSchema(projectSchemaData)
+})
+
+// Project is the project config read from an xgen.yaml or xgen.json file,
+// which declares the targets of generating the code. The config is versioned
+// and validated against the schema xgen.schema.json.
+type Project struct {
+	Version int          `yaml:"version"`
+	Fetch   *FetchConfig `yaml:"fetch"`
+	Targets []*Target    `yaml:"targets"`
+}
+
+// FetchConfig is the config of fetching the remote schemas, shared by the
+// targets of the project. The remote schemas are skipped unless it's enabled.
+type FetchConfig struct {
+	Enabled       bool     `yaml:"enabled"`
+	Cache         string   `yaml:"cache"`
+	Offline       bool     `yaml:"offline"`
+	AllowHosts    []string `yaml:"allowHosts"`
+	MaxSchemaSize int64    `yaml:"maxSchemaSize"`
+	Timeout       string   `yaml:"timeout"`
+}
+
+// Target is a target of the project, which generates the code of the schema
+// files of the input in a language into the output.
+type Target struct {
+	Name    string   `yaml:"name"`
+	Input   string   `yaml:"input"`
+	Exclude []string `yaml:"exclude"`
+	Output  string   `yaml:"output"`
+	Lang    string   `yaml:"lang"`
+	Package string   `yaml:"package"`
+	Catalog []string `yaml:"catalog"`
+	Strict  bool     `yaml:"strict"`
+	Jobs    int      `yaml:"jobs"`
+	// Types are the types of the generated code by the QNames of the XSD
+	// types mapped to them.
+	Types map[string]TypeConfig `yaml:"types"`
+	Go    GoConfig              `yaml:"go"`
+}
+
+// TypeConfig is the config of the type of the generated code an XSD type is
+// mapped to.
+type TypeConfig struct {
+	Type   string `yaml:"type"`
+	Import string `yaml:"import"`
+}
+
+// GoConfig is the config of the generated Go code.
+type GoConfig struct {
+	OmitXMLName      bool `yaml:"omitXMLName"`
+	PrefixNamespaces bool `yaml:"prefixNamespaces"`
+	// NamespacePrefixes are the namespaces by the prefixes written by the
+	// MarshalXML methods.
+	NamespacePrefixes map[string]string `yaml:"namespacePrefixes"`
+}
+
+// TypeMappings returns the type mappings of the target by the QNames of the
+// XSD types. The import of a type without one is given by the type in the
+// language of the target, see ParseTypeMapping.
+func (t *Target) TypeMappings() (map[QName]TypeMapping, error) {
+	mappings := make(map[QName]TypeMapping, len(t.Types))
+	for name, typ := range t.Types {
+		qname, err := ParseQName(name)
+		if err != nil {
+			return nil, err
+		}
+		mapping := TypeMapping{Type: typ.Type, Import: typ.Import}
+		if mapping.Import == "" {
+			mapping = ParseTypeMapping(t.Lang, typ.Type)
+		}
+		mappings[qname] = mapping
+	}
+	return mappings, nil
+}
+
+// LoadProject reads the project config file in YAML or JSON. The diagnostics
+// are returned if the config doesn't match its schema. The relative paths in
+// the config are resolved against the directory of the config file, and the
+// output of a target is the xgen_out directory there if it's not given.
+func LoadProject(file string) (*Project, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	schema, err := projectSchema()
+	if err != nil {
+		return nil, err
+	}
+	var doc yaml.Node
+	if err = yaml.Unmarshal(data, &doc); err != nil {
+		return nil, Diagnostics{{Severity: SeverityError, Code: CodeInvalidConfig, File: file, Message: err.Error(), err: err}}
+	}
+	root := &doc
+	if doc.Kind == yaml.DocumentNode {
+		root = doc.Content[0]
+	}
+	if diagnostics := schema.validate(file, root); len(diagnostics) > 0 {
+		return nil, diagnostics
+	}
+	var project Project
+	if err = root.Decode(&project); err != nil {
+		return nil, Diagnostics{{Severity: SeverityError, Code: CodeInvalidConfig, File: file, Message: err.Error(), err: err}}
+	}
+	dir := filepath.Dir(file)
+	resolve := func(path *string) {
+		if *path != "" && !filepath.IsAbs(*path) {
+			*path = filepath.Join(dir, filepath.FromSlash(*path))
+		}
+	}
+	if project.Fetch != nil {
+		resolve(&project.Fetch.Cache)
+	}
+	for _, target := range project.Targets {
+		if target.Output == "" {
+			target.Output = "xgen_out"
+		}
+		resolve(&target.Input)
+		resolve(&target.Output)
+		for i := range target.Catalog {
+			resolve(&target.Catalog[i])
+		}
+	}
+	return &project, nil
+}
@@ -0,0 +1,230 @@
+// Copyright 2026 The xgen Authors. All rights reserved. Use of this source
+// code is governed by a BSD-style license that can be found in the LICENSE
+// file.
+//
+// Package xgen written in pure Go providing a set of functions that allow you
+// to parse XSD (XML schema files). This library needs Go version 1.10 or
+// later.
+
+package xgen
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadProject(t *testing.T) {
+	dir := t.TempDir()
+	yamlFile, jsonFile := filepath.Join(dir, "xgen.yaml"), filepath.Join(dir, "xgen.json")
+	require.NoError(t, os.WriteFile(yamlFile, []byte(`version: 1
+fetch:
+  enabled: true
+  offline: true
+  timeout: 1m30s
+targets:
+  - name: orders
+    input: xsd/orders
+    exclude: [legacy, "*.draft.xsd"]
+    output: gen/orders
+    lang: Go
+    package: orders
+    catalog: [catalog.xml]
+    jobs: 4
+    types:
+      xs:decimal: {type: github.com/shopspring/decimal.Decimal}
+      "{urn:orders}Code": {type: codes.Code, import: example.com/codes}
+    go:
+      omitXMLName: true
+      namespacePrefixes:
+        o: urn:orders
+  - input: /schemas/common.xsd
+    lang: TypeScript
+`), 0o644))
+	require.NoError(t, os.WriteFile(jsonFile, []byte(`{
+	"version": 1,
+	"fetch": {"enabled": true, "offline": true, "timeout": "1m30s"},
+	"targets": [
+		{
+			"name": "orders",
+			"input": "xsd/orders",
+			"exclude": ["legacy", "*.draft.xsd"],
+			"output": "gen/orders",
+			"lang": "Go",
+			"package": "orders",
+			"catalog": ["catalog.xml"],
+			"jobs": 4,
+			"types": {
+				"xs:decimal": {"type": "github.com/shopspring/decimal.Decimal"},
+				"{urn:orders}Code": {"type": "codes.Code", "import": "example.com/codes"}
+			},
+			"go": {"omitXMLName": true, "namespacePrefixes": {"o": "urn:orders"}}
+		},
+		{"input": "/schemas/common.xsd", "lang": "TypeScript"}
+	]
+}
+`), 0o644))
+	expected := &Project{
+		Version: 1,
+		Fetch:   &FetchConfig{Enabled: true, Offline: true, Timeout: "1m30s"},
+		Targets: []*Target{{
+			Name:    "orders",
+			Input:   filepath.Join(dir, "xsd", "orders"),
+			Exclude: []string{"legacy", "*.draft.xsd"},
+			Output:  filepath.Join(dir, "gen", "orders"),
+			Lang:    "Go",
+			Package: "orders",
+			Catalog: []string{filepath.Join(dir, "catalog.xml")},
+			Jobs:    4,
+			Types: map[string]TypeConfig{
+				"xs:decimal":       {Type: "github.com/shopspring/decimal.Decimal"},
+				"{urn:orders}Code": {Type: "codes.Code", Import: "example.com/codes"},
+			},
+			Go: GoConfig{OmitXMLName: true, NamespacePrefixes: map[string]string{"o": "urn:orders"}},
+		}, {
+			Input:  "/schemas/common.xsd",
+			Output: filepath.Join(dir, "xgen_out"),
+			Lang:   "TypeScript",
+		}},
+	}
+	if filepath.Separator != '/' {
+		expected.Targets[1].Input = filepath.Join(dir, "schemas", "common.xsd")
+	}
+	for _, file := range []string{yamlFile, jsonFile} {
+		project, err := LoadProject(file)
+		require.NoError(t, err, file)
+		assert.Equal(t, expected, project, file)
+	}
+
+	for config, messages := range map[string][]string{
+		"": {": error: must be an object"},
+		"version: 2\ntargets: []\n": {
+			":1:10: error: must be 1",
+			":2:10: error: must have at least 1 items",
+		},
+		"version: 1\nfetch:\n  timeout: 30\n  offline: yes\n": {
+			":3:12: error: must be a string",
+			":4:12: error: must be a boolean",
+			":1:1: error: missing property \"targets\"",
+		},
+		"version: 1\ntargets:\n  - input: a.xsd\n    lang: Python\n    jobs: 0\n    output: \"\"\n    go: {omitXmlName: true}\n    lang: Go\n": {
+			":4:11: error: must be one of Go, C, Java, Rust, TypeScript",
+			":5:11: error: must be at least 1",
+			":6:13: error: must not be shorter than 1 characters",
+			":7:10: error: unknown property \"omitXmlName\"",
+			":8:5: error: duplicate property \"lang\"",
+		},
+		"version: 1\ntargets:\n  - input: a.xsd\n    lang: Go\n    types:\n      c:Code: {type: Code}\n      xs:date: {import: time}\n": {
+			`:6:7: error: must match ^(\{[^{}]*\}|(xs|xsd|xml):)?[^{}:]+$`,
+			":7:16: error: missing property \"type\"",
+		},
+		"version: 1\ntargets: [{input: a.xsd, lang: Go}]\nfetch: {timeout: soon}\n": {
+			`:3:18: error: must match ^([0-9]+(\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$`,
+		},
+	} {
+		require.NoError(t, os.WriteFile(yamlFile, []byte(config), 0o644))
+		_, err := LoadProject(yamlFile)
+		var diagnostics Diagnostics
+		require.True(t, errors.As(err, &diagnostics), config)
+		actual := make([]string, len(diagnostics))
+		for i, d := range diagnostics {
+			assert.Equal(t, CodeInvalidConfig, d.Code)
+			actual[i] = strings.TrimPrefix(d.Error(), yamlFile)
+		}
+		assert.Equal(t, messages, actual, config)
+	}
+	require.NoError(t, os.WriteFile(yamlFile, []byte("version: 1\ntargets:\n  - lang: Go\n"), 0o644))
+	_, err := LoadProject(yamlFile)
+	var diagnostics Diagnostics
+	require.True(t, errors.As(err, &diagnostics))
+	assert.Equal(t, "/targets/0", diagnostics[0].Path)
+}
+
+func TestTargetTypeMappings(t *testing.T) {
+	target := &Target{Lang: "Go", Types: map[string]TypeConfig{
+		"xs:decimal":           {Type: "github.com/shopspring/decimal.Decimal"},
+		"xs:date":              {Type: "civil.Date", Import: "cloud.google.com/go/civil"},
+		"xs:duration":          {Type: "github.com/sosodev/duration/v2.Duration"},
+		"xs:anyURI":            {Type: "gopkg.in/url.v1.URL"},
+		"{urn:orders}Code":     {Type: "string"},
+		"{urn:orders}Quantity": {Type: "uint"},
+	}}
+	mappings, err := target.TypeMappings()
+	require.NoError(t, err)
+	assert.Equal(t, map[QName]TypeMapping{
+		{Space: xsdNamespace, Local: "decimal"}:  {Type: "decimal.Decimal", Import: "github.com/shopspring/decimal"},
+		{Space: xsdNamespace, Local: "date"}:     {Type: "civil.Date", Import: "cloud.google.com/go/civil"},
+		{Space: xsdNamespace, Local: "duration"}: {Type: "duration.Duration", Import: "github.com/sosodev/duration/v2"},
+		{Space: xsdNamespace, Local: "anyURI"}:   {Type: "url.URL", Import: "gopkg.in/url.v1"},
+		{Space: "urn:orders", Local: "Code"}:     {Type: "string"},
+		{Space: "urn:orders", Local: "Quantity"}: {Type: "uint"},
+	}, mappings)
+
+	// The types of the other languages are written as is
+	target = &Target{Lang: "Java", Types: map[string]TypeConfig{"xs:decimal": {Type: "java.math.BigDecimal"}}}
+	mappings, err = target.TypeMappings()
+	require.NoError(t, err)
+	assert.Equal(t, map[QName]TypeMapping{{Space: xsdNamespace, Local: "decimal"}: {Type: "java.math.BigDecimal"}}, mappings)
+	_, err = (&Target{Lang: "Go", Types: map[string]TypeConfig{"c:Code": {Type: "string"}}}).TypeMappings()
+	assert.EqualError(t, err, `unknown prefix "c" of QName "c:Code", use {namespace}Code instead`)
+}
+
+func TestProjectSchema(t *testing.T) {
+	schema, err := projectSchema()
+	require.NoError(t, err)
+	// The properties of the published schema are the fields of the config
+	var check func(typ reflect.Type, s *jsonSchema, path string)
+	check = func(typ reflect.Type, s *jsonSchema, path string) {
+		s = s.resolve()
+		for typ.Kind() == reflect.Ptr || typ.Kind() == reflect.Slice || typ.Kind() == reflect.Map {
+			switch typ.Kind() {
+			case reflect.Slice:
+				s = s.Items.resolve()
+			case reflect.Map:
+				s = s.additional.resolve()
+			}
+			typ = typ.Elem()
+		}
+		if typ.Kind() != reflect.Struct {
+			return
+		}
+		var fields []string
+		for i := 0; i < typ.NumField(); i++ {
+			name := typ.Field(i).Tag.Get("yaml")
+			fields = append(fields, name)
+			require.Contains(t, s.Properties, name, path)
+			check(typ.Field(i).Type, s.Properties[name], path+"/"+name)
+		}
+		sort.Strings(fields)
+		assert.Equal(t, sortedSchemaKeys(s.Properties), fields, path)
+	}
+	check(reflect.TypeOf(Project{}), schema, "")
+}
+
+func TestLoadExclude(t *testing.T) {
+	schema := []byte(`<xs:schema xmlns:xs="http://www.w3.org/2001/XMLSchema"/>`)
+	fsys := fstest.MapFS{
+		"order.xsd":         {Data: schema},
+		"order.draft.xsd":   {Data: schema},
+		"legacy/old.xsd":    {Data: schema},
+		"common/legacy.xsd": {Data: schema},
+		"common/types.xsd":  {Data: schema},
+	}
+	set, err := Load(context.Background(), &Options{FS: fsys, Lang: "Go", Exclude: []string{"legacy", "*.draft.xsd"}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"common/legacy.xsd", "common/types.xsd", "order.xsd"}, setLocations(set))
+	set, err = Load(context.Background(), &Options{FS: fsys, Lang: "Go", Exclude: []string{"common/*"}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"legacy/old.xsd", "order.draft.xsd", "order.xsd"}, setLocations(set))
+	_, err = Load(context.Background(), &Options{FS: fsys, Lang: "Go", Exclude: []string{"["}})
+	assert.EqualError(t, err, `.: error: invalid exclude pattern "[": syntax error in pattern`)
+}
@@ -13,9 +13,13 @@ import "regexp"
 // SimpleType definitions provide for constraining character information item
 // [children] of element and attribute information items.
 // https://www.w3.org/TR/xmlschema-1/#Simple_Type_Definitions
+//
+// The Namespace holds the target namespace of the schema the type is defined
+// in.
 type SimpleType struct {
 	Doc         string
 	Name        string
+	Namespace   string
 	Base        string
 	Anonymous   bool
 	List        bool
@@ -31,26 +35,44 @@ type SimpleType struct {
 // attributes; Controlling the substitutability of elements through the
 // mechanism of element substitution groups.
 // https://www.w3.org/TR/xmlschema-1/#cElement_Declarations
+//
+// The SubstitutionGroup holds the QName of the head element of the
+// substitution group the element is a member of. The Substitutes of a head
+// element lists the elements of the schema set which may appear in place of
+// it, the members of nested substitution groups included.
+//
+// The Namespace holds the namespace of the element name, which is the target
+// namespace for the top-level and qualified local elements, and empty for
+// the unqualified ones.
 type Element struct {
-	Doc         string
-	Name        string
-	Wildcard    bool
-	Type        string
-	TypeRef     string
-	Restriction Restriction
-	Abstract    bool
-	Plural      bool
-	Optional    bool
-	Nillable    bool
-	Default     string
+	Doc                 string
+	Name                string
+	Namespace           string
+	Wildcard            bool
+	Type                string
+	TypeRef             string
+	Restriction         Restriction
+	Abstract            bool
+	Plural              bool
+	Optional            bool
+	Nillable            bool
+	Default             string
+	SubstitutionGroup   QName
+	Substitutes         []Element
+	IdentityConstraints []IdentityConstraint
 }
 
 // Attribute declarations provide for: Local validation of attribute
 // information item values using a simple type definition; Specifying default
 // or fixed values for attribute information items.
 // https://www.w3.org/TR/xmlschema-1/structures.html#element-attribute
+//
+// The Namespace holds the namespace of the attribute name, which is the
+// target namespace for the top-level and qualified local attributes, and
+// empty for the unqualified ones.
 type Attribute struct {
 	Name        string
+	Namespace   string
 	Doc         string
 	Type        string
 	TypeRef     string
@@ -58,6 +80,7 @@ type Attribute struct {
 	Plural      bool
 	Default     string
 	Optional    bool
+	Prohibited  bool
 }
 
 // ComplexType definitions are identified by their {name} and {target
@@ -70,18 +93,35 @@ type Attribute struct {
 // XML representation of schema components (specifically in <element>). See
 // References to schema components across namespaces for the use of component
 // identifiers when importing one schema into another.
+//
+// The Content holds simpleContent or complexContent when the type is derived
+// from the Base, and the Derivation holds the derivation method: extension
+// or restriction. The Restriction holds the facets of a simpleContent
+// restriction. The Assertions hold the XSD 1.1 assert elements of the type.
+// The Namespace holds the {target namespace}, and the Global is set for the
+// anonymous type of a top-level element, which is named after the element.
 // https://www.w3.org/TR/xmlschema-1/structures.html#element-complexType
 type ComplexType struct {
-	Doc            string
-	Name           string
-	Base           string
-	Anonymous      bool
-	Elements       []Element
-	Attributes     []Attribute
-	Groups         []Group
-	Choice         []Choice
-	AttributeGroup []AttributeGroup
-	Mixed          bool
+	Doc                 string
+	Name                string
+	Namespace           string
+	Global              bool
+	Base                string
+	Content             string
+	Derivation          string
+	Restriction         Restriction
+	Anonymous           bool
+	Elements            []Element
+	Attributes          []Attribute
+	Groups              []Group
+	Choice              []Choice
+	All                 *All
+	AttributeGroup      []AttributeGroup
+	IdentityConstraints []IdentityConstraint
+	Assertions          []Assertion
+	Any                 []Wildcard
+	AnyAttribute        *Wildcard
+	Mixed               bool
 }
 
 // Group (model group) definitions are provided primarily for reference from
@@ -90,12 +130,15 @@ type ComplexType struct {
 // facility.
 // https://www.w3.org/TR/xmlschema-1/structures.html#cModel_Group_Definitions
 type Group struct {
-	Doc      string
-	Name     string
-	Elements []Element
-	Groups   []Group
-	Plural   bool
-	Ref      string
+	Doc       string
+	Name      string
+	Namespace string
+	Elements  []Element
+	Groups    []Group
+	Any       []Wildcard
+	All       *All
+	Plural    bool
+	Ref       string
 }
 
 // Choice definitions are provided primarily for reference from
@@ -111,6 +154,19 @@ type Choice struct {
 	Plural bool
 }
 
+// All definitions are provided primarily for reference from the XML
+// Representation of Model Group Definitions with the compositor all, which
+// states that all the elements in the group may appear once or not at all,
+// and that they may appear in any order. The Elements holds the names of the
+// elements in the group, and the Optional is set when the group itself is
+// optional, in which case all of its elements are optional.
+// https://www.w3.org/TR/xmlschema-1/#element-all
+type All struct {
+	ID       string
+	Elements []string
+	Optional bool
+}
+
 // AttributeGroup definitions do not participate in ·validation· as such, but
 // the {attribute uses} and {attribute wildcard} of one or more complex type
 // definitions may be constructed in whole or part by reference to an
@@ -121,10 +177,56 @@ type Choice struct {
 // <attributeGroup>).
 // https://www.w3.org/TR/xmlschema-1/structures.html#Attribute_Group_Definition
 type AttributeGroup struct {
-	Doc        string
-	Name       string
-	Ref        string
-	Attributes []Attribute
+	Doc          string
+	Name         string
+	Namespace    string
+	Ref          string
+	Attributes   []Attribute
+	AnyAttribute *Wildcard
+}
+
+// IdentityConstraint definitions are provided for reference from the XML
+// Representation of Identity-constraint Definitions: unique, key and keyref.
+// The Selector holds the restricted XPath expression which selects the nodes
+// the constraint applies to, relative to the declaring element, and the
+// Fields hold the restricted XPath expressions of the values identifying each
+// node. The Refer holds the name of the key or unique constraint referenced
+// by a keyref constraint. The identity constraints of an element declared
+// with an anonymous complex type are held by the ComplexType.
+// https://www.w3.org/TR/xmlschema-1/#cIdentity-constraint_Definitions
+type IdentityConstraint struct {
+	Doc      string
+	Name     string
+	Category string
+	Refer    string
+	Selector string
+	Fields   []string
+}
+
+// Wildcard components provide for validation of attribute and element
+// information items dependent on their namespace names, but independently of
+// their local names. A wildcard is declared by the any and anyAttribute
+// elements, the Namespace holds the namespace constraint (##any, ##other,
+// ##local, ##targetNamespace or a list of namespace names) and the
+// ProcessContents holds one of strict, lax or skip.
+// https://www.w3.org/TR/xmlschema-1/#Wildcards
+type Wildcard struct {
+	Doc             string
+	Namespace       string
+	ProcessContents string
+	Plural          bool
+	Optional        bool
+}
+
+// Assertion constrains the existence and values of related elements and
+// attributes by an XPath 2.0 expression, declared by the assert element of a
+// complex type or the assertion facet of a simple type restriction. The Test
+// holds the expression which must evaluate to true, the value of a simple
+// type is referenced as $value.
+// https://www.w3.org/TR/xmlschema11-1/#cAssertions
+type Assertion struct {
+	Doc  string
+	Test string
 }
 
 // Restriction are used to define acceptable values for XML elements or
@@ -145,4 +247,5 @@ type Restriction struct {
 	HasLength            bool
 	Pattern              *regexp.Regexp
 	PatternStr           string
+	Assertions           []Assertion
 }
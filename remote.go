@@ -0,0 +1,309 @@
+// Copyright 2026 The xgen Authors. All rights reserved. Use of this source
+// code is governed by a BSD-style license that can be found in the LICENSE
+// file.
+//
+// Package xgen written in pure Go providing a set of functions that allow you
+// to parse XSD (XML schema files). This library needs Go version 1.10 or
+// later.
+
+package xgen
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultMaxSchemaSize is the default limit of the size of a remote
+	// schema in bytes.
+	DefaultMaxSchemaSize = 10 << 20
+	// DefaultFetchTimeout is the default limit of the time of fetching a
+	// remote schema.
+	DefaultFetchTimeout = 30 * time.Second
+)
+
+// SchemaFetcher fetches the remote schemas referenced by the schemaLocation of
+// import, include, redefine and override elements over HTTP(S). Each schema is
+// stored in a persistent cache directory, and is never fetched again once
+// cached. A nil SchemaFetcher disables the remote schemas, which are skipped
+// as if they were not referenced. A SchemaFetcher is safe for concurrent use,
+// and must not be copied after first use.
+type SchemaFetcher struct {
+	// CacheDir is the directory of the cached schemas, the xgen directory in
+	// the user cache directory by default.
+	CacheDir string
+	// Offline prevents fetching the schemas which are not cached.
+	Offline bool
+	// AllowedHosts restricts the hosts the schemas may be fetched from, an
+	// entry starting with a dot matches the subdomains of the domain. All
+	// hosts are allowed if it's empty.
+	AllowedHosts []string
+	// MaxSize limits the size of each schema in bytes, DefaultMaxSchemaSize
+	// if it's zero.
+	MaxSize int64
+	// Timeout limits the time of fetching each schema, DefaultFetchTimeout
+	// if it's zero.
+	Timeout time.Duration
+	// Client is the HTTP client used to fetch the schemas,
+	// http.DefaultClient if it's nil. The redirects it follows are
+	// restricted to the allowed hosts as well.
+	Client *http.Client
+
+	mu        sync.Mutex
+	locations map[string]string // cached file path -> schema URL
+}
+
+// Fetch returns the path of the cached copy of the schema by given URL,
+// fetching the schema if it's not cached.
+func (f *SchemaFetcher) Fetch(location string) (string, error) {
+	return f.FetchContext(context.Background(), location)
+}
+
+// FetchContext is like Fetch, the fetching is canceled along with the context.
+func (f *SchemaFetcher) FetchContext(ctx context.Context, location string) (string, error) {
+	u, err := url.Parse(location)
+	if err != nil {
+		return "", err
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return "", fmt.Errorf("remote schema %s: unsupported scheme %s", location, u.Scheme)
+	}
+	if !validRemoteHost(u.Hostname()) {
+		return "", fmt.Errorf("remote schema %s: invalid host %q", location, u.Hostname())
+	}
+	if !f.allowed(u.Hostname()) {
+		return "", fmt.Errorf("remote schema %s: host %s is not allowed", location, u.Hostname())
+	}
+	cacheDir, err := f.cacheDir()
+	if err != nil {
+		return "", err
+	}
+	file := filepath.Join(cacheDir, filepath.FromSlash(remoteSchemaPath(u)))
+	f.mu.Lock()
+	if f.locations == nil {
+		f.locations = make(map[string]string)
+	}
+	f.locations[file] = u.String()
+	f.mu.Unlock()
+	if fi, err := os.Stat(file); err == nil && !fi.IsDir() {
+		return file, nil
+	}
+	if f.Offline {
+		return "", fmt.Errorf("remote schema %s is not cached in %s and fetching is disabled in offline mode", location, cacheDir)
+	}
+	body, err := f.download(ctx, u)
+	if err != nil {
+		return "", fmt.Errorf("remote schema %s: %w", location, err)
+	}
+	if err = PrepareOutputDir(filepath.Dir(file)); err != nil {
+		return "", err
+	}
+	// Write to a temporary file first, so that an interrupted fetch never
+	// leaves a truncated schema in the cache
+	tmp, err := os.CreateTemp(filepath.Dir(file), ".fetch-*")
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err = tmp.Write(body); err != nil {
+		tmp.Close()
+		return "", err
+	}
+	if err = tmp.Close(); err != nil {
+		return "", err
+	}
+	return file, os.Rename(tmp.Name(), file)
+}
+
+// download fetches the schema within the limits of size and time.
+func (f *SchemaFetcher) download(ctx context.Context, u *url.URL) ([]byte, error) {
+	timeout, maxSize, client := f.Timeout, f.MaxSize, f.Client
+	if timeout <= 0 {
+		timeout = DefaultFetchTimeout
+	}
+	if maxSize <= 0 {
+		maxSize = DefaultMaxSchemaSize
+	}
+	if client == nil {
+		client = http.DefaultClient
+	}
+	client = f.redirectClient(client)
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	if resp.ContentLength > maxSize {
+		return nil, fmt.Errorf("size exceeds the limit of %d bytes", maxSize)
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(body)) > maxSize {
+		return nil, fmt.Errorf("size exceeds the limit of %d bytes", maxSize)
+	}
+	return body, nil
+}
+
+// redirectClient returns a copy of the client which checks the scheme and the
+// host of each redirect, so that a schema is never fetched from a host which is
+// not allowed through a redirect.
+func (f *SchemaFetcher) redirectClient(client *http.Client) *http.Client {
+	checkRedirect := client.CheckRedirect
+	redirect := *client
+	redirect.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+		if req.URL.Scheme != "http" && req.URL.Scheme != "https" {
+			return fmt.Errorf("redirect to %s: unsupported scheme %s", req.URL, req.URL.Scheme)
+		}
+		if !f.allowed(req.URL.Hostname()) {
+			return fmt.Errorf("redirect to %s: host %s is not allowed", req.URL, req.URL.Hostname())
+		}
+		if checkRedirect != nil {
+			return checkRedirect(req, via)
+		}
+		if len(via) >= 10 {
+			return errors.New("stopped after 10 redirects")
+		}
+		return nil
+	}
+	return &redirect
+}
+
+// allowed reports whether the schemas may be fetched from the host.
+func (f *SchemaFetcher) allowed(host string) bool {
+	if len(f.AllowedHosts) == 0 {
+		return true
+	}
+	host = strings.ToLower(host)
+	for _, allowed := range f.AllowedHosts {
+		allowed = strings.ToLower(strings.TrimSpace(allowed))
+		if host == allowed || strings.HasPrefix(allowed, ".") && (strings.HasSuffix(host, allowed) || host == allowed[1:]) {
+			return true
+		}
+	}
+	return false
+}
+
+// cacheDir returns the directory of the cached schemas.
+func (f *SchemaFetcher) cacheDir() (string, error) {
+	if f.CacheDir != "" {
+		return filepath.Abs(f.CacheDir)
+	}
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "xgen"), nil
+}
+
+// location returns the URL of the schema cached in given file, or an empty
+// string if the file is not a cached schema.
+func (f *SchemaFetcher) location(file string) string {
+	if f == nil {
+		return ""
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.locations[file]
+}
+
+// validRemoteHost reports whether the host name of a remote schema is the
+// name of a directory in the cache, which holds the copies of the schemas
+// fetched from the host.
+func validRemoteHost(host string) bool {
+	return host != "" && host != "." && host != ".." && !strings.ContainsAny(host, `/\`)
+}
+
+// remoteSchemaPath returns the slash-separated relative path of the local
+// copy of the schema by given URL, which consists of the host and the path of
+// the URL. The query of the URL is hashed into the file name, and so is the
+// host if it's not a valid directory name, so that the path never leaves the
+// directory it's relative to.
+func remoteSchemaPath(u *url.URL) string {
+	p := path.Clean("/" + u.Path)
+	if strings.HasSuffix(u.Path, "/") || p == "/" {
+		p = path.Join(p, "index.xsd")
+	}
+	if u.RawQuery != "" {
+		sum := sha256.Sum256([]byte(u.RawQuery))
+		ext := path.Ext(p)
+		p = strings.TrimSuffix(p, ext) + "-" + hex.EncodeToString(sum[:6]) + ext
+	}
+	host := strings.ReplaceAll(u.Host, ":", "_")
+	if !validRemoteHost(u.Hostname()) {
+		sum := sha256.Sum256([]byte(u.Host))
+		host = "host-" + hex.EncodeToString(sum[:6])
+	}
+	return host + p
+}
+
+// absoluteLocation returns the schemaLocation resolved against the URL of
+// the schema being parsed if the schema is a remote one, the locations in the
+// local schemas are relative to their directories.
+func (opt *Options) absoluteLocation(location string) string {
+	if opt.schemaURL == "" || location == "" || isValidURL(location) {
+		return location
+	}
+	base, err := url.Parse(opt.schemaURL)
+	if err != nil {
+		return location
+	}
+	ref, err := url.Parse(filepath.ToSlash(location))
+	if err != nil {
+		return location
+	}
+	return base.ResolveReference(ref).String()
+}
+
+// schemaFile returns the path of the schema by given schemaLocation and
+// namespace, which are resolved through the catalog first, the remote schemas
+// are fetched into the cache. An empty path is returned for a remote schema if
+// fetching is disabled.
+func (opt *Options) schemaFile(location, namespace string) (string, error) {
+	location = opt.resolveLocation(location, namespace)
+	if !isValidURL(location) {
+		if file := catalogFile(location); filepath.IsAbs(file) {
+			return file, nil
+		}
+		return opt.joinFileDir(location), nil
+	}
+	if opt.Fetcher == nil {
+		return "", nil
+	}
+	ctx := opt.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	file, err := opt.Fetcher.FetchContext(ctx, location)
+	if err != nil {
+		return "", &codedError{CodeRemoteSchema, err}
+	}
+	if opt.RemoteSchema != nil {
+		if opt.RemoteSchema[location], err = os.ReadFile(file); err != nil {
+			return "", err
+		}
+	}
+	return file, nil
+}
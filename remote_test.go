@@ -0,0 +1,235 @@
+// Copyright 2026 The xgen Authors. All rights reserved. Use of this source
+// code is governed by a BSD-style license that can be found in the LICENSE
+// file.
+//
+// Package xgen written in pure Go providing a set of functions that allow you
+// to parse XSD (XML schema files). This library needs Go version 1.10 or
+// later.
+
+package xgen
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// remoteSchemas are served by the test server of remote schemas.
+var remoteSchemas = map[string]string{
+	"/schemas/common.xsd": `<schema xmlns="http://www.w3.org/2001/XMLSchema" targetNamespace="urn:common" xmlns:c="urn:common">
+  <include schemaLocation="types/base.xsd"/>
+  <complexType name="Address">
+    <sequence>
+      <element name="city" type="c:CityName"/>
+    </sequence>
+  </complexType>
+</schema>`,
+	"/schemas/types/base.xsd": `<schema xmlns="http://www.w3.org/2001/XMLSchema" targetNamespace="urn:common">
+  <simpleType name="CityName">
+    <restriction base="string">
+      <maxLength value="32"/>
+    </restriction>
+  </simpleType>
+</schema>`,
+}
+
+// newRemoteSchemaServer starts a server of the remote schemas, which counts
+// the requests.
+func newRemoteSchemaServer(t *testing.T, requests *int) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*requests++
+		body, ok := remoteSchemas[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// writeRemoteSchemaRoot writes a local schema importing the remote schema.
+func writeRemoteSchemaRoot(t *testing.T, dir, location string) string {
+	file := filepath.Join(dir, "root.xsd")
+	require.NoError(t, os.WriteFile(file, []byte(`<schema xmlns="http://www.w3.org/2001/XMLSchema" xmlns:c="urn:common">
+  <import namespace="urn:common" schemaLocation="`+location+`"/>
+  <element name="Customer">
+    <complexType>
+      <sequence>
+        <element name="address" type="c:Address"/>
+        <element name="city" type="c:CityName"/>
+      </sequence>
+    </complexType>
+  </element>
+</schema>`), 0o644))
+	return file
+}
+
+// parseRemoteSchemaRoot generates Go code for the local schema with given
+// fetcher, and returns the generated code.
+func parseRemoteSchemaRoot(t *testing.T, file string, fetcher *SchemaFetcher) (string, error) {
+	dir := filepath.Dir(file)
+	err := NewParser(&Options{
+		FilePath:            file,
+		InputDir:            dir,
+		OutputDir:           dir,
+		Lang:                "Go",
+		IncludeMap:          make(map[string]bool),
+		LocalNameNSMap:      make(map[string]string),
+		NSSchemaLocationMap: make(map[string]string),
+		ParseFileList:       make(map[string]bool),
+		ParseFileMap:        make(map[string][]Component),
+		ProtoTree:           make([]Component, 0),
+		RemoteSchema:        make(map[string][]byte),
+		Fetcher:             fetcher,
+	}).Parse()
+	if err != nil {
+		return "", err
+	}
+	code, err := os.ReadFile(file + ".go")
+	return string(code), err
+}
+
+func TestParseRemoteSchema(t *testing.T) {
+	var requests int
+	server := newRemoteSchemaServer(t, &requests)
+	cacheDir := t.TempDir()
+	file := writeRemoteSchemaRoot(t, t.TempDir(), server.URL+"/schemas/common.xsd")
+
+	code, err := parseRemoteSchemaRoot(t, file, &SchemaFetcher{CacheDir: cacheDir})
+	require.NoError(t, err)
+	assert.Contains(t, code, "*CityName `xml:\"city\"`")
+	u, err := url.Parse(server.URL)
+	require.NoError(t, err)
+	host := strings.ReplaceAll(u.Host, ":", "_")
+	common, err := os.ReadFile(filepath.Join(filepath.Dir(file), host, "schemas", "common.xsd.go"))
+	require.NoError(t, err)
+	assert.Contains(t, string(common), "type Address struct")
+	assert.FileExists(t, filepath.Join(cacheDir, host, "schemas", "common.xsd"))
+	assert.FileExists(t, filepath.Join(cacheDir, host, "schemas", "types", "base.xsd"))
+	fetched := requests
+
+	// The cached schemas are used in the offline mode
+	server.Close()
+	code, err = parseRemoteSchemaRoot(t, file, &SchemaFetcher{CacheDir: cacheDir, Offline: true})
+	require.NoError(t, err)
+	assert.Contains(t, code, "*CityName `xml:\"city\"`")
+	assert.Equal(t, fetched, requests)
+
+	// The remote schemas are skipped without a fetcher
+	code, err = parseRemoteSchemaRoot(t, file, nil)
+	require.NoError(t, err)
+	assert.Contains(t, code, "*CityName `xml:\"city\"`")
+}
+
+func TestParseRemoteSchemaErrors(t *testing.T) {
+	var requests int
+	server := newRemoteSchemaServer(t, &requests)
+	slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(5 * time.Second):
+		}
+	}))
+	t.Cleanup(slow.Close)
+	redirect := httptest.NewServer(http.RedirectHandler(server.URL+"/schemas/common.xsd", http.StatusFound))
+	t.Cleanup(redirect.Close)
+	testCases := []struct {
+		name     string
+		location string
+		fetcher  *SchemaFetcher
+		expected string
+	}{
+		{name: "offline", location: server.URL + "/schemas/common.xsd", fetcher: &SchemaFetcher{Offline: true}, expected: "fetching is disabled in offline mode"},
+		{name: "host", location: server.URL + "/schemas/common.xsd", fetcher: &SchemaFetcher{AllowedHosts: []string{".example.org"}}, expected: "host 127.0.0.1 is not allowed"},
+		{name: "redirect", location: strings.Replace(redirect.URL, "127.0.0.1", "localhost", 1) + "/schemas/common.xsd", fetcher: &SchemaFetcher{AllowedHosts: []string{"localhost"}}, expected: "host 127.0.0.1 is not allowed"},
+		{name: "size", location: server.URL + "/schemas/common.xsd", fetcher: &SchemaFetcher{MaxSize: 64}, expected: "size exceeds the limit of 64 bytes"},
+		{name: "status", location: server.URL + "/schemas/missing.xsd", fetcher: &SchemaFetcher{}, expected: "unexpected status 404 Not Found"},
+		{name: "dot host", location: "http://../schemas/common.xsd", fetcher: &SchemaFetcher{}, expected: `invalid host ".."`},
+		{name: "timeout", location: slow.URL + "/schemas/common.xsd", fetcher: &SchemaFetcher{Timeout: 50 * time.Millisecond}, expected: "context deadline exceeded"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.fetcher.CacheDir = t.TempDir()
+			_, err := parseRemoteSchemaRoot(t, writeRemoteSchemaRoot(t, t.TempDir(), tc.location), tc.fetcher)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.expected)
+		})
+	}
+}
+
+func TestRemoteSchemaPath(t *testing.T) {
+	for location, expected := range map[string]string{
+		"http://www.w3.org/2001/xml.xsd":      "www.w3.org/2001/xml.xsd",
+		"https://example.org:8443/schemas/":   "example.org_8443/schemas/index.xsd",
+		"http://example.org/a/../../../x.xsd": "example.org/x.xsd",
+		"http://example.org/x.xsd?v=1":        "example.org/x-" + sha256Prefix("v=1") + ".xsd",
+		"http://../x.xsd":                     "host-" + sha256Prefix("..") + "/x.xsd",
+		"http://..:80/x.xsd":                  "host-" + sha256Prefix("..:80") + "/x.xsd",
+		"http://./x.xsd":                      "host-" + sha256Prefix(".") + "/x.xsd",
+		"http:///x.xsd":                       "host-" + sha256Prefix("") + "/x.xsd",
+	} {
+		u, err := url.Parse(location)
+		require.NoError(t, err, location)
+		p := remoteSchemaPath(u)
+		assert.Equal(t, expected, p, location)
+		assert.True(t, filepath.IsLocal(filepath.FromSlash(p)), location)
+	}
+}
+
+// sha256Prefix returns the hex encoded prefix of the SHA-256 sum of s, which
+// names the local copies of the remote schemas.
+func sha256Prefix(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:6])
+}
+
+func TestSchemaFetcherAllowed(t *testing.T) {
+	fetcher := &SchemaFetcher{AllowedHosts: []string{"www.w3.org", ".example.org"}}
+	for host, expected := range map[string]bool{
+		"www.w3.org":          true,
+		"WWW.W3.ORG":          true,
+		"w3.org":              false,
+		"example.org":         true,
+		"schemas.example.org": true,
+		"badexample.org":      false,
+	} {
+		assert.Equal(t, expected, fetcher.allowed(host), host)
+	}
+}
+
+func TestVendor(t *testing.T) {
+	var requests int
+	server := newRemoteSchemaServer(t, &requests)
+	input, output := t.TempDir(), t.TempDir()
+	writeRemoteSchemaRoot(t, input, server.URL+"/schemas/common.xsd")
+
+	require.NoError(t, Vendor(input, output, &SchemaFetcher{CacheDir: t.TempDir()}))
+	u, err := url.Parse(server.URL)
+	require.NoError(t, err)
+	host := strings.ReplaceAll(u.Host, ":", "_")
+	root, err := os.ReadFile(filepath.Join(output, "root.xsd"))
+	require.NoError(t, err)
+	assert.Contains(t, string(root), `schemaLocation="`+host+`/schemas/common.xsd"`)
+	common, err := os.ReadFile(filepath.Join(output, host, "schemas", "common.xsd"))
+	require.NoError(t, err)
+	assert.Contains(t, string(common), `schemaLocation="types/base.xsd"`)
+	assert.FileExists(t, filepath.Join(output, host, "schemas", "types", "base.xsd"))
+
+	// The vendored schemas are generated without fetching
+	server.Close()
+	code, err := parseRemoteSchemaRoot(t, filepath.Join(output, "root.xsd"), &SchemaFetcher{CacheDir: t.TempDir(), Offline: true})
+	require.NoError(t, err)
+	assert.Contains(t, code, "*CityName `xml:\"city\"`")
+}
@@ -8,7 +8,10 @@
 
 package xgen
 
-import "encoding/xml"
+import (
+	"encoding/xml"
+	"sort"
+)
 
 func (opt *Options) prepareLocalNameNSMap(element xml.StartElement) {
 	for _, ele := range element.Attr {
@@ -28,19 +31,61 @@ func (opt *Options) prepareNSSchemaLocationMap(element xml.StartElement) {
 			if _, ok := opt.NSSchemaLocationMap[currentNS]; ok {
 				continue
 			}
-			if isValidURL(ele.Value) {
-				continue
-				// TODO: fetch remote schema
-				// var err error
-				// if opt.RemoteSchema[ele.Value], err = fetchSchema(ele.Value); err != nil {
-				// 	continue
-				// }
-			}
-			opt.NSSchemaLocationMap[currentNS] = ele.Value
+			opt.NSSchemaLocationMap[currentNS] = opt.absoluteLocation(ele.Value)
 		}
 	}
 }
 
 func (opt *Options) parseNS(str string) (ns string) {
+	if prefix := getNSPrefix(str); prefix == "xml" {
+		return xmlNamespace
+	}
 	return opt.LocalNameNSMap[getNSPrefix(str)]
 }
+
+// declarationNamespace returns the namespace of the name of an element or
+// attribute declaration by given ref and form attributes, the form default of
+// the schema, and whether it's a top-level declaration. The referenced
+// declarations are in the namespace of the QName, the top-level and qualified
+// local declarations are in the target namespace.
+func (opt *Options) declarationNamespace(ref, form, formDefault string, global bool) string {
+	if ref != "" {
+		if ns := opt.parseNS(ref); ns != "" && ns != xsdNamespace {
+			return ns
+		}
+		return opt.targetNamespace
+	}
+	if global || form == "qualified" || form == "" && formDefault == "qualified" {
+		return opt.targetNamespace
+	}
+	return ""
+}
+
+// namespacePrefixes returns the prefixes of the namespaces written by the
+// generated MarshalXML methods, which are the prefixes declared in the schemas
+// overridden by the NamespacePrefixes option.
+func (opt *Options) namespacePrefixes() map[string]string {
+	prefixes := map[string]string{xsiNamespace: "xsi"}
+	declared := make([]string, 0, len(opt.LocalNameNSMap))
+	for prefix := range opt.LocalNameNSMap {
+		declared = append(declared, prefix)
+	}
+	// Take the first prefix in order when a namespace is declared with
+	// several ones, so that the prefixes are stable
+	sort.Sort(sort.Reverse(sort.StringSlice(declared)))
+	for _, prefix := range declared {
+		if ns := opt.LocalNameNSMap[prefix]; prefix != "" && ns != xsdNamespace {
+			prefixes[ns] = prefix
+		}
+	}
+	for ns, prefix := range opt.NamespacePrefixes {
+		prefixes[ns] = prefix
+	}
+	return prefixes
+}
+
+const (
+	xmlNamespace = "http://www.w3.org/XML/1998/namespace"
+	xsdNamespace = "http://www.w3.org/2001/XMLSchema"
+	xsiNamespace = "http://www.w3.org/2001/XMLSchema-instance"
+)
@@ -0,0 +1,124 @@
+// Copyright 2026 The xgen Authors. All rights reserved. Use of this source
+// code is governed by a BSD-style license that can be found in the LICENSE
+// file.
+//
+// Package xgen written in pure Go providing a set of functions that allow you
+// to parse XSD (XML schema files). This library needs Go version 1.10 or
+// later.
+
+package xgen
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Sink receives the files of the generated code.
+type Sink interface {
+	// WriteFile writes the file by given slash-separated path relative to
+	// the output. The code of the schema given as the input file itself has
+	// an empty path, the name consists of the extension only.
+	WriteFile(name string, data []byte) error
+}
+
+// DirSink writes the files of the generated code into the directory by the
+// path, the parent directories are created as needed. A file of an empty path
+// is written into the path itself with the extension, as the path is the
+// output file when a single schema file is generated.
+type DirSink string
+
+// WriteFile writes the file into the directory.
+func (d DirSink) WriteFile(name string, data []byte) error {
+	file := d.path(name)
+	if err := PrepareOutputDir(filepath.Dir(file)); err != nil {
+		return err
+	}
+	return os.WriteFile(file, data, 0o644)
+}
+
+// Diff returns the unified diff of the files in the directory to the files of
+// the generated code, in the order of their names. A file which doesn't exist
+// in the directory is compared as an empty one, and the diff is empty if all
+// the files are up to date.
+func (d DirSink) Diff(files MapSink) (string, error) {
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var diff strings.Builder
+	for _, name := range names {
+		file := filepath.ToSlash(d.path(name))
+		data, err := os.ReadFile(d.path(name))
+		oldName := file
+		if errors.Is(err, fs.ErrNotExist) {
+			oldName = "/dev/null"
+		} else if err != nil {
+			return "", err
+		}
+		diff.WriteString(unifiedDiff(oldName, file, data, files[name]))
+	}
+	return diff.String(), nil
+}
+
+// path returns the path of the file by given name in the directory.
+func (d DirSink) path(name string) string {
+	file := filepath.Join(string(d), filepath.FromSlash(name))
+	if name == path.Ext(name) {
+		if file = string(d); !strings.HasSuffix(file, name) {
+			file += name
+		}
+	}
+	return file
+}
+
+// MapSink holds the files of the generated code in memory by their names.
+type MapSink map[string][]byte
+
+// WriteFile stores a copy of the file in the map.
+func (m MapSink) WriteFile(name string, data []byte) error {
+	m[name] = append([]byte(nil), data...)
+	return nil
+}
+
+// WriterSink writes the generated code of a single file into the writer, such
+// as the standard output. Writing more than one file is an error, as the files
+// can't be told apart in the stream.
+type WriterSink struct {
+	W io.Writer
+
+	written string // the name of the written file
+	wrote   bool
+}
+
+// WriteFile writes the file into the writer.
+func (w *WriterSink) WriteFile(name string, data []byte) error {
+	if w.wrote {
+		return fmt.Errorf("can not write %s after %s into a single stream", name, w.written)
+	}
+	w.written, w.wrote = name, true
+	_, err := w.W.Write(data)
+	return err
+}
+
+// syncSink writes the files into the sink one at a time, as the files are
+// generated by several goroutines.
+type syncSink struct {
+	mu   sync.Mutex
+	sink Sink
+}
+
+// WriteFile writes the file into the sink.
+func (s *syncSink) WriteFile(name string, data []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.sink.WriteFile(name, data)
+}
@@ -8,7 +8,10 @@
 
 package xgen
 
-import "container/list"
+import (
+	"container/list"
+	"fmt"
+)
 
 // Stack defined an abstract data type that serves as a collection of elements
 type Stack struct {
@@ -54,3 +57,23 @@ func (stack *Stack) Len() int {
 func (stack *Stack) Empty() bool {
 	return stack.list.Len() == 0
 }
+
+// peekAs returns the top item of the stack of the components by given name.
+// An error is returned if the stack is empty, as the element being parsed is
+// not enclosed by the component which its handler expects.
+func peekAs[T any](stack *Stack, name string) (value T, err error) {
+	value, ok := stack.Peek().(T)
+	if !ok {
+		err = fmt.Errorf("missing the enclosing %s", name)
+	}
+	return
+}
+
+// popAs pops the top item of the stack of the components by given name, an
+// error is returned if the stack is empty.
+func popAs[T any](stack *Stack, name string) (value T, err error) {
+	if value, err = peekAs[T](stack, name); err == nil {
+		stack.Pop()
+	}
+	return
+}
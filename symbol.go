@@ -0,0 +1,310 @@
+// Copyright 2026 The xgen Authors. All rights reserved. Use of this source
+// code is governed by a BSD-style license that can be found in the LICENSE
+// file.
+//
+// Package xgen written in pure Go providing a set of functions that allow you
+// to parse XSD (XML schema files). This library needs Go version 1.10 or
+// later.
+
+package xgen
+
+import (
+	"sort"
+	"sync"
+)
+
+// symbolScope indexes the components of a proto tree by their names. The tree
+// is indexed incrementally as the components are appended to it while being
+// parsed, and indexed again if it's replaced by another one.
+type symbolScope struct {
+	tree         []Component
+	bases        map[string]int // the simple type, attribute or element by its name
+	simpleTypes  map[string]int
+	complexTypes map[string]int
+}
+
+// update indexes the components of the tree which are not indexed yet. Only
+// the first component of each name is indexed.
+func (s *symbolScope) update(tree []Component) {
+	if len(tree) <= len(s.tree) && (len(tree) == 0 || &tree[0] == &s.tree[0]) {
+		return
+	}
+	if len(s.tree) == 0 || len(tree) == 0 || &tree[0] != &s.tree[0] {
+		s.reset()
+	}
+	if s.bases == nil {
+		s.bases, s.simpleTypes, s.complexTypes = map[string]int{}, map[string]int{}, map[string]int{}
+	}
+	for i := len(s.tree); i < len(tree); i++ {
+		if name, _, ok := declaredBase(tree[i]); ok {
+			if _, indexed := s.bases[name]; !indexed {
+				s.bases[name] = i
+			}
+		}
+		switch c := tree[i].(type) {
+		case *SimpleType:
+			if _, indexed := s.simpleTypes[c.Name]; !indexed {
+				s.simpleTypes[c.Name] = i
+			}
+		case *ComplexType:
+			if _, indexed := s.complexTypes[c.Name]; !indexed {
+				s.complexTypes[c.Name] = i
+			}
+		}
+	}
+	s.tree = tree
+}
+
+// reset drops the index, it must be called when the components of the tree
+// are replaced in place.
+func (s *symbolScope) reset() {
+	s.tree, s.bases, s.simpleTypes, s.complexTypes = nil, nil, nil, nil
+}
+
+// lookup returns the component of the tree by given name in the index, which
+// is not found if it's beyond the tree, as the tree may be a prefix of the
+// one indexed.
+func (s *symbolScope) lookup(index map[string]int, name string, tree []Component) Component {
+	if i, ok := index[name]; ok && i < len(tree) {
+		return tree[i]
+	}
+	return nil
+}
+
+// base returns the base type of the simple type, or the type of the attribute
+// or element declared in the tree by given name. The name itself is returned
+// if there is no such declaration.
+func (s *symbolScope) base(name string, tree []Component) string {
+	s.update(tree)
+	if c := s.lookup(s.bases, name, tree); c != nil {
+		_, base, _ := declaredBase(c)
+		return base
+	}
+	return name
+}
+
+// simpleType returns the simple type declared in the tree by given name, or
+// nil if there is no such simple type.
+func (s *symbolScope) simpleType(name string, tree []Component) *SimpleType {
+	s.update(tree)
+	if c := s.lookup(s.simpleTypes, name, tree); c != nil {
+		return c.(*SimpleType)
+	}
+	return nil
+}
+
+// complexType returns the complex type declared in the tree by given name, or
+// nil if there is no such complex type.
+func (s *symbolScope) complexType(name string, tree []Component) *ComplexType {
+	s.update(tree)
+	if c := s.lookup(s.complexTypes, name, tree); c != nil {
+		return c.(*ComplexType)
+	}
+	return nil
+}
+
+// declaredBase returns the name and the base type of the component if it
+// declares a base type for its name, which are the simple types except lists
+// and unions, the attributes and the elements.
+func declaredBase(c Component) (name, base string, ok bool) {
+	switch v := c.(type) {
+	case *SimpleType:
+		if !v.List && !v.Union {
+			return v.Name, v.Base, true
+		}
+	case *Attribute:
+		return v.Name, v.Type, true
+	case *Element:
+		return v.Name, v.Type, true
+	}
+	return
+}
+
+// symbolTable holds the declarations of the schemas referenced when resolving
+// the type references of a schema file. The proto trees of the schemas are
+// indexed once, and each referenced schema is parsed once for the schema set
+// no matter how many references are resolved against it. The files read when
+// resolving the schema file are recorded along the way.
+type symbolTable struct {
+	schemas *schemaCache
+	scopes  map[string]*symbolScope
+	files   map[string]bool
+}
+
+// schemaCache holds the declarations extracted from the schemas referenced by
+// the schema files of a schema set, which is safe for concurrent use.
+type schemaCache struct {
+	mu      sync.Mutex
+	schemas map[string]*referencedSchema
+}
+
+// referencedSchema is the declarations extracted from a schema, along with the
+// files of the schemas it includes and the diagnostics of the schema.
+type referencedSchema struct {
+	once        sync.Once
+	symbols     symbolScope
+	tree        []Component
+	includes    []string
+	files       map[string]bool
+	diagnostics Diagnostics
+	err         error
+}
+
+// newSchemaCache creates an empty schema cache.
+func newSchemaCache() *schemaCache {
+	return &schemaCache{schemas: map[string]*referencedSchema{}}
+}
+
+// schema returns the entry of the schema file in the cache.
+func (c *schemaCache) schema(file string) *referencedSchema {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	schema, ok := c.schemas[file]
+	if !ok {
+		schema = &referencedSchema{}
+		c.schemas[file] = schema
+	}
+	return schema
+}
+
+// invalidate drops the schemas which have read any of the changed files from
+// the cache, which are parsed again when referenced.
+func (c *schemaCache) invalidate(changed map[string]bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for file, schema := range c.schemas {
+		if changed[file] {
+			delete(c.schemas, file)
+			continue
+		}
+		for read := range schema.files {
+			if changed[read] {
+				delete(c.schemas, file)
+				break
+			}
+		}
+	}
+}
+
+// newSymbolTable creates an empty symbol table sharing the schema cache, a new
+// cache is created if it's nil.
+func newSymbolTable(schemas *schemaCache) *symbolTable {
+	if schemas == nil {
+		schemas = newSchemaCache()
+	}
+	return &symbolTable{schemas: schemas, scopes: map[string]*symbolScope{}, files: map[string]bool{}}
+}
+
+// scope returns the index of the proto tree of the schema file.
+func (t *symbolTable) scope(file string) *symbolScope {
+	s, ok := t.scopes[file]
+	if !ok {
+		s = &symbolScope{}
+		t.scopes[file] = s
+	}
+	return s
+}
+
+// referencedSchema returns the declarations extracted from the schema file,
+// which is parsed on the first call, and the others wait for it. The schema is
+// parsed with its own namespace prefixes, imports and includes, so that the
+// declarations don't depend on the schema referencing it. The diagnostics of
+// the schema are reported to each schema referencing it, which depends on the
+// files read when parsing the schema.
+func (opt *Options) referencedSchema(file string) *referencedSchema {
+	schema := opt.symbols.schemas.schema(file)
+	schema.once.Do(func() { schema.extract(opt, file) })
+	*opt.diagnostics = opt.diagnostics.add(schema.diagnostics...)
+	for file := range schema.files {
+		opt.symbols.files[file] = true
+	}
+	return schema
+}
+
+// extract parses the schema file on behalf of the schema being parsed by the
+// options, and indexes the declarations of the schema.
+func (schema *referencedSchema) extract(opt *Options, file string) {
+	symbols := newSymbolTable(opt.symbols.schemas)
+	schema.files = symbols.files
+	parser := NewParser(&Options{
+		FilePath:            file,
+		OutputDir:           opt.OutputDir,
+		Extract:             true,
+		Lang:                opt.Lang,
+		IncludeMap:          make(map[string]bool),
+		LocalNameNSMap:      make(map[string]string),
+		NSSchemaLocationMap: make(map[string]string),
+		ParseFileList:       make(map[string]bool),
+		ParseFileMap:        make(map[string][]Component),
+		ProtoTree:           make([]Component, 0),
+		RemoteSchema:        opt.RemoteSchema,
+		Fetcher:             opt.Fetcher,
+		Catalog:             opt.Catalog,
+		FS:                  opt.FS,
+		Strict:              opt.Strict,
+		TypeMappings:        opt.TypeMappings,
+		diagnostics:         &schema.diagnostics,
+		symbols:             symbols,
+		referenced:          true,
+		ctx:                 opt.ctx,
+	})
+	if schema.err = parser.parse(); schema.err != nil {
+		return
+	}
+	schema.tree = parser.ProtoTree
+	schema.symbols.update(schema.tree)
+	for _, include := range sortedKeys(parser.IncludeMap) {
+		includeFile, err := parser.schemaFile(include, "")
+		if err != nil {
+			schema.err = err
+			return
+		}
+		if includeFile != "" {
+			schema.includes = append(schema.includes, includeFile)
+		}
+	}
+}
+
+// includedBase returns the base type declared by given name in the schemas
+// included by the schema being parsed, directly or through other included
+// schemas, or the name itself if there is no such declaration.
+func (opt *Options) includedBase(name string) (string, error) {
+	var files []string
+	for _, include := range sortedKeys(opt.IncludeMap) {
+		file, err := opt.schemaFile(include, "")
+		if err != nil {
+			return "", err
+		}
+		if file != "" {
+			files = append(files, file)
+		}
+	}
+	visited := map[string]bool{}
+	for len(files) > 0 {
+		file := files[0]
+		files = files[1:]
+		if visited[file] {
+			continue
+		}
+		visited[file] = true
+		schema := opt.referencedSchema(file)
+		if schema.err != nil {
+			continue
+		}
+		if base := schema.symbols.base(name, schema.tree); base != name && base != "" {
+			return base, nil
+		}
+		files = append(files, schema.includes...)
+	}
+	return name, nil
+}
+
+// sortedKeys returns the keys of the set in ascending order.
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
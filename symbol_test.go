@@ -0,0 +1,108 @@
+// Copyright 2026 The xgen Authors. All rights reserved. Use of this source
+// code is governed by a BSD-style license that can be found in the LICENSE
+// file.
+//
+// Package xgen written in pure Go providing a set of functions that allow you
+// to parse XSD (XML schema files). This library needs Go version 1.10 or
+// later.
+
+package xgen
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// writeLargeSchema writes a bundle of given number of document schemas, each
+// of which declares given number of types, into the directory in the style
+// of the standards bundles: each document schema
+// includes the shared types of its namespace, which include the code lists,
+// and imports the common schema of another namespace. The types of all of
+// them are referred to by the document schemas.
+func writeLargeSchema(tb testing.TB, dir string, schemas, types int) {
+	files := map[string]*strings.Builder{}
+	schema := func(name, namespace string) *strings.Builder {
+		b := &strings.Builder{}
+		fmt.Fprintf(b, `<xs:schema xmlns:xs="http://www.w3.org/2001/XMLSchema" xmlns="%s" xmlns:c="urn:common" targetNamespace="%s">`+"\n", namespace, namespace)
+		files[name] = b
+		return b
+	}
+	common := schema("common.xsd", "urn:common")
+	codes := schema("codes.xsd", "urn:main")
+	shared := schema("types.xsd", "urn:main")
+	shared.WriteString(`  <xs:include schemaLocation="codes.xsd"/>` + "\n")
+	for i := 0; i < types; i++ {
+		fmt.Fprintf(common, `  <xs:simpleType name="Code%d"><xs:restriction base="xs:string"/></xs:simpleType>`+"\n", i)
+		fmt.Fprintf(common, `  <xs:complexType name="Party%d"><xs:sequence><xs:element name="code" type="c:Code%d"/></xs:sequence></xs:complexType>`+"\n", i, i)
+		fmt.Fprintf(codes, `  <xs:simpleType name="Currency%d"><xs:restriction base="xs:token"/></xs:simpleType>`+"\n", i)
+		fmt.Fprintf(shared, `  <xs:simpleType name="Amount%d"><xs:restriction base="xs:decimal"/></xs:simpleType>`+"\n", i)
+		fmt.Fprintf(shared, `  <xs:complexType name="Money%d"><xs:simpleContent><xs:extension base="Amount%d"><xs:attribute name="currency" type="Currency%d"/></xs:extension></xs:simpleContent></xs:complexType>`+"\n", i, i, i)
+	}
+	for s := 0; s < schemas; s++ {
+		b := schema(fmt.Sprintf("document%d.xsd", s), "urn:main")
+		b.WriteString(`  <xs:import namespace="urn:common" schemaLocation="common.xsd"/>` + "\n")
+		b.WriteString(`  <xs:include schemaLocation="types.xsd"/>` + "\n")
+		fmt.Fprintf(b, `  <xs:element name="Document%d" type="Line%d_0"/>`+"\n", s, s)
+		for i := 0; i < types; i++ {
+			fmt.Fprintf(b, `  <xs:simpleType name="Quantity%d_%d"><xs:restriction base="xs:int"/></xs:simpleType>`+"\n", s, i)
+			fmt.Fprintf(b, `  <xs:complexType name="Line%d_%d"><xs:sequence>`+"\n", s, i)
+			fmt.Fprintf(b, `    <xs:element name="quantity" type="Quantity%d_%d"/>`+"\n", s, i)
+			fmt.Fprintf(b, `    <xs:element name="price" type="Amount%d"/>`+"\n", i)
+			fmt.Fprintf(b, `    <xs:element name="total" type="Money%d"/>`+"\n", i)
+			fmt.Fprintf(b, `    <xs:element name="currency" type="Currency%d"/>`+"\n", i)
+			fmt.Fprintf(b, `    <xs:element name="code" type="c:Code%d"/>`+"\n", i)
+			fmt.Fprintf(b, `    <xs:element name="party" type="c:Party%d"/>`+"\n", i)
+			if i+1 < types {
+				fmt.Fprintf(b, `    <xs:element name="next" type="Line%d_%d" minOccurs="0"/>`+"\n", s, i+1)
+			}
+			b.WriteString("  </xs:sequence></xs:complexType>\n")
+		}
+	}
+	for name, b := range files {
+		b.WriteString("</xs:schema>\n")
+		require.NoError(tb, os.WriteFile(filepath.Join(dir, name), []byte(b.String()), 0o644))
+	}
+}
+
+// benchmarkBundles is the sizes of the bundles the benchmarks run on, which
+// grow in the number of the schemas and in the number of the types in a
+// schema. The time per type should stay flat as they grow.
+var benchmarkBundles = []struct{ schemas, types int }{
+	{50, 20}, {100, 20}, {200, 20}, {400, 20},
+	{1, 500}, {1, 1000}, {1, 2000}, {1, 4000},
+}
+
+func BenchmarkLoad(b *testing.B) {
+	for _, bundle := range benchmarkBundles {
+		dir := b.TempDir()
+		writeLargeSchema(b, dir, bundle.schemas, bundle.types)
+		b.Run(fmt.Sprintf("schemas=%d/types=%d", bundle.schemas, bundle.types), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				_, err := Load(context.Background(), &Options{InputDir: dir, Lang: "Go"})
+				require.NoError(b, err)
+			}
+			b.ReportMetric(float64(b.Elapsed().Nanoseconds())/float64(b.N*bundle.schemas*bundle.types), "ns/type")
+		})
+	}
+}
+
+func BenchmarkGenerate(b *testing.B) {
+	for _, bundle := range benchmarkBundles {
+		dir := b.TempDir()
+		writeLargeSchema(b, dir, bundle.schemas, bundle.types)
+		set, err := Load(context.Background(), &Options{InputDir: dir, Lang: "Go"})
+		require.NoError(b, err)
+		b.Run(fmt.Sprintf("schemas=%d/types=%d", bundle.schemas, bundle.types), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				require.NoError(b, Generate(context.Background(), set, MapSink{}))
+			}
+			b.ReportMetric(float64(b.Elapsed().Nanoseconds())/float64(b.N*bundle.schemas*bundle.types), "ns/type")
+		})
+	}
+}
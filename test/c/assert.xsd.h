@@ -0,0 +1,31 @@
+// Code generated by xgen. DO NOT EDIT.
+
+// Percentage ...
+typedef float Percentage;
+
+// Window ...
+typedef struct {
+	int FromAttr; // attr, optional
+	int ToAttr; // attr, optional
+} Window;
+
+// Discount ...
+typedef struct {
+	bool CappedAttr; // attr, optional
+} Discount;
+
+// Shipment ...
+typedef struct {
+	bool ExpressAttr; // attr, optional
+	float TaxAttr; // attr, optional
+	char Item[];
+	char Carrier;
+	char Tracking;
+	Window Window;
+	Discount Discount;
+} Shipment;
+
+// ExpressShipment ...
+typedef struct {
+	int Deadline;
+} ExpressShipment;
@@ -0,0 +1,20 @@
+// Code generated by xgen. DO NOT EDIT.
+
+// Revision ...
+typedef int Revision;
+
+// Order ...
+typedef struct {
+	char IdAttr; // attr
+	int OrdRevisionAttr; // attr, optional
+	char XmlLangAttr; // attr, optional
+	char Customer;
+	char Note;
+	OrderEntry Entry[];
+} Order;
+
+// OrderEntry ...
+typedef struct {
+	int QuantityAttr; // attr, optional
+	char Sku;
+} OrderEntry;
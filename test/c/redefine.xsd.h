@@ -0,0 +1,30 @@
+// Code generated by xgen. DO NOT EDIT.
+
+// PostCode ...
+typedef char PostCode;
+
+// Address ...
+typedef struct {
+	char KindAttr; // attr, optional
+	char Street;
+	char City;
+	char Country;
+} Address;
+
+// Names ...
+typedef struct {
+	char Given;
+	char Family;
+} Names;
+
+// Audit ...
+typedef struct {
+	char CreatedAttr; // attr, optional
+	char ModifiedAttr; // attr, optional
+} Audit;
+
+// Resident ...
+typedef struct {
+	Address Address;
+	char PostCode;
+} Resident;
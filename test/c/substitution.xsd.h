@@ -0,0 +1,31 @@
+// Code generated by xgen. DO NOT EDIT.
+
+// ShapeType ...
+typedef struct {
+	char IdAttr; // attr, optional
+} ShapeType;
+
+// CircleType ...
+typedef struct {
+	float Radius;
+} CircleType;
+
+// SquareType ...
+typedef struct {
+	float Side;
+} SquareType;
+
+typedef ShapeType Shape;
+
+typedef CircleType Circle;
+
+typedef SquareType Square;
+
+typedef SquareType Tile;
+
+// Drawing ...
+typedef struct {
+	char Title;
+	ShapeType HereShape[];
+	char Caption;
+} Drawing;
@@ -0,0 +1,81 @@
+// Code generated by xgen. DO NOT EDIT.
+
+package schema
+
+import (
+	"encoding/xml"
+	"fmt"
+)
+
+// Preferences ...
+type Preferences struct {
+	Language    *string `xml:"language,omitempty"`
+	Newsletter  *bool   `xml:"newsletter,omitempty"`
+	occurrences map[string]int
+}
+
+func (m *Preferences) Validate() error {
+	if m == nil {
+		return nil
+	}
+	if m.occurrences["language"] > 1 {
+		return fmt.Errorf("language must occur at most once")
+	}
+	if m.occurrences["newsletter"] > 1 {
+		return fmt.Errorf("newsletter must occur at most once")
+	}
+	return nil
+}
+
+// UnmarshalXML decodes the element into the fields of Preferences.
+func (m *Preferences) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	type plain Preferences
+	m.occurrences = map[string]int{}
+	r := &childReader{d: d, start: &start, decode: func(t xml.StartElement) (bool, error) {
+		m.occurrences[t.Name.Local]++
+		return false, nil
+	}}
+	return xml.NewTokenDecoder(r).DecodeElement((*plain)(m), nil)
+}
+
+// Contact ...
+type Contact struct {
+	XMLName     xml.Name     `xml:"http://example.org/ Contact"`
+	Id          int          `xml:"id,attr"`
+	Name        string       `xml:"name"`
+	Email       string       `xml:"email"`
+	Phone       *string      `xml:"phone,omitempty"`
+	Label       []string     `xml:"label,omitempty"`
+	Preferences *Preferences `xml:"preferences,omitempty"`
+	occurrences map[string]int
+}
+
+func (m *Contact) Validate() error {
+	if m == nil {
+		return nil
+	}
+	if m.occurrences["name"] > 1 {
+		return fmt.Errorf("name must occur at most once")
+	}
+	if m.occurrences["email"] > 1 {
+		return fmt.Errorf("email must occur at most once")
+	}
+	if m.occurrences["phone"] > 1 {
+		return fmt.Errorf("phone must occur at most once")
+	}
+	if m.occurrences["preferences"] > 1 {
+		return fmt.Errorf("preferences must occur at most once")
+	}
+	return nil
+}
+
+// UnmarshalXML decodes the element into the fields of Contact.
+func (m *Contact) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	type plain Contact
+	m.occurrences = map[string]int{}
+	r := &childReader{d: d, start: &start, decode: func(t xml.StartElement) (bool, error) {
+		m.occurrences[t.Name.Local]++
+		return false, nil
+	}}
+	return xml.NewTokenDecoder(r).DecodeElement((*plain)(m), nil)
+}
@@ -0,0 +1,123 @@
+// Code generated by xgen. DO NOT EDIT.
+
+package schema
+
+import (
+	"fmt"
+)
+
+// Percentage ...
+type Percentage float64
+
+func (v Percentage) Validate() error {
+	if !(float64(v) >= 0 && float64(v) <= 100) {
+		return fmt.Errorf("Percentage must satisfy assertion %q", "$value ge 0 and $value le 100")
+	}
+	return nil
+}
+
+// Window ...
+type Window struct {
+	From *int `xml:"from,attr"`
+	To   *int `xml:"to,attr"`
+}
+
+func (m *Window) Validate() error {
+	if m == nil {
+		return nil
+	}
+	if !(m.From == nil || m.To == nil || m.From != nil && m.To != nil && float64(*m.From) <= float64(*m.To)) {
+		return fmt.Errorf("Window must satisfy assertion %q", "empty(@from) or empty(@to) or @from <= @to")
+	}
+	return nil
+}
+
+// Discount ...
+type Discount struct {
+	Capped *bool   `xml:"capped,attr"`
+	Value  float64 `xml:",chardata"`
+}
+
+func (m *Discount) Validate() error {
+	if m == nil {
+		return nil
+	}
+	if !(!(m.Capped != nil && *m.Capped) || float64(m.Value) <= 50) {
+		return fmt.Errorf("Discount must satisfy assertion %q", "not(@capped = true()) or . le 50")
+	}
+	return nil
+}
+
+// Shipment ...
+type Shipment struct {
+	Express  *bool       `xml:"express,attr"`
+	Tax      *Percentage `xml:"tax,attr"`
+	Item     []string    `xml:"item"`
+	Carrier  *string     `xml:"carrier,omitempty"`
+	Tracking *string     `xml:"tracking,omitempty"`
+	Window   *Window     `xml:"window,omitempty"`
+	Discount *Discount   `xml:"discount,omitempty"`
+}
+
+func (m *Shipment) Validate() error {
+	if m == nil {
+		return nil
+	}
+	if !(float64(len(m.Item)) > 0) {
+		return fmt.Errorf("Shipment must satisfy assertion %q", "count(item) gt 0")
+	}
+	if !(!(m.Express != nil && *m.Express) || m.Carrier != nil) {
+		return fmt.Errorf("Shipment must satisfy assertion %q", "not(@express = true()) or exists(carrier)")
+	}
+	if !(m.Tracking == nil || m.Carrier != nil && string(*m.Carrier) != "none") {
+		return fmt.Errorf("Shipment must satisfy assertion %q", "empty(tracking) or (exists(carrier) and carrier != 'none')")
+	}
+	if !(m.Window == nil || m.Window != nil && m.Window.From != nil && float64(*m.Window.From) >= 0) {
+		return fmt.Errorf("Shipment must satisfy assertion %q", "not(window) or window/@from ge 0")
+	}
+	if !(m.Discount == nil || m.Discount != nil && float64(m.Discount.Value) <= 20 || float64(len(m.Item)) >= 3) {
+		return fmt.Errorf("Shipment must satisfy assertion %q", "empty(discount) or discount <= 20 or count(item) >= 3")
+	}
+	return nil
+}
+
+// ExpressShipment ...
+type ExpressShipment struct {
+	Deadline int `xml:"deadline"`
+	*Shipment
+}
+
+func (m *ExpressShipment) Validate() error {
+	if m == nil {
+		return nil
+	}
+	if !(float64(func() (n int) {
+		if it1 := m.Shipment; it1 != nil {
+			n += len(it1.Item)
+		}
+		return
+	}()) > 0) {
+		return fmt.Errorf("ExpressShipment must satisfy assertion %q", "count(item) gt 0")
+	}
+	if !(!(m.Shipment != nil && m.Shipment.Express != nil && *m.Shipment.Express) || m.Shipment != nil && m.Shipment.Carrier != nil) {
+		return fmt.Errorf("ExpressShipment must satisfy assertion %q", "not(@express = true()) or exists(carrier)")
+	}
+	if !(!(m.Shipment != nil && m.Shipment.Tracking != nil) || m.Shipment != nil && m.Shipment.Carrier != nil && string(*m.Shipment.Carrier) != "none") {
+		return fmt.Errorf("ExpressShipment must satisfy assertion %q", "empty(tracking) or (exists(carrier) and carrier != 'none')")
+	}
+	if !(!(m.Shipment != nil && m.Shipment.Window != nil) || m.Shipment != nil && m.Shipment.Window != nil && m.Shipment.Window.From != nil && float64(*m.Shipment.Window.From) >= 0) {
+		return fmt.Errorf("ExpressShipment must satisfy assertion %q", "not(window) or window/@from ge 0")
+	}
+	if !(!(m.Shipment != nil && m.Shipment.Discount != nil) || m.Shipment != nil && m.Shipment.Discount != nil && float64(m.Shipment.Discount.Value) <= 20 || float64(func() (n int) {
+		if it2 := m.Shipment; it2 != nil {
+			n += len(it2.Item)
+		}
+		return
+	}()) >= 3) {
+		return fmt.Errorf("ExpressShipment must satisfy assertion %q", "empty(discount) or discount <= 20 or count(item) >= 3")
+	}
+	if !(float64(m.Deadline) < 72) {
+		return fmt.Errorf("ExpressShipment must satisfy assertion %q", "deadline < 72")
+	}
+	return nil
+}
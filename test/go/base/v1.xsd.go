@@ -0,0 +1,40 @@
+// Code generated by xgen. DO NOT EDIT.
+
+package schema
+
+import (
+	"fmt"
+)
+
+// PostCode ...
+type PostCode string
+
+func (v PostCode) Validate() error {
+	if len(string(v)) > 10 {
+		return fmt.Errorf("PostCode length must be <= 10")
+	}
+	return nil
+}
+
+// Address ...
+type Address struct {
+	Kind   *string `xml:"kind,attr"`
+	Street string  `xml:"street"`
+	City   string  `xml:"city"`
+}
+
+// Names ...
+type Names struct {
+	Given string
+}
+
+// Audit ...
+type Audit struct {
+	Created string `xml:"created,attr,omitempty"`
+}
+
+// Resident ...
+type Resident struct {
+	Address  *Address `xml:"address"`
+	PostCode PostCode `xml:"postCode" validate:"max=10"`
+}
@@ -0,0 +1,28 @@
+// Code generated by xgen. DO NOT EDIT.
+
+package schema
+
+import (
+	"fmt"
+)
+
+// Currency ...
+type Currency string
+
+func (v Currency) Validate() error {
+	if len(string(v)) != 3 {
+		return fmt.Errorf("Currency length must be exactly 3")
+	}
+	return nil
+}
+
+// LineItem ...
+type LineItem struct {
+	Sku string `xml:"sku"`
+}
+
+// Invoice ...
+type Invoice struct {
+	Item     []*LineItem `xml:"item"`
+	Currency Currency    `xml:"currency" validate:"len=3"`
+}
@@ -4,23 +4,34 @@ package schema
 
 import (
 	"encoding/xml"
+	"fmt"
 )
 
 // MyType1 ...
 type MyType1 string
 
+func (v MyType1) Validate() error {
+	if len(string(v)) != 10 {
+		return fmt.Errorf("MyType1 length must be exactly 10")
+	}
+	return nil
+}
+
+// MyType5 ...
+type MyType5 string
+
 // MyType2 ...
 type MyType2 struct {
-	XMLName    xml.Name `xml:"myType2"`
-	LengthAttr *int     `xml:"length,attr"`
-	Value      string   `xml:",chardata"`
+	XMLName xml.Name `xml:"myType2"`
+	Length  *int     `xml:"length,attr"`
+	Value   string   `xml:",chardata"`
 }
 
 // MyType3 ...
 type MyType3 struct {
-	XMLName    xml.Name `xml:"myType3"`
-	LengthAttr *int     `xml:"length,attr"`
-	Value      string   `xml:",chardata"`
+	XMLName xml.Name `xml:"myType3"`
+	Length  *int     `xml:"length,attr"`
+	Value   string   `xml:",chardata"`
 }
 
 // MyType4 ...
@@ -29,22 +40,37 @@ type MyType4 struct {
 	Title     string   `xml:"title"`
 	Blob      string   `xml:"blob"`
 	Timestamp string   `xml:"timestamp"`
-	Metadata  *string  `xml:"metadata"`
+	Metadata  *string  `xml:"metadata,omitempty"`
 }
 
-// MyType5 ...
-type MyType5 string
-
 // MyType6 ...
 type MyType6 struct {
-	CodeAttr       *string `xml:"code,attr"`
-	IdentifierAttr *int    `xml:"identifier,attr"`
+	Code       *string `xml:"code,attr" validate:"omitempty,oneof=value1 value2"`
+	Identifier *int    `xml:"identifier,attr"`
+}
+
+func (m *MyType6) Validate() error {
+	if m == nil {
+		return nil
+	}
+	if m.Code != nil {
+		{
+			allowed := map[string]struct{}{
+				"value1": {},
+				"value2": {},
+			}
+			if _, ok := allowed[string(*m.Code)]; !ok {
+				return fmt.Errorf("Code must be one of enum values")
+			}
+		}
+	}
+	return nil
 }
 
 // MyType7 ...
 type MyType7 struct {
-	OriginAttr string `xml:"origin,attr"`
-	Value      string `xml:",chardata"`
+	Origin string `xml:"origin,attr"`
+	Value  string `xml:",chardata"`
 }
 
 // MyType8 ...
@@ -64,17 +90,18 @@ type MyType10 struct {
 
 // MyType11 ...
 type MyType11 struct {
-	Option1 *int      `xml:"option1"`
-	Option2 *string   `xml:"option2"`
-	Option3 *MyType10 `xml:"option3"`
+	Option1 *int      `xml:"option1,omitempty"`
+	Option2 *string   `xml:"option2,omitempty"`
+	Option3 *MyType10 `xml:"option3,omitempty"`
 }
 
 // TopLevel ...
 type TopLevel struct {
-	CostAttr        *float64   `xml:"cost,attr"`
-	LastUpdatedAttr string     `xml:"LastUpdated,attr"`
-	Nested          *MyType7   `xml:"nested"`
-	MyType1         []string   `xml:"myType1"`
-	MyType2         []*MyType2 `xml:"myType2"`
+	XMLName     xml.Name   `xml:"http://example.org/ TopLevel"`
+	Cost        *float64   `xml:"cost,attr"`
+	LastUpdated string     `xml:"LastUpdated,attr"`
+	Nested      *MyType7   `xml:"nested,omitempty"`
+	MyType1     []MyType1  `xml:"myType1,omitempty" validate:"dive,omitempty,len=10"`
+	MyType2     []*MyType2 `xml:"myType2,omitempty"`
 	*MyType6
 }
@@ -0,0 +1,67 @@
+// Code generated by xgen. DO NOT EDIT.
+
+package schema
+
+import (
+	"encoding/xml"
+	"fmt"
+)
+
+// Price ...
+type Price struct {
+	Currency string  `xml:"currency,attr"`
+	Vat      *bool   `xml:"vat,attr"`
+	Value    float64 `xml:",chardata"`
+}
+
+// RetailPrice ...
+type RetailPrice struct {
+	Currency string  `xml:"currency,attr"`
+	Value    float64 `xml:",chardata"`
+}
+
+func (m *RetailPrice) Validate() error {
+	if m == nil {
+		return nil
+	}
+	vv := float64(m.Value)
+	if vv < 0 {
+		return fmt.Errorf("Value must be >= 0")
+	}
+	return nil
+}
+
+// Vehicle ...
+type Vehicle struct {
+	Vin    string  `xml:"vin,attr"`
+	Make   string  `xml:"make"`
+	Model  *string `xml:"model,omitempty"`
+	Wheels *int    `xml:"wheels,omitempty"`
+}
+
+// Bicycle ...
+type Bicycle struct {
+	Vin    string `xml:"vin,attr"`
+	Make   string `xml:"make"`
+	Wheels *int   `xml:"wheels,omitempty"`
+}
+
+// Truck ...
+type Truck struct {
+	Payload float64 `xml:"payload"`
+	*Vehicle
+}
+
+// Remark ...
+type Remark struct {
+	Note string `xml:"note"`
+}
+
+// Listing ...
+type Listing struct {
+	XMLName xml.Name     `xml:"http://example.org/ Listing"`
+	Price   *RetailPrice `xml:"price"`
+	Bicycle *Bicycle     `xml:"bicycle"`
+	Truck   *Truck       `xml:"truck"`
+	Remark  *Remark      `xml:"remark,omitempty"`
+}
@@ -0,0 +1,96 @@
+// Code generated by xgen. DO NOT EDIT.
+
+package schema
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+)
+
+// Product ...
+type Product struct {
+	Sku   string  `xml:"sku,attr"`
+	Title *string `xml:"title,attr"`
+}
+
+// OrderLine ...
+type OrderLine struct {
+	Number   *int   `xml:"number,attr"`
+	Product  string `xml:"product"`
+	Quantity int    `xml:"quantity"`
+}
+
+// Products ...
+type Products struct {
+	XMLName xml.Name   `xml:"products"`
+	Product []*Product `xml:"product"`
+}
+
+// PurchaseOrder ...
+type PurchaseOrder struct {
+	XMLName  xml.Name     `xml:"http://example.org/ PurchaseOrder"`
+	Products *Products    `xml:"products"`
+	Line     []*OrderLine `xml:"line"`
+}
+
+// ValidateIdentity checks the identity constraints of the PurchaseOrder element.
+func (m *PurchaseOrder) ValidateIdentity() error {
+	if m == nil {
+		return nil
+	}
+	var errs []error
+	table0 := map[[1]string]string{}
+	if it1 := m.Products; it1 != nil {
+		p1 := "/PurchaseOrder/products"
+		for i, it2 := range it1.Product {
+			if it2 == nil {
+				continue
+			}
+			p2 := fmt.Sprintf("%s/product[%d]", p1, i+1)
+			var key [1]string
+			fields := 0
+			key[0] = fmt.Sprint(it2.Sku)
+			fields++
+			if fields < 1 {
+				errs = append(errs, fmt.Errorf("%s: key productKey value is missing", p2))
+			} else if first, ok := table0[key]; ok {
+				errs = append(errs, fmt.Errorf("%s: duplicate productKey value %q, first used at %s", p2, key[0], first))
+			} else {
+				table0[key] = p2
+			}
+		}
+	}
+	table1 := map[[1]string]string{}
+	for i, it1 := range m.Line {
+		if it1 == nil {
+			continue
+		}
+		p1 := fmt.Sprintf("/PurchaseOrder/line[%d]", i+1)
+		var key [1]string
+		fields := 0
+		if it2 := it1.Number; it2 != nil {
+			key[0] = fmt.Sprint(*it2)
+			fields++
+		}
+		if first, ok := table1[key]; fields == 1 && ok {
+			errs = append(errs, fmt.Errorf("%s: duplicate lineNumber value %q, first used at %s", p1, key[0], first))
+		} else if fields == 1 {
+			table1[key] = p1
+		}
+	}
+	for i, it1 := range m.Line {
+		if it1 == nil {
+			continue
+		}
+		p1 := fmt.Sprintf("/PurchaseOrder/line[%d]", i+1)
+		var key [1]string
+		fields := 0
+		key[0] = fmt.Sprint(it1.Product)
+		fields++
+		if _, ok := table0[key]; fields == 1 && !ok {
+			errs = append(errs, fmt.Errorf("%s: keyref lineProduct value %q does not refer to a productKey value", p1, key[0]))
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,24 @@
+// Code generated by xgen. DO NOT EDIT.
+
+package schema
+
+import (
+	"encoding/xml"
+)
+
+// Order ...
+type Order struct {
+	XMLName     xml.Name      `xml:"urn:example:orders Order"`
+	Id          string        `xml:"id,attr"`
+	OrdRevision *int          `xml:"urn:example:orders revision,attr"`
+	XmlLang     *string       `xml:"http://www.w3.org/XML/1998/namespace lang,attr"`
+	Customer    string        `xml:"urn:example:orders customer"`
+	Note        *string       `xml:"note,omitempty"`
+	Entry       []*OrderEntry `xml:"urn:example:orders entry"`
+}
+
+// OrderEntry ...
+type OrderEntry struct {
+	Quantity *int   `xml:"urn:example:orders quantity,attr"`
+	Sku      string `xml:"urn:example:orders sku"`
+}
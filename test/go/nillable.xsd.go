@@ -0,0 +1,37 @@
+// Code generated by xgen. DO NOT EDIT.
+
+package schema
+
+import (
+	"encoding/xml"
+	"fmt"
+)
+
+// Money ...
+type Money struct {
+	Currency string  `xml:"currency,attr"`
+	Value    float64 `xml:",chardata"`
+}
+
+// Payment ...
+type Payment struct {
+	XMLName   xml.Name           `xml:"http://example.org/ Payment"`
+	Amount    Nillable[Money]    `xml:"amount"`
+	Fee       *Nillable[float64] `xml:"fee,omitempty"`
+	Reference *Nillable[string]  `xml:"reference,omitempty"`
+	Tag       []Nillable[string] `xml:"tag"`
+}
+
+func (m *Payment) Validate() error {
+	if m == nil {
+		return nil
+	}
+	if it := m.Reference; it != nil {
+		if !it.Nil {
+			if len(string(it.Value)) > 16 {
+				return fmt.Errorf("Reference length must be <= 16")
+			}
+		}
+	}
+	return nil
+}
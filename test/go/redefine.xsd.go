@@ -0,0 +1,47 @@
+// Code generated by xgen. DO NOT EDIT.
+
+package schema
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// PostCode ...
+type PostCode string
+
+func (v PostCode) Validate() error {
+	if len(string(v)) > 10 {
+		return fmt.Errorf("PostCode length must be <= 10")
+	}
+	if ok := regexp.MustCompile("^[0-9]+$").MatchString(string(v)); !ok {
+		return fmt.Errorf("%s does not match pattern: %q", "PostCode", "[0-9]+")
+	}
+	return nil
+}
+
+// Address ...
+type Address struct {
+	Kind    *string `xml:"kind,attr"`
+	Street  string  `xml:"street"`
+	City    string  `xml:"city"`
+	Country string  `xml:"country"`
+}
+
+// Names ...
+type Names struct {
+	Given  string
+	Family string
+}
+
+// Audit ...
+type Audit struct {
+	Created  string `xml:"created,attr,omitempty"`
+	Modified string `xml:"modified,attr,omitempty"`
+}
+
+// Resident ...
+type Resident struct {
+	Address  *Address `xml:"address"`
+	PostCode PostCode `xml:"postCode" validate:"max=10"`
+}
@@ -0,0 +1,120 @@
+// Code generated by xgen. DO NOT EDIT.
+
+package schema
+
+import (
+	"encoding/xml"
+)
+
+// ShapeType ...
+type ShapeType struct {
+	Id *string `xml:"id,attr"`
+}
+
+// CircleType ...
+type CircleType struct {
+	Radius float64 `xml:"radius"`
+	*ShapeType
+}
+
+// SquareType ...
+type SquareType struct {
+	Side float64 `xml:"side"`
+	*ShapeType
+}
+
+// ShapeGroup is implemented by the elements of the Shape substitution group.
+type ShapeGroup interface {
+	isShapeGroup()
+}
+
+// CircleElement is the Circle element.
+type CircleElement struct {
+	CircleType
+}
+
+// MarshalXML writes the Circle element.
+func (v CircleElement) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return e.EncodeElement(v.CircleType, xml.StartElement{Name: xml.Name{Space: "http://example.org/", Local: "Circle"}})
+}
+
+// UnmarshalXML reads the Circle element.
+func (v *CircleElement) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	return d.DecodeElement(&v.CircleType, &start)
+}
+
+func (CircleElement) isShapeGroup() {}
+
+// SquareGroup is implemented by the elements of the Square substitution group.
+type SquareGroup interface {
+	isSquareGroup()
+}
+
+// SquareElement is the Square element.
+type SquareElement struct {
+	SquareType
+}
+
+// MarshalXML writes the Square element.
+func (v SquareElement) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return e.EncodeElement(v.SquareType, xml.StartElement{Name: xml.Name{Space: "http://example.org/", Local: "Square"}})
+}
+
+// UnmarshalXML reads the Square element.
+func (v *SquareElement) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	return d.DecodeElement(&v.SquareType, &start)
+}
+
+func (SquareElement) isSquareGroup() {}
+
+func (SquareElement) isShapeGroup() {}
+
+// TileElement is the Tile element.
+type TileElement struct {
+	SquareType
+}
+
+// MarshalXML writes the Tile element.
+func (v TileElement) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return e.EncodeElement(v.SquareType, xml.StartElement{Name: xml.Name{Space: "http://example.org/", Local: "Tile"}})
+}
+
+// UnmarshalXML reads the Tile element.
+func (v *TileElement) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	return d.DecodeElement(&v.SquareType, &start)
+}
+
+func (TileElement) isSquareGroup() {}
+
+func (TileElement) isShapeGroup() {}
+
+// Drawing ...
+type Drawing struct {
+	XMLName   xml.Name     `xml:"http://example.org/ Drawing"`
+	Title     string       `xml:"title"`
+	HereShape []ShapeGroup `xml:"http://example.org/ Shape"`
+	Caption   *string      `xml:"caption,omitempty"`
+}
+
+// UnmarshalXML decodes the element into the fields of Drawing.
+func (m *Drawing) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	type plain Drawing
+	r := &childReader{d: d, start: &start, decode: func(t xml.StartElement) (bool, error) {
+		switch t.Name {
+		case xml.Name{Space: "http://example.org/", Local: "Circle"}:
+			v := new(CircleElement)
+			m.HereShape = append(m.HereShape, v)
+			return true, d.DecodeElement(v, &t)
+		case xml.Name{Space: "http://example.org/", Local: "Square"}:
+			v := new(SquareElement)
+			m.HereShape = append(m.HereShape, v)
+			return true, d.DecodeElement(v, &t)
+		case xml.Name{Space: "http://example.org/", Local: "Tile"}:
+			v := new(TileElement)
+			m.HereShape = append(m.HereShape, v)
+			return true, d.DecodeElement(v, &t)
+		}
+		return false, nil
+	}}
+	return xml.NewTokenDecoder(r).DecodeElement((*plain)(m), nil)
+}
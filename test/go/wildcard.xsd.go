@@ -0,0 +1,22 @@
+// Code generated by xgen. DO NOT EDIT.
+
+package schema
+
+import (
+	"encoding/xml"
+)
+
+// Extensible ...
+type Extensible struct {
+	Version *string      `xml:"version,attr"`
+	AnyAttr []xml.Attr   `xml:",any,attr"`
+	Id      string       `xml:"id"`
+	Any     []AnyElement `xml:",any"`
+}
+
+// Envelope ...
+type Envelope struct {
+	XMLName xml.Name     `xml:"http://example.org/ Envelope"`
+	Header  *Extensible  `xml:"header"`
+	Any     []AnyElement `xml:",any"`
+}
@@ -0,0 +1,37 @@
+// Code generated by xgen. DO NOT EDIT.
+
+package schema;
+
+import java.util.ArrayList;
+import java.util.List;
+import javax.xml.bind.annotation.XmlAccessType;
+import javax.xml.bind.annotation.XmlAccessorType;
+import javax.xml.bind.annotation.XmlAttribute;
+import javax.xml.bind.annotation.XmlElement;
+import javax.xml.bind.annotation.XmlSchemaType;
+import javax.xml.bind.annotation.XmlType;
+import javax.xml.bind.annotation.XmlValue;
+
+// Preferences ...
+public class Preferences {
+	@XmlElement(name = "language")
+	protected String Language;
+	@XmlElement(name = "newsletter")
+	protected Boolean Newsletter;
+}
+
+// Contact ...
+public class Contact {
+	@XmlAttribute(required = true, name = "id")
+	protected Integer IdAttr;
+	@XmlElement(required = true, name = "name")
+	protected String Name;
+	@XmlElement(required = true, name = "email")
+	protected String Email;
+	@XmlElement(name = "phone")
+	protected String Phone;
+	@XmlElement(name = "label")
+	protected List<String> Label;
+	@XmlElement(name = "preferences")
+	protected Preferences Preferences;
+}
@@ -0,0 +1,60 @@
+// Code generated by xgen. DO NOT EDIT.
+
+package schema;
+
+import java.util.ArrayList;
+import java.util.List;
+import javax.xml.bind.annotation.XmlAccessType;
+import javax.xml.bind.annotation.XmlAccessorType;
+import javax.xml.bind.annotation.XmlAttribute;
+import javax.xml.bind.annotation.XmlElement;
+import javax.xml.bind.annotation.XmlSchemaType;
+import javax.xml.bind.annotation.XmlType;
+import javax.xml.bind.annotation.XmlValue;
+
+// Percentage ...
+@XmlAccessorType(XmlAccessType.FIELD)
+@XmlAttribute(required = true, name = "Percentage")
+public class Percentage {
+	protected Float Percentage;
+}
+
+// Window ...
+public class Window {
+	@XmlAttribute(name = "from")
+	protected Integer FromAttr;
+	@XmlAttribute(name = "to")
+	protected Integer ToAttr;
+}
+
+// Discount ...
+public class Discount {
+	@XmlAttribute(name = "capped")
+	protected Boolean CappedAttr;
+	@XmlValue
+	protected Float value;
+}
+
+// Shipment ...
+public class Shipment {
+	@XmlAttribute(name = "express")
+	protected Boolean ExpressAttr;
+	@XmlAttribute(name = "tax")
+	protected Float TaxAttr;
+	@XmlElement(required = true, name = "item")
+	protected List<String> Item;
+	@XmlElement(name = "carrier")
+	protected String Carrier;
+	@XmlElement(name = "tracking")
+	protected String Tracking;
+	@XmlElement(name = "window")
+	protected Window Window;
+	@XmlElement(name = "discount")
+	protected Discount Discount;
+}
+
+// ExpressShipment ...
+public class ExpressShipment extends Shipment  {
+	@XmlElement(required = true, name = "deadline")
+	protected Integer Deadline;
+}
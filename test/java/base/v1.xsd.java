@@ -0,0 +1,50 @@
+// Code generated by xgen. DO NOT EDIT.
+
+package schema;
+
+import java.util.ArrayList;
+import java.util.List;
+import javax.xml.bind.annotation.XmlAccessType;
+import javax.xml.bind.annotation.XmlAccessorType;
+import javax.xml.bind.annotation.XmlAttribute;
+import javax.xml.bind.annotation.XmlElement;
+import javax.xml.bind.annotation.XmlSchemaType;
+import javax.xml.bind.annotation.XmlType;
+import javax.xml.bind.annotation.XmlValue;
+
+// PostCode ...
+@XmlAccessorType(XmlAccessType.FIELD)
+@XmlAttribute(required = true, name = "PostCode")
+public class PostCode {
+	protected String PostCode;
+}
+
+// Address ...
+public class Address {
+	@XmlAttribute(name = "kind")
+	protected String KindAttr;
+	@XmlElement(required = true, name = "street")
+	protected String Street;
+	@XmlElement(required = true, name = "city")
+	protected String City;
+}
+
+// Names ...
+public class Names {
+	@XmlElement(required = true, name = "given")
+	protected String Given;
+}
+
+// Audit ...
+public class Audit {
+	@XmlAttribute(name = "created")
+	protected StringAttr Created;
+}
+
+// Resident ...
+public class Resident {
+	@XmlElement(required = true, name = "address")
+	protected Address Address;
+	@XmlElement(required = true, name = "postCode")
+	protected String PostCode;
+}
@@ -0,0 +1,34 @@
+// Code generated by xgen. DO NOT EDIT.
+
+package schema;
+
+import java.util.ArrayList;
+import java.util.List;
+import javax.xml.bind.annotation.XmlAccessType;
+import javax.xml.bind.annotation.XmlAccessorType;
+import javax.xml.bind.annotation.XmlAttribute;
+import javax.xml.bind.annotation.XmlElement;
+import javax.xml.bind.annotation.XmlSchemaType;
+import javax.xml.bind.annotation.XmlType;
+import javax.xml.bind.annotation.XmlValue;
+
+// Currency ...
+@XmlAccessorType(XmlAccessType.FIELD)
+@XmlAttribute(required = true, name = "Currency")
+public class Currency {
+	protected String Currency;
+}
+
+// LineItem ...
+public class LineItem {
+	@XmlElement(required = true, name = "sku")
+	protected String Sku;
+}
+
+// Invoice ...
+public class Invoice {
+	@XmlElement(required = true, name = "item")
+	protected List<LineItem> Item;
+	@XmlElement(required = true, name = "currency")
+	protected String Currency;
+}
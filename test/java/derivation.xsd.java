@@ -0,0 +1,77 @@
+// Code generated by xgen. DO NOT EDIT.
+
+package schema;
+
+import java.util.ArrayList;
+import java.util.List;
+import javax.xml.bind.annotation.XmlAccessType;
+import javax.xml.bind.annotation.XmlAccessorType;
+import javax.xml.bind.annotation.XmlAttribute;
+import javax.xml.bind.annotation.XmlElement;
+import javax.xml.bind.annotation.XmlSchemaType;
+import javax.xml.bind.annotation.XmlType;
+import javax.xml.bind.annotation.XmlValue;
+
+// Price ...
+public class Price {
+	@XmlAttribute(required = true, name = "currency")
+	protected String CurrencyAttr;
+	@XmlAttribute(name = "vat")
+	protected Boolean VatAttr;
+	@XmlValue
+	protected Float value;
+}
+
+// RetailPrice ...
+public class RetailPrice {
+	@XmlAttribute(required = true, name = "currency")
+	protected String CurrencyAttr;
+	@XmlValue
+	protected Float value;
+}
+
+// Vehicle ...
+public class Vehicle {
+	@XmlAttribute(required = true, name = "vin")
+	protected String VinAttr;
+	@XmlElement(required = true, name = "make")
+	protected String Make;
+	@XmlElement(name = "model")
+	protected String Model;
+	@XmlElement(name = "wheels")
+	protected Integer Wheels;
+}
+
+// Bicycle ...
+public class Bicycle {
+	@XmlAttribute(required = true, name = "vin")
+	protected String VinAttr;
+	@XmlElement(required = true, name = "make")
+	protected String Make;
+	@XmlElement(name = "wheels")
+	protected Integer Wheels;
+}
+
+// Truck ...
+public class Truck extends Vehicle  {
+	@XmlElement(required = true, name = "payload")
+	protected Float Payload;
+}
+
+// Remark ...
+public class Remark {
+	@XmlElement(required = true, name = "note")
+	protected String Note;
+}
+
+// Listing ...
+public class Listing {
+	@XmlElement(required = true, name = "price")
+	protected RetailPrice Price;
+	@XmlElement(required = true, name = "bicycle")
+	protected Bicycle Bicycle;
+	@XmlElement(required = true, name = "truck")
+	protected Truck Truck;
+	@XmlElement(name = "remark")
+	protected Remark Remark;
+}
@@ -0,0 +1,45 @@
+// Code generated by xgen. DO NOT EDIT.
+
+package schema;
+
+import java.util.ArrayList;
+import java.util.List;
+import javax.xml.bind.annotation.XmlAccessType;
+import javax.xml.bind.annotation.XmlAccessorType;
+import javax.xml.bind.annotation.XmlAttribute;
+import javax.xml.bind.annotation.XmlElement;
+import javax.xml.bind.annotation.XmlSchemaType;
+import javax.xml.bind.annotation.XmlType;
+import javax.xml.bind.annotation.XmlValue;
+
+// Product ...
+public class Product {
+	@XmlAttribute(required = true, name = "sku")
+	protected String SkuAttr;
+	@XmlAttribute(name = "title")
+	protected String TitleAttr;
+}
+
+// OrderLine ...
+public class OrderLine {
+	@XmlAttribute(name = "number")
+	protected Integer NumberAttr;
+	@XmlElement(required = true, name = "product")
+	protected String Product;
+	@XmlElement(required = true, name = "quantity")
+	protected Integer Quantity;
+}
+
+// Products ...
+public class Products {
+	@XmlElement(required = true, name = "product")
+	protected List<Product> Product;
+}
+
+// PurchaseOrder ...
+public class PurchaseOrder {
+	@XmlElement(required = true, name = "products")
+	protected Products Products;
+	@XmlElement(required = true, name = "line")
+	protected List<OrderLine> Line;
+}
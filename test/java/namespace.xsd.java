@@ -0,0 +1,43 @@
+// Code generated by xgen. DO NOT EDIT.
+
+package schema;
+
+import java.util.ArrayList;
+import java.util.List;
+import javax.xml.bind.annotation.XmlAccessType;
+import javax.xml.bind.annotation.XmlAccessorType;
+import javax.xml.bind.annotation.XmlAttribute;
+import javax.xml.bind.annotation.XmlElement;
+import javax.xml.bind.annotation.XmlSchemaType;
+import javax.xml.bind.annotation.XmlType;
+import javax.xml.bind.annotation.XmlValue;
+
+@XmlAccessorType(XmlAccessType.FIELD)
+@XmlAttribute(required = true, name = "revision")
+public class Revision {
+	protected Integer Revision;
+}
+
+// Order ...
+public class Order {
+	@XmlAttribute(required = true, name = "id")
+	protected String IdAttr;
+	@XmlAttribute(name = "ord:revision")
+	protected Integer OrdRevisionAttr;
+	@XmlAttribute(name = "xml:lang")
+	protected String XmlLangAttr;
+	@XmlElement(required = true, name = "customer")
+	protected String Customer;
+	@XmlElement(name = "note")
+	protected String Note;
+	@XmlElement(required = true, name = "entry")
+	protected List<OrderEntry> Entry;
+}
+
+// OrderEntry ...
+public class OrderEntry {
+	@XmlAttribute(name = "quantity")
+	protected Integer QuantityAttr;
+	@XmlElement(required = true, name = "sku")
+	protected String Sku;
+}
@@ -0,0 +1,39 @@
+// Code generated by xgen. DO NOT EDIT.
+
+package schema;
+
+import java.util.ArrayList;
+import java.util.List;
+import javax.xml.bind.annotation.XmlAccessType;
+import javax.xml.bind.annotation.XmlAccessorType;
+import javax.xml.bind.annotation.XmlAttribute;
+import javax.xml.bind.annotation.XmlElement;
+import javax.xml.bind.annotation.XmlSchemaType;
+import javax.xml.bind.annotation.XmlType;
+import javax.xml.bind.annotation.XmlValue;
+
+// Money ...
+public class Money {
+	@XmlAttribute(required = true, name = "currency")
+	protected String CurrencyAttr;
+	@XmlValue
+	protected Float value;
+}
+
+// Payment ...
+public class Payment {
+	@XmlElement(required = true, nillable = true, name = "amount")
+	protected Money Amount;
+	@XmlElement(nillable = true, name = "fee")
+	protected Float Fee;
+	@XmlElement(nillable = true, name = "reference")
+	protected String Reference;
+	@XmlElement(required = true, nillable = true, name = "tag")
+	protected List<String> Tag;
+}
+
+@XmlAccessorType(XmlAccessType.FIELD)
+@XmlElement(required = true, name = "reference")
+public class Reference {
+	protected String Reference;
+}
@@ -0,0 +1,65 @@
+// Code generated by xgen. DO NOT EDIT.
+
+package schema;
+
+import java.util.ArrayList;
+import java.util.List;
+import javax.xml.bind.annotation.XmlAccessType;
+import javax.xml.bind.annotation.XmlAccessorType;
+import javax.xml.bind.annotation.XmlAttribute;
+import javax.xml.bind.annotation.XmlElement;
+import javax.xml.bind.annotation.XmlSchemaType;
+import javax.xml.bind.annotation.XmlType;
+import javax.xml.bind.annotation.XmlValue;
+
+// ShapeType ...
+public class ShapeType {
+	@XmlAttribute(name = "id")
+	protected String IdAttr;
+}
+
+// CircleType ...
+public class CircleType extends ShapeType  {
+	@XmlElement(required = true, name = "radius")
+	protected Float Radius;
+}
+
+// SquareType ...
+public class SquareType extends ShapeType  {
+	@XmlElement(required = true, name = "side")
+	protected Float Side;
+}
+
+@XmlAccessorType(XmlAccessType.FIELD)
+@XmlElement(required = true, name = "Shape")
+public class Shape {
+	protected ShapeType Shape;
+}
+
+@XmlAccessorType(XmlAccessType.FIELD)
+@XmlElement(required = true, name = "Circle")
+public class Circle {
+	protected CircleType Circle;
+}
+
+@XmlAccessorType(XmlAccessType.FIELD)
+@XmlElement(required = true, name = "Square")
+public class Square {
+	protected SquareType Square;
+}
+
+@XmlAccessorType(XmlAccessType.FIELD)
+@XmlElement(required = true, name = "Tile")
+public class Tile {
+	protected SquareType Tile;
+}
+
+// Drawing ...
+public class Drawing {
+	@XmlElement(required = true, name = "title")
+	protected String Title;
+	@XmlElement(required = true, name = "here:Shape")
+	protected List<ShapeType> HereShape;
+	@XmlElement(name = "caption")
+	protected String Caption;
+}
@@ -0,0 +1,25 @@
+// Code generated by xgen. DO NOT EDIT.
+
+package schema;
+
+import java.util.ArrayList;
+import java.util.List;
+import javax.xml.bind.annotation.XmlAccessType;
+import javax.xml.bind.annotation.XmlAccessorType;
+import javax.xml.bind.annotation.XmlAttribute;
+import javax.xml.bind.annotation.XmlElement;
+import javax.xml.bind.annotation.XmlSchemaType;
+import javax.xml.bind.annotation.XmlType;
+import javax.xml.bind.annotation.XmlValue;
+
+// Party ...
+public class Party {
+	@XmlElement(required = true, name = "name")
+	protected TName Name;
+	@XmlElement(required = true, name = "code")
+	protected TCode Code;
+	@XmlElement(required = true, name = "country")
+	protected TCountry Country;
+	@XmlElement(required = true, name = "region")
+	protected TRegion Region;
+}
@@ -0,0 +1,27 @@
+// Code generated by xgen. DO NOT EDIT.
+
+package schema;
+
+import java.util.ArrayList;
+import java.util.List;
+import javax.xml.bind.annotation.XmlAccessType;
+import javax.xml.bind.annotation.XmlAccessorType;
+import javax.xml.bind.annotation.XmlAttribute;
+import javax.xml.bind.annotation.XmlElement;
+import javax.xml.bind.annotation.XmlSchemaType;
+import javax.xml.bind.annotation.XmlType;
+import javax.xml.bind.annotation.XmlValue;
+
+// Extensible ...
+public class Extensible {
+	@XmlAttribute(name = "version")
+	protected String VersionAttr;
+	@XmlElement(required = true, name = "id")
+	protected String Id;
+}
+
+// Envelope ...
+public class Envelope {
+	@XmlElement(required = true, name = "header")
+	protected Extensible Header;
+}
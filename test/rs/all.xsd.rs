@@ -0,0 +1,34 @@
+// Code generated by xgen. DO NOT EDIT.
+
+use serde::Serialize;
+use serde::Deserialize;
+
+use serde_xml_rs::from_reader;
+
+
+// Preferences ...
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub struct Preferences {
+	#[serde(rename = "language")]
+	pub language: Option<String>,
+	#[serde(rename = "newsletter")]
+	pub newsletter: Option<bool>,
+}
+
+
+// Contact ...
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub struct Contact {
+	#[serde(rename = "id")]
+	pub id: i32,
+	#[serde(rename = "name")]
+	pub name: String,
+	#[serde(rename = "email")]
+	pub email: String,
+	#[serde(rename = "phone")]
+	pub phone: Option<String>,
+	#[serde(rename = "label")]
+	pub label: Vec<String>,
+	#[serde(rename = "preferences")]
+	pub preferences: Option<Preferences>,
+}
@@ -0,0 +1,64 @@
+// Code generated by xgen. DO NOT EDIT.
+
+use serde::Serialize;
+use serde::Deserialize;
+
+use serde_xml_rs::from_reader;
+
+
+// Percentage ...
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub struct Percentage {
+	#[serde(rename = "Percentage")]
+	pub percentage: f64,
+}
+
+
+// Window ...
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub struct Window {
+	#[serde(rename = "from")]
+	pub from: Option<i32>,
+	#[serde(rename = "to")]
+	pub to: Option<i32>,
+}
+
+
+// Discount ...
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub struct Discount {
+	#[serde(rename = "capped")]
+	pub capped: Option<bool>,
+	#[serde(rename = "$value")]
+	pub value: f64,
+}
+
+
+// Shipment ...
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub struct Shipment {
+	#[serde(rename = "express")]
+	pub express: Option<bool>,
+	#[serde(rename = "tax")]
+	pub tax: Option<f64>,
+	#[serde(rename = "item")]
+	pub item: Vec<String>,
+	#[serde(rename = "carrier")]
+	pub carrier: Option<String>,
+	#[serde(rename = "tracking")]
+	pub tracking: Option<String>,
+	#[serde(rename = "window")]
+	pub window: Option<Window>,
+	#[serde(rename = "discount")]
+	pub discount: Option<Discount>,
+}
+
+
+// ExpressShipment ...
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub struct ExpressShipment {
+	#[serde(rename = "deadline")]
+	pub deadline: i32,
+	#[serde(flatten)]
+	pub shipment: Shipment,
+}
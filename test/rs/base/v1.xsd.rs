@@ -0,0 +1,52 @@
+// Code generated by xgen. DO NOT EDIT.
+
+use serde::Serialize;
+use serde::Deserialize;
+
+use serde_xml_rs::from_reader;
+
+
+// PostCode ...
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub struct PostCode {
+	#[serde(rename = "PostCode")]
+	pub post_code: String,
+}
+
+
+// Address ...
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub struct Address {
+	#[serde(rename = "kind")]
+	pub kind: Option<String>,
+	#[serde(rename = "street")]
+	pub street: String,
+	#[serde(rename = "city")]
+	pub city: String,
+}
+
+
+// Names ...
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub struct Names {
+	#[serde(rename = "given")]
+	pub given: String,
+}
+
+
+// Audit ...
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub struct Audit {
+	#[serde(rename = "created")]
+	pub created: Option<String>,
+}
+
+
+// Resident ...
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub struct Resident {
+	#[serde(rename = "address")]
+	pub address: Address,
+	#[serde(rename = "postCode")]
+	pub post_code: String,
+}
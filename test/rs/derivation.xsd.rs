@@ -0,0 +1,86 @@
+// Code generated by xgen. DO NOT EDIT.
+
+use serde::Serialize;
+use serde::Deserialize;
+
+use serde_xml_rs::from_reader;
+
+
+// Price ...
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub struct Price {
+	#[serde(rename = "currency")]
+	pub currency: String,
+	#[serde(rename = "vat")]
+	pub vat: Option<bool>,
+	#[serde(rename = "$value")]
+	pub value: f64,
+}
+
+
+// RetailPrice ...
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub struct RetailPrice {
+	#[serde(rename = "currency")]
+	pub currency: String,
+	#[serde(rename = "$value")]
+	pub value: f64,
+}
+
+
+// Vehicle ...
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub struct Vehicle {
+	#[serde(rename = "vin")]
+	pub vin: String,
+	#[serde(rename = "make")]
+	pub make: String,
+	#[serde(rename = "model")]
+	pub model: Option<String>,
+	#[serde(rename = "wheels")]
+	pub wheels: Option<i32>,
+}
+
+
+// Bicycle ...
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub struct Bicycle {
+	#[serde(rename = "vin")]
+	pub vin: String,
+	#[serde(rename = "make")]
+	pub make: String,
+	#[serde(rename = "wheels")]
+	pub wheels: Option<i32>,
+}
+
+
+// Truck ...
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub struct Truck {
+	#[serde(rename = "payload")]
+	pub payload: f64,
+	#[serde(flatten)]
+	pub vehicle: Vehicle,
+}
+
+
+// Remark ...
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub struct Remark {
+	#[serde(rename = "note")]
+	pub note: String,
+}
+
+
+// Listing ...
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub struct Listing {
+	#[serde(rename = "price")]
+	pub price: RetailPrice,
+	#[serde(rename = "bicycle")]
+	pub bicycle: Bicycle,
+	#[serde(rename = "truck")]
+	pub truck: Truck,
+	#[serde(rename = "remark")]
+	pub remark: Option<Remark>,
+}
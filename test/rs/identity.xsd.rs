@@ -0,0 +1,46 @@
+// Code generated by xgen. DO NOT EDIT.
+
+use serde::Serialize;
+use serde::Deserialize;
+
+use serde_xml_rs::from_reader;
+
+
+// Product ...
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub struct Product {
+	#[serde(rename = "sku")]
+	pub sku: String,
+	#[serde(rename = "title")]
+	pub title: Option<String>,
+}
+
+
+// OrderLine ...
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub struct OrderLine {
+	#[serde(rename = "number")]
+	pub number: Option<i32>,
+	#[serde(rename = "product")]
+	pub product: String,
+	#[serde(rename = "quantity")]
+	pub quantity: i32,
+}
+
+
+// Products ...
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub struct Products {
+	#[serde(rename = "product")]
+	pub product: Vec<Product>,
+}
+
+
+// PurchaseOrder ...
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub struct PurchaseOrder {
+	#[serde(rename = "products")]
+	pub products: Products,
+	#[serde(rename = "line")]
+	pub line: Vec<OrderLine>,
+}
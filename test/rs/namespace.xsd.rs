@@ -0,0 +1,42 @@
+// Code generated by xgen. DO NOT EDIT.
+
+use serde::Serialize;
+use serde::Deserialize;
+
+use serde_xml_rs::from_reader;
+
+
+// revision ...
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub struct revision {
+	#[serde(rename = "revision")]
+	pub revision: i32,
+}
+
+
+// Order ...
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub struct Order {
+	#[serde(rename = "id")]
+	pub id: String,
+	#[serde(rename = "ord:revision")]
+	pub ord_revision: Option<i32>,
+	#[serde(rename = "xml:lang")]
+	pub xml_lang: Option<String>,
+	#[serde(rename = "customer")]
+	pub customer: String,
+	#[serde(rename = "note")]
+	pub note: Option<String>,
+	#[serde(rename = "entry")]
+	pub entry: Vec<OrderEntry>,
+}
+
+
+// OrderEntry ...
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub struct OrderEntry {
+	#[serde(rename = "quantity")]
+	pub quantity: Option<i32>,
+	#[serde(rename = "sku")]
+	pub sku: String,
+}
@@ -0,0 +1,38 @@
+// Code generated by xgen. DO NOT EDIT.
+
+use serde::Serialize;
+use serde::Deserialize;
+
+use serde_xml_rs::from_reader;
+
+
+// Money ...
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub struct Money {
+	#[serde(rename = "currency")]
+	pub currency: String,
+	#[serde(rename = "$value")]
+	pub value: f64,
+}
+
+
+// Payment ...
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub struct Payment {
+	#[serde(rename = "amount")]
+	pub amount: Option<Money>,
+	#[serde(rename = "fee")]
+	pub fee: Option<Option<f64>>,
+	#[serde(rename = "reference")]
+	pub reference: Option<Option<String>>,
+	#[serde(rename = "tag")]
+	pub tag: Vec<Option<String>>,
+}
+
+
+// reference ...
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub struct reference {
+	#[serde(rename = "reference")]
+	pub reference: String,
+}
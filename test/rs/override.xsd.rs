@@ -0,0 +1,34 @@
+// Code generated by xgen. DO NOT EDIT.
+
+use serde::Serialize;
+use serde::Deserialize;
+
+use serde_xml_rs::from_reader;
+
+
+// Currency ...
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub struct Currency {
+	#[serde(rename = "Currency")]
+	pub currency: String,
+}
+
+
+// LineItem ...
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub struct LineItem {
+	#[serde(rename = "sku")]
+	pub sku: String,
+	#[serde(rename = "quantity")]
+	pub quantity: i32,
+}
+
+
+// Invoice ...
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub struct Invoice {
+	#[serde(rename = "item")]
+	pub item: Vec<LineItem>,
+	#[serde(rename = "currency")]
+	pub currency: String,
+}
@@ -0,0 +1,78 @@
+// Code generated by xgen. DO NOT EDIT.
+
+use serde::Serialize;
+use serde::Deserialize;
+
+use serde_xml_rs::from_reader;
+
+
+// ShapeType ...
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub struct ShapeType {
+	#[serde(rename = "id")]
+	pub id: Option<String>,
+}
+
+
+// CircleType ...
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub struct CircleType {
+	#[serde(rename = "radius")]
+	pub radius: f64,
+	#[serde(flatten)]
+	pub shape_type: ShapeType,
+}
+
+
+// SquareType ...
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub struct SquareType {
+	#[serde(rename = "side")]
+	pub side: f64,
+	#[serde(flatten)]
+	pub shape_type: ShapeType,
+}
+
+
+// shape ...
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub struct shape {
+	#[serde(rename = "Shape")]
+	pub shape: ShapeType,
+}
+
+
+// circle ...
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub struct circle {
+	#[serde(rename = "Circle")]
+	pub circle: CircleType,
+}
+
+
+// square ...
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub struct square {
+	#[serde(rename = "Square")]
+	pub square: SquareType,
+}
+
+
+// tile ...
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub struct tile {
+	#[serde(rename = "Tile")]
+	pub tile: SquareType,
+}
+
+
+// Drawing ...
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub struct Drawing {
+	#[serde(rename = "title")]
+	pub title: String,
+	#[serde(rename = "here:Shape")]
+	pub here_shape: Vec<ShapeType>,
+	#[serde(rename = "caption")]
+	pub caption: Option<String>,
+}
@@ -0,0 +1,24 @@
+// Code generated by xgen. DO NOT EDIT.
+
+use serde::Serialize;
+use serde::Deserialize;
+
+use serde_xml_rs::from_reader;
+
+
+// Extensible ...
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub struct Extensible {
+	#[serde(rename = "version")]
+	pub version: Option<String>,
+	#[serde(rename = "id")]
+	pub id: String,
+}
+
+
+// Envelope ...
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub struct Envelope {
+	#[serde(rename = "header")]
+	pub header: Extensible,
+}
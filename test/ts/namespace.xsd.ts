@@ -0,0 +1,20 @@
+// Code generated by xgen. DO NOT EDIT.
+
+// Revision ...
+export type Revision = number;
+
+// Order ...
+export class Order {
+	IdAttr: string;
+	OrdRevisionAttr?: number;
+	XmlLangAttr?: string;
+	Customer: string;
+	Note?: string;
+	Entry: Array<OrderEntry>;
+}
+
+// OrderEntry ...
+export class OrderEntry {
+	QuantityAttr?: number;
+	Sku: string;
+}
@@ -0,0 +1,30 @@
+// Code generated by xgen. DO NOT EDIT.
+
+// PostCode ...
+export type PostCode = string;
+
+// Address ...
+export class Address {
+	KindAttr?: string;
+	Street: string;
+	City: string;
+	Country: string;
+}
+
+// Names ...
+export class Names {
+	Given: string;
+	Family: string;
+}
+
+// Audit ...
+export class Audit {
+	CreatedAttr: string | null;
+	ModifiedAttr: string | null;
+}
+
+// Resident ...
+export class Resident {
+	Address: Address;
+	PostCode: string;
+}
@@ -0,0 +1,35 @@
+// Code generated by xgen. DO NOT EDIT.
+
+// ShapeType ...
+export class ShapeType {
+	IdAttr?: string;
+}
+
+// CircleType ...
+export class CircleType extends ShapeType  {
+	Radius: number;
+}
+
+// SquareType ...
+export class SquareType extends ShapeType  {
+	Side: number;
+}
+
+// Shape ...
+export type Shape = ShapeType;
+
+// Circle ...
+export type Circle = CircleType;
+
+// Square ...
+export type Square = SquareType;
+
+// Tile ...
+export type Tile = SquareType;
+
+// Drawing ...
+export class Drawing {
+	Title: string;
+	HereShape: Array<ShapeType>;
+	Caption?: string;
+}
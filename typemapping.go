@@ -0,0 +1,151 @@
+// Copyright 2026 The xgen Authors. All rights reserved. Use of this source
+// code is governed by a BSD-style license that can be found in the LICENSE
+// file.
+//
+// Package xgen written in pure Go providing a set of functions that allow you
+// to parse XSD (XML schema files). This library needs Go version 1.10 or
+// later.
+
+package xgen
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// TypeMapping maps an XSD type to a type of the generated code, which
+// overrides the type a built-in type is generated as, or a user-defined simple
+// type is referenced by.
+type TypeMapping struct {
+	// Type is the type written in the generated code, such as
+	// decimal.Decimal in Go or BigDecimal in Java.
+	Type string
+	// Import is what the generated code imports the type from, which is the
+	// import path in Go, the class in Java, the module in TypeScript, the use
+	// path in Rust, and the header in C. Nothing is imported if it's empty.
+	Import string
+}
+
+// ParseQName parses the QName of an XSD type in the form of {namespace}local,
+// or xs:local for the XSD namespace. The xsd and xml prefixes are accepted as
+// well, and a name without namespace has no prefix.
+func ParseQName(name string) (QName, error) {
+	if rest, ok := strings.CutPrefix(name, "{"); ok {
+		if space, local, ok := strings.Cut(rest, "}"); ok && local != "" && !strings.ContainsAny(local, "{}:") {
+			return QName{Space: space, Local: local}, nil
+		}
+		return QName{}, fmt.Errorf("invalid QName %q", name)
+	}
+	prefix, local, ok := strings.Cut(name, ":")
+	if !ok {
+		prefix, local = "", name
+	}
+	if local == "" || strings.ContainsAny(local, "{}:") {
+		return QName{}, fmt.Errorf("invalid QName %q", name)
+	}
+	namespaces := map[string]string{"": "", "xs": xsdNamespace, "xsd": xsdNamespace, "xml": xmlNamespace}
+	space, ok := namespaces[prefix]
+	if !ok {
+		return QName{}, fmt.Errorf("unknown prefix %q of QName %q, use {namespace}%s instead", prefix, name, local)
+	}
+	return QName{Space: space, Local: local}, nil
+}
+
+// ParseTypeMapping returns the mapping to the type written in given language.
+// A Go type may be qualified by its import path, such as
+// github.com/shopspring/decimal.Decimal, which is imported by the path and
+// referenced by the package name assumed from the path, such as
+// decimal.Decimal. Other types are written as is.
+func ParseTypeMapping(lang, typ string) TypeMapping {
+	slash, dot := strings.LastIndex(typ, "/"), strings.LastIndex(typ, ".")
+	if lang != "Go" || slash < 0 || dot < slash {
+		return TypeMapping{Type: typ}
+	}
+	importPath := typ[:dot]
+	elements := strings.Split(importPath, "/")
+	name := elements[len(elements)-1]
+	if len(elements) > 1 && len(name) > 1 && name[0] == 'v' && strings.Trim(name[1:], "0123456789") == "" {
+		// The major version suffix of a module path
+		name = elements[len(elements)-2]
+	}
+	name, _, _ = strings.Cut(name, ".")
+	return TypeMapping{Type: name + typ[dot:], Import: importPath}
+}
+
+// mappedType returns the type the type referenced by given QName in the schema
+// is mapped to by the type mappings. A type which is already mapped is
+// returned as is, as the resolved types are resolved again.
+func (opt *Options) mappedType(value string) (string, bool) {
+	if len(opt.TypeMappings) == 0 {
+		return "", false
+	}
+	if mapping, ok := opt.TypeMappings[QName{Space: opt.parseNS(value), Local: trimNSPrefix(value)}]; ok {
+		return mapping.Type, true
+	}
+	for _, mapping := range opt.TypeMappings {
+		if mapping.Type == value {
+			return value, true
+		}
+	}
+	return "", false
+}
+
+// isMappedType reports whether the type of the generated code is mapped from
+// an XSD type by the type mappings, which is written as is.
+func (gen *CodeGenerator) isMappedType(name string) bool {
+	if gen.mappedTypes == nil {
+		gen.mappedTypes = map[string]bool{}
+		for _, mapping := range gen.TypeMappings {
+			gen.mappedTypes[mapping.Type] = true
+		}
+	}
+	return gen.mappedTypes[name]
+}
+
+// mappedImports returns the imports of the mapped types used by the generated
+// code in order, each of which is formatted by given function.
+func (gen *CodeGenerator) mappedImports(format func(mapping TypeMapping) string) (imports string) {
+	set := map[string]bool{}
+	for _, mapping := range gen.TypeMappings {
+		if mapping.Import != "" && usesType(gen.Field.String(), mapping.Type) {
+			set[format(mapping)] = true
+		}
+	}
+	for _, line := range sortedKeys(set) {
+		imports += line
+	}
+	return
+}
+
+// cInclude returns the include directive of the header, which is quoted
+// unless it's already enclosed in angle brackets or quotes.
+func cInclude(mapping TypeMapping) string {
+	header := mapping.Import
+	if !strings.HasPrefix(header, "<") && !strings.HasPrefix(header, `"`) {
+		header = strconv.Quote(header)
+	}
+	return fmt.Sprintf("#include %s\n", header)
+}
+
+// usesType reports whether the code refers to the type, which is not a part of
+// another identifier.
+func usesType(code, typ string) bool {
+	isIdent := func(r rune) bool { return r == '_' || r == '.' || unicode.IsLetter(r) || unicode.IsDigit(r) }
+	for offset := 0; typ != ""; {
+		i := strings.Index(code[offset:], typ)
+		if i < 0 {
+			return false
+		}
+		start, end := offset+i, offset+i+len(typ)
+		before, _ := utf8.DecodeLastRuneInString(code[:start])
+		after, _ := utf8.DecodeRuneInString(code[end:])
+		if !isIdent(before) && !isIdent(after) {
+			return true
+		}
+		offset = end
+	}
+	return false
+}
@@ -0,0 +1,170 @@
+// Copyright 2026 The xgen Authors. All rights reserved. Use of this source
+// code is governed by a BSD-style license that can be found in the LICENSE
+// file.
+//
+// Package xgen written in pure Go providing a set of functions that allow you
+// to parse XSD (XML schema files). This library needs Go version 1.10 or
+// later.
+
+package xgen
+
+import (
+	"context"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseQName(t *testing.T) {
+	for name, expected := range map[string]QName{
+		"xs:decimal":            {Space: xsdNamespace, Local: "decimal"},
+		"xsd:date":              {Space: xsdNamespace, Local: "date"},
+		"xml:lang":              {Space: xmlNamespace, Local: "lang"},
+		"{urn:common}Code":      {Space: "urn:common", Local: "Code"},
+		"{}Code":                {Local: "Code"},
+		"Code":                  {Local: "Code"},
+		"{http://a/b}Code-Type": {Space: "http://a/b", Local: "Code-Type"},
+	} {
+		qname, err := ParseQName(name)
+		require.NoError(t, err, name)
+		assert.Equal(t, expected, qname, name)
+	}
+	for name, message := range map[string]string{
+		"":                `invalid QName ""`,
+		"xs:":             `invalid QName "xs:"`,
+		"{urn:common":     `invalid QName "{urn:common"`,
+		"{urn:common}":    `invalid QName "{urn:common}"`,
+		"{urn:common}a:b": `invalid QName "{urn:common}a:b"`,
+		"c:Code":          `unknown prefix "c" of QName "c:Code", use {namespace}Code instead`,
+	} {
+		_, err := ParseQName(name)
+		assert.EqualError(t, err, message, name)
+	}
+}
+
+func TestTypeMappings(t *testing.T) {
+	fsys := fstest.MapFS{
+		"common.xsd": {Data: []byte(`<xs:schema xmlns:xs="http://www.w3.org/2001/XMLSchema" targetNamespace="urn:common">
+	<xs:simpleType name="Code">
+		<xs:restriction base="xs:string">
+			<xs:maxLength value="3"/>
+		</xs:restriction>
+	</xs:simpleType>
+</xs:schema>`)},
+		"order.xsd": {Data: []byte(`<xs:schema xmlns:xs="http://www.w3.org/2001/XMLSchema" xmlns:c="urn:common" targetNamespace="urn:common">
+	<xs:include schemaLocation="common.xsd"/>
+	<xs:simpleType name="Amount">
+		<xs:restriction base="xs:decimal">
+			<xs:minInclusive value="0"/>
+		</xs:restriction>
+	</xs:simpleType>
+	<xs:simpleType name="Rate">
+		<xs:restriction base="xs:decimal"/>
+	</xs:simpleType>
+	<xs:complexType name="Order">
+		<xs:sequence>
+			<xs:element name="total" type="xs:decimal"/>
+			<xs:element name="amount" type="c:Amount" minOccurs="0"/>
+			<xs:element name="rate" type="c:Rate"/>
+			<xs:element name="code" type="c:Code"/>
+			<xs:element name="codes" type="c:Code" maxOccurs="unbounded"/>
+		</xs:sequence>
+		<xs:attribute name="tax" type="xs:decimal"/>
+	</xs:complexType>
+</xs:schema>`)},
+	}
+	decimal, amount, code := QName{Space: xsdNamespace, Local: "decimal"}, QName{Space: "urn:common", Local: "Amount"}, QName{Space: "urn:common", Local: "Code"}
+	for lang, test := range map[string]struct {
+		mappings map[QName]TypeMapping
+		contains []string
+	}{
+		"Go": {
+			mappings: map[QName]TypeMapping{
+				decimal: {Type: "decimal.Decimal", Import: "github.com/shopspring/decimal"},
+				amount:  {Type: "money.Amount", Import: "example.com/money"},
+				code:    {Type: "codes.Code", Import: "example.com/codes"},
+			},
+			contains: []string{
+				"import (\n\t\"example.com/codes\"\n\t\"example.com/money\"\n\t\"github.com/shopspring/decimal\"\n)",
+				"type Rate decimal.Decimal\n",
+				"Tax    *decimal.Decimal `xml:\"tax,attr\"`",
+				"Total  decimal.Decimal  `xml:\"total\"`",
+				"Amount *money.Amount    `xml:\"amount,omitempty\"`",
+				"Rate   Rate             `xml:\"rate\"`",
+				"Code   codes.Code       `xml:\"code\"`",
+				"Codes  []codes.Code     `xml:\"codes\"`",
+			},
+		},
+		"Java": {
+			mappings: map[QName]TypeMapping{
+				decimal: {Type: "BigDecimal", Import: "java.math.BigDecimal"},
+				code:    {Type: "Code", Import: "com.example.Code"},
+			},
+			contains: []string{
+				"import javax.xml.bind.annotation.XmlValue;\nimport com.example.Code;\nimport java.math.BigDecimal;\n",
+				"protected BigDecimal TaxAttr;",
+				"protected BigDecimal Total;",
+				"protected List<Code> Codes;",
+			},
+		},
+		"TypeScript": {
+			mappings: map[QName]TypeMapping{
+				decimal: {Type: "Decimal", Import: "decimal.js"},
+				code:    {Type: "CodeValue", Import: "./codes"},
+			},
+			contains: []string{
+				"import { CodeValue } from \"./codes\";\nimport { Decimal } from \"decimal.js\";\n",
+				"export type Amount = Decimal;",
+				"TaxAttr?: Decimal;",
+				"Code: CodeValue;",
+				"Codes: Array<CodeValue>;",
+			},
+		},
+		"Rust": {
+			mappings: map[QName]TypeMapping{
+				decimal: {Type: "Decimal", Import: "rust_decimal::Decimal"},
+				code:    {Type: "codes::Code"},
+			},
+			contains: []string{
+				"use serde_xml_rs::from_reader;\nuse rust_decimal::Decimal;\n",
+				"pub tax: Option<Decimal>,",
+				"pub total: Decimal,",
+				"pub codes: Vec<codes::Code>,",
+			},
+		},
+		"C": {
+			mappings: map[QName]TypeMapping{
+				decimal: {Type: "decimal_t", Import: "decimal.h"},
+				code:    {Type: "code_t", Import: "<codes.h>"},
+			},
+			contains: []string{
+				"#include \"decimal.h\"\n#include <codes.h>\n",
+				"typedef decimal_t Amount;",
+				"decimal_t TaxAttr; // attr, optional",
+				"code_t Codes[];",
+			},
+		},
+	} {
+		set, err := Load(context.Background(), &Options{FS: fsys, FilePath: "order.xsd", Lang: lang, TypeMappings: test.mappings})
+		require.NoError(t, err, lang)
+		sink := MapSink{}
+		require.NoError(t, Generate(context.Background(), set, sink), lang)
+		require.Len(t, sink, 1, lang)
+		for _, code := range sink {
+			for _, expected := range test.contains {
+				assert.Contains(t, string(code), expected, lang)
+			}
+		}
+	}
+
+	// Nothing is imported by the mapped types unused by the generated code
+	set, err := Load(context.Background(), &Options{FS: fsys, FilePath: "common.xsd", Lang: "Go", TypeMappings: map[QName]TypeMapping{
+		decimal: {Type: "decimal.Decimal", Import: "github.com/shopspring/decimal"},
+	}})
+	require.NoError(t, err)
+	sink := MapSink{}
+	require.NoError(t, Generate(context.Background(), set, sink))
+	assert.NotContains(t, string(sink[".go"]), "shopspring")
+}
@@ -9,9 +9,8 @@
 package xgen
 
 import (
+	"context"
 	"fmt"
-	"io/ioutil"
-	"net/http"
 	"net/url"
 	"os"
 	"path/filepath"
@@ -19,15 +18,15 @@ import (
 	"regexp"
 	"sort"
 	"strings"
+	"sync"
 	"unicode"
 	"unicode/utf8"
 )
 
 var (
-	copyright      = "// Code generated by xgen. DO NOT EDIT."
-	matchFirstCap  = regexp.MustCompile("([A-Z])([A-Z][a-z])")
-	matchAllCap    = regexp.MustCompile("([a-z0-9])([A-Z])")
-	fieldNameCount map[string]int
+	copyright     = "// Code generated by xgen. DO NOT EDIT."
+	matchFirstCap = regexp.MustCompile("([A-Z])([A-Z][a-z])")
+	matchAllCap   = regexp.MustCompile("([a-z0-9])([A-Z])")
 )
 
 // ToSnakeCase converts the provided string to snake_case.
@@ -144,26 +143,6 @@ func getBuildInTypeByLang(value, lang string) (buildType string, ok bool) {
 	return
 }
 
-func getBasefromSimpleType(name string, XSDSchema []interface{}) string {
-	for _, ele := range XSDSchema {
-		switch v := ele.(type) {
-		case *SimpleType:
-			if !v.List && !v.Union && v.Name == name {
-				return v.Base
-			}
-		case *Attribute:
-			if v.Name == name {
-				return v.Type
-			}
-		case *Element:
-			if v.Name == name {
-				return v.Type
-			}
-		}
-	}
-	return name
-}
-
 func getNSPrefix(str string) (ns string) {
 	split := strings.Split(str, ":")
 	if len(split) == 2 {
@@ -235,25 +214,6 @@ func isValidURL(toTest string) bool {
 	return true
 }
 
-func fetchSchema(URL string) ([]byte, error) {
-	var body []byte
-	var client http.Client
-	var err error
-	resp, err := client.Get(URL)
-	if err != nil {
-		return body, err
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode == http.StatusOK {
-		body, err = ioutil.ReadAll(resp.Body)
-		if err != nil {
-			return body, err
-		}
-	}
-	return body, err
-}
-
 func genFieldComment(name, doc, prefix string) string {
 	docReplacer := strings.NewReplacer("\n", fmt.Sprintf("\r\n%s ", prefix), "\t", "")
 	if doc == "" {
@@ -287,3 +247,57 @@ func toSortedPairs(toSort map[string]string) kvPairList {
 	sort.Sort(pl)
 	return pl
 }
+
+// runJobs runs the job by each index from 0 to n-1 in up to given number of
+// goroutines, the jobs are run one at a time if it's less than 2. The jobs are
+// started in the order of the indexes, no more job is started once a job fails
+// or the context is canceled. The error of the failed job by the lowest index
+// is returned, which is the one returned when running the jobs one by one.
+func runJobs(ctx context.Context, jobs, n int, job func(i int) error) error {
+	if jobs > n {
+		jobs = n
+	}
+	if jobs < 2 {
+		for i := 0; i < n && ctx.Err() == nil; i++ {
+			if err := job(i); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	var (
+		wg     sync.WaitGroup
+		mu     sync.Mutex
+		failed = n
+		errs   = make([]error, n)
+		next   = make(chan int)
+	)
+	for w := 0; w < jobs; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				if errs[i] = job(i); errs[i] != nil {
+					mu.Lock()
+					failed = min(failed, i)
+					mu.Unlock()
+				}
+			}
+		}()
+	}
+	for i := 0; i < n && ctx.Err() == nil; i++ {
+		mu.Lock()
+		stop := failed < n
+		mu.Unlock()
+		if stop {
+			break
+		}
+		next <- i
+	}
+	close(next)
+	wg.Wait()
+	if failed < n {
+		return errs[failed]
+	}
+	return nil
+}
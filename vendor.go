@@ -0,0 +1,214 @@
+// Copyright 2026 The xgen Authors. All rights reserved. Use of this source
+// code is governed by a BSD-style license that can be found in the LICENSE
+// file.
+//
+// Package xgen written in pure Go providing a set of functions that allow you
+// to parse XSD (XML schema files). This library needs Go version 1.10 or
+// later.
+
+package xgen
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"html"
+	"io"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"golang.org/x/net/html/charset"
+)
+
+// schemaLocationAttr matches the schemaLocation attributes in a schema.
+var schemaLocationAttr = regexp.MustCompile(`(schemaLocation\s*=\s*)("[^"]*"|'[^']*')`)
+
+// Vendor copies the schemas by given input path, which is a schema file, a
+// directory of schema files or the URL of a remote schema, into the output
+// directory along with all schemas they import, include, redefine or
+// override, directly or indirectly. The remote schemas are fetched by the
+// fetcher and stored by their hosts and paths, the local schemas keep their
+// paths relative to the input directory. The schemaLocation of each
+// reference is rewritten to the relative path of the local copy.
+func Vendor(input, output string, fetcher *SchemaFetcher) error {
+	if fetcher == nil {
+		fetcher = &SchemaFetcher{}
+	}
+	v := &vendor{root: input, fetcher: fetcher, targets: map[string]string{}}
+	if isValidURL(input) {
+		u, err := url.Parse(input)
+		if err != nil {
+			return err
+		}
+		v.add(input, remoteSchemaPath(u))
+	} else {
+		fi, err := os.Stat(input)
+		if err != nil {
+			return err
+		}
+		if !fi.IsDir() {
+			v.root = filepath.Dir(input)
+		}
+		files, err := GetFileList(input)
+		if err != nil {
+			return err
+		}
+		for _, file := range files {
+			if filepath.Ext(file) != ".xsd" {
+				continue
+			}
+			target, err := v.localTarget(file)
+			if err != nil {
+				return err
+			}
+			v.add(file, target)
+		}
+	}
+	for len(v.queue) > 0 {
+		source := v.queue[0]
+		v.queue = v.queue[1:]
+		if err := v.copy(source, output); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// vendor holds the state of copying a closure of schemas.
+type vendor struct {
+	root    string            // the directory of the local schemas
+	fetcher *SchemaFetcher    // fetches the remote schemas
+	targets map[string]string // source path or URL -> slash-separated path of the copy
+	queue   []string          // sources to be copied
+}
+
+// add queues the schema by given source path or URL to be copied to the
+// target path.
+func (v *vendor) add(source, target string) {
+	if _, ok := v.targets[source]; ok {
+		return
+	}
+	v.targets[source] = target
+	v.queue = append(v.queue, source)
+}
+
+// localTarget returns the path of the copy of the local schema, which must
+// be inside of the input directory.
+func (v *vendor) localTarget(file string) (string, error) {
+	rel, err := filepath.Rel(v.root, file)
+	if err != nil {
+		return "", err
+	}
+	if rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("schema %s is outside of %s", file, v.root)
+	}
+	return filepath.ToSlash(rel), nil
+}
+
+// copy copies the schema by given source path or URL into the output
+// directory, queues the schemas it references and rewrites the references.
+func (v *vendor) copy(source, output string) error {
+	file := source
+	if isValidURL(source) {
+		var err error
+		if file, err = v.fetcher.Fetch(source); err != nil {
+			return err
+		}
+	}
+	body, err := os.ReadFile(file)
+	if err != nil {
+		return err
+	}
+	locations, err := schemaLocations(body)
+	if err != nil {
+		return fmt.Errorf("%s: %w", source, err)
+	}
+	target := v.targets[source]
+	rewrites := map[string]string{}
+	for _, location := range locations {
+		var referenced, referencedTarget string
+		switch {
+		case isValidURL(location):
+			referenced = location
+		case isValidURL(source):
+			base, _ := url.Parse(source)
+			ref, err := url.Parse(location)
+			if err != nil {
+				return fmt.Errorf("%s: %w", source, err)
+			}
+			referenced = base.ResolveReference(ref).String()
+		default:
+			referenced = filepath.Join(filepath.Dir(file), filepath.FromSlash(location))
+		}
+		if referencedTarget = v.targets[referenced]; referencedTarget == "" {
+			if isValidURL(referenced) {
+				u, err := url.Parse(referenced)
+				if err != nil {
+					return fmt.Errorf("%s: %w", source, err)
+				}
+				referencedTarget = remoteSchemaPath(u)
+			} else if referencedTarget, err = v.localTarget(referenced); err != nil {
+				return err
+			}
+			v.add(referenced, referencedTarget)
+		}
+		rel, err := filepath.Rel(filepath.FromSlash(path.Dir(target)), filepath.FromSlash(referencedTarget))
+		if err != nil {
+			return err
+		}
+		if rel = filepath.ToSlash(rel); rel != location {
+			rewrites[location] = rel
+		}
+	}
+	if len(rewrites) > 0 {
+		body = schemaLocationAttr.ReplaceAllFunc(body, func(attr []byte) []byte {
+			m := schemaLocationAttr.FindSubmatch(attr)
+			location := html.UnescapeString(string(m[2][1 : len(m[2])-1]))
+			rewrite, ok := rewrites[location]
+			if !ok {
+				return attr
+			}
+			var escaped bytes.Buffer
+			_ = xml.EscapeText(&escaped, []byte(rewrite))
+			return []byte(fmt.Sprintf("%s\"%s\"", m[1], escaped.String()))
+		})
+	}
+	dest := filepath.Join(output, filepath.FromSlash(target))
+	if err = PrepareOutputDir(filepath.Dir(dest)); err != nil {
+		return err
+	}
+	return os.WriteFile(dest, body, 0o644)
+}
+
+// schemaLocations returns the schemaLocation of the import, include,
+// redefine and override elements in the schema.
+func schemaLocations(body []byte) (locations []string, err error) {
+	decoder := xml.NewDecoder(bytes.NewReader(body))
+	decoder.CharsetReader = charset.NewReaderLabel
+	decoder.Strict = false
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			return locations, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		ele, ok := token.(xml.StartElement)
+		if !ok {
+			continue
+		}
+		switch ele.Name.Local {
+		case "import", "include", "redefine", "override":
+			for _, attr := range ele.Attr {
+				if attr.Name.Local == "schemaLocation" && attr.Value != "" {
+					locations = append(locations, attr.Value)
+				}
+			}
+		}
+	}
+}
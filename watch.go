@@ -0,0 +1,166 @@
+// Copyright 2026 The xgen Authors. All rights reserved. Use of this source
+// code is governed by a BSD-style license that can be found in the LICENSE
+// file.
+//
+// Package xgen written in pure Go providing a set of functions that allow you
+// to parse XSD (XML schema files). This library needs Go version 1.10 or
+// later.
+
+package xgen
+
+import (
+	"context"
+	"time"
+)
+
+// DefaultWatchInterval is the interval the files are polled at by a watcher
+// if none is given.
+const DefaultWatchInterval = time.Second
+
+// Watcher regenerates the code of the schema files loaded by the options
+// whenever the files they depend on change. The files are polled, which are
+// the files of the input and the files the schema files depend on recorded in
+// the manifest. Only the schema files whose dependencies have changed are
+// parsed again, and the referenced schemas which have not changed are reused
+// between the runs. A manifest is kept by the watcher if the options have
+// none.
+type Watcher struct {
+	Options  *Options
+	Sink     Sink
+	Interval time.Duration
+	// Report is called with each run of the watcher if it's not nil.
+	Report func(WatchRun)
+
+	manifest *Manifest
+	schemas  *schemaCache
+	stamps   map[string]fileStamp
+}
+
+// WatchRun is a run of the watcher regenerating the code of the schemas.
+type WatchRun struct {
+	// Changed are the files changed since the previous run, which are all
+	// the files polled on the first run.
+	Changed []string
+	// Schemas are the locations of the schemas the code is generated for.
+	Schemas []string
+	// Diagnostics holds the warnings found when loading the schemas, the
+	// errors are returned by Err.
+	Diagnostics Diagnostics
+	Err         error
+	Duration    time.Duration
+}
+
+// fileStamp is the modification time and size of a polled file, which is zero
+// if the file doesn't exist.
+type fileStamp struct {
+	modTime time.Time
+	size    int64
+}
+
+// Run polls the files at the interval until the context is canceled, the code
+// is generated on the first poll and whenever any file has changed.
+func (w *Watcher) Run(ctx context.Context) error {
+	interval := w.Interval
+	if interval <= 0 {
+		interval = DefaultWatchInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if run, ok := w.Poll(ctx); ok && w.Report != nil && ctx.Err() == nil {
+			w.Report(run)
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// Poll checks the files once, and regenerates the code of the schema files
+// depending on the changed ones. It reports false if no file has changed
+// since the previous poll. The manifest is left as it was if the run fails,
+// so that the schema files are generated again on the next change.
+func (w *Watcher) Poll(ctx context.Context) (run WatchRun, ok bool) {
+	if w.manifest == nil {
+		if w.manifest = w.Options.Manifest; w.manifest == nil {
+			w.manifest = NewManifest("")
+		}
+		w.schemas = newSchemaCache()
+	}
+	stamps := w.snapshot()
+	changed := map[string]bool{}
+	for file, stamp := range stamps {
+		if previous, polled := w.stamps[file]; !polled || previous != stamp {
+			changed[file] = true
+		}
+	}
+	for file := range w.stamps {
+		if _, polled := stamps[file]; !polled {
+			changed[file] = true
+		}
+	}
+	if w.stamps != nil && len(changed) == 0 {
+		return run, false
+	}
+	start := time.Now()
+	run.Changed = sortedKeys(changed)
+	w.schemas.invalidate(changed)
+	recorded := make(map[string]*ManifestSchema, len(w.manifest.Schemas))
+	for file, schema := range w.manifest.Schemas {
+		recorded[file] = schema
+	}
+	cfg := *w.Options
+	cfg.Manifest, cfg.schemas = w.manifest, w.schemas
+	set, err := Load(ctx, &cfg)
+	if err == nil {
+		for _, schema := range set.Schemas {
+			run.Schemas = append(run.Schemas, schema.Location)
+		}
+		err = Generate(ctx, set, w.Sink)
+		run.Diagnostics = set.Diagnostics
+	}
+	if run.Err = err; err != nil {
+		w.manifest.Schemas = recorded
+	}
+	// The files are polled as of before the run, so that the changes made
+	// while running are found by the next poll
+	polled := w.snapshot()
+	for file := range polled {
+		if stamp, ok := stamps[file]; ok {
+			polled[file] = stamp
+		}
+	}
+	w.stamps = polled
+	run.Duration = time.Since(start)
+	return run, true
+}
+
+// snapshot returns the stamps of the files of the input, and the files the
+// schema files depend on recorded in the manifest.
+func (w *Watcher) snapshot() map[string]fileStamp {
+	stamps := map[string]fileStamp{}
+	if files, err := w.Options.inputFiles(w.Options.input()); err == nil {
+		for _, file := range files {
+			stamps[file] = w.stamp(file)
+		}
+	}
+	for _, schema := range w.manifest.Schemas {
+		for file := range schema.Inputs {
+			if _, polled := stamps[file]; !polled {
+				stamps[file] = w.stamp(file)
+			}
+		}
+	}
+	return stamps
+}
+
+// stamp returns the stamp of the file.
+func (w *Watcher) stamp(file string) fileStamp {
+	fi, err := w.Options.stat(file)
+	if err != nil {
+		return fileStamp{}
+	}
+	return fileStamp{modTime: fi.ModTime(), size: fi.Size()}
+}
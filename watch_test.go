@@ -0,0 +1,113 @@
+// Copyright 2026 The xgen Authors. All rights reserved. Use of this source
+// code is governed by a BSD-style license that can be found in the LICENSE
+// file.
+//
+// Package xgen written in pure Go providing a set of functions that allow you
+// to parse XSD (XML schema files). This library needs Go version 1.10 or
+// later.
+
+package xgen
+
+import (
+	"context"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWatcher(t *testing.T) {
+	modTime := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	fsys := fstest.MapFS{
+		"common.xsd": {ModTime: modTime, Data: []byte(`<xs:schema xmlns:xs="http://www.w3.org/2001/XMLSchema" targetNamespace="urn:common">
+	<xs:simpleType name="Code">
+		<xs:restriction base="xs:string"/>
+	</xs:simpleType>
+</xs:schema>`)},
+		"note.xsd": {ModTime: modTime, Data: []byte(`<xs:schema xmlns:xs="http://www.w3.org/2001/XMLSchema">
+	<xs:element name="note" type="xs:string"/>
+</xs:schema>`)},
+		"order/order.xsd": {ModTime: modTime, Data: []byte(`<xs:schema xmlns:xs="http://www.w3.org/2001/XMLSchema" xmlns:c="urn:common">
+	<xs:import namespace="urn:common" schemaLocation="../common.xsd"/>
+	<xs:complexType name="Order">
+		<xs:attribute name="code" type="c:Code"/>
+	</xs:complexType>
+</xs:schema>`)},
+	}
+	ctx := context.Background()
+	sink := MapSink{}
+	watcher := &Watcher{Options: &Options{FS: fsys, InputDir: ".", Lang: "Go"}, Sink: sink}
+	run, ok := watcher.Poll(ctx)
+	require.True(t, ok)
+	require.NoError(t, run.Err)
+	assert.Equal(t, []string{"common.xsd", "note.xsd", "order/order.xsd"}, run.Changed)
+	assert.Equal(t, []string{"common.xsd", "note.xsd", "order/order.xsd"}, run.Schemas)
+	assert.Contains(t, string(sink["order/order.xsd.go"]), "Code *string")
+	cached := watcher.schemas.schema("common.xsd")
+
+	_, ok = watcher.Poll(ctx)
+	assert.False(t, ok)
+
+	// Only the schema files depending on the changed one are regenerated
+	modTime = modTime.Add(time.Second)
+	fsys["note.xsd"].ModTime = modTime
+	run, ok = watcher.Poll(ctx)
+	require.True(t, ok)
+	assert.Equal(t, []string{"note.xsd"}, run.Changed)
+	assert.Empty(t, run.Schemas, "the content is unchanged")
+	fsys["note.xsd"].Data = append(fsys["note.xsd"].Data, '\n')
+	run, ok = watcher.Poll(ctx)
+	require.True(t, ok)
+	assert.Equal(t, []string{"note.xsd"}, run.Changed)
+	assert.Equal(t, []string{"note.xsd"}, run.Schemas)
+	assert.Same(t, cached, watcher.schemas.schema("common.xsd"))
+
+	fsys["common.xsd"] = &fstest.MapFile{ModTime: modTime, Data: []byte(`<xs:schema xmlns:xs="http://www.w3.org/2001/XMLSchema" targetNamespace="urn:common">
+	<xs:simpleType name="Code">
+		<xs:restriction base="xs:int"/>
+	</xs:simpleType>
+</xs:schema>`)}
+	run, ok = watcher.Poll(ctx)
+	require.True(t, ok)
+	require.NoError(t, run.Err)
+	assert.Equal(t, []string{"common.xsd"}, run.Changed)
+	assert.Equal(t, []string{"common.xsd", "order/order.xsd"}, run.Schemas)
+	assert.NotSame(t, cached, watcher.schemas.schema("common.xsd"))
+	assert.Contains(t, string(sink["order/order.xsd.go"]), "Code *int")
+
+	// The schema files are generated again once the failing one is fixed
+	data := fsys["order/order.xsd"].Data
+	fsys["order/order.xsd"] = &fstest.MapFile{ModTime: modTime, Data: []byte(`<xs:schema`)}
+	fsys["note.xsd"].Data = append(fsys["note.xsd"].Data, '\n')
+	run, ok = watcher.Poll(ctx)
+	require.True(t, ok)
+	assert.Error(t, run.Err)
+	assert.Empty(t, run.Schemas)
+	fsys["order/order.xsd"] = &fstest.MapFile{ModTime: modTime, Data: append(data, '\n')}
+	run, ok = watcher.Poll(ctx)
+	require.True(t, ok)
+	require.NoError(t, run.Err)
+	assert.Equal(t, []string{"order/order.xsd"}, run.Changed)
+	assert.Equal(t, []string{"note.xsd", "order/order.xsd"}, run.Schemas)
+
+	// A removed schema file is no longer polled
+	delete(fsys, "note.xsd")
+	run, ok = watcher.Poll(ctx)
+	require.True(t, ok)
+	assert.Equal(t, []string{"note.xsd"}, run.Changed)
+	assert.Empty(t, run.Schemas)
+	_, ok = watcher.Poll(ctx)
+	assert.False(t, ok)
+
+	var runs []WatchRun
+	ctx, cancel := context.WithCancel(ctx)
+	watcher = &Watcher{Options: &Options{FS: fsys, Lang: "Go"}, Sink: MapSink{}, Interval: time.Millisecond, Report: func(run WatchRun) {
+		runs = append(runs, run)
+		cancel()
+	}}
+	require.NoError(t, watcher.Run(ctx))
+	require.Len(t, runs, 1)
+	assert.Equal(t, []string{"common.xsd", "order/order.xsd"}, runs[0].Schemas)
+}
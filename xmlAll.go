@@ -0,0 +1,76 @@
+// Copyright 2026 The xgen Authors. All rights reserved. Use of this source
+// code is governed by a BSD-style license that can be found in the LICENSE
+// file.
+//
+// Package xgen written in pure Go providing a set of functions that allow you
+// to parse XSD (XML schema files). This library needs Go version 1.10 or
+// later.
+
+package xgen
+
+import (
+	"encoding/xml"
+	"fmt"
+)
+
+// OnAll handles parsing event on the all start elements. The all element
+// specifies that the child elements can appear in any order and that each
+// child element can occur zero or one time.
+func (opt *Options) OnAll(ele xml.StartElement, protoTree []Component) (err error) {
+	all := All{}
+	for _, attr := range ele.Attr {
+		if attr.Name.Local == "id" {
+			all.ID = attr.Value
+		}
+		if attr.Name.Local == "minOccurs" {
+			all.Optional = attr.Value == "0"
+		}
+	}
+	if opt.ComplexType.Len() > 0 {
+		var complexType *ComplexType
+		if complexType, err = peekAs[*ComplexType](opt.ComplexType, "complexType"); err != nil {
+			return
+		}
+		complexType.All = &all
+	} else if opt.InGroup > 0 && opt.Group.Len() > 0 {
+		var group *Group
+		if group, err = peekAs[*Group](opt.Group, "group"); err != nil {
+			return
+		}
+		group.All = &all
+	}
+	// The elements in the all group are not plural unless they have their
+	// own maxOccurs
+	opt.InPluralSequence = append(opt.InPluralSequence, false)
+	opt.All.Push(&all)
+	return
+}
+
+// EndAll handles parsing event on the all end elements.
+func (opt *Options) EndAll(ele xml.EndElement, protoTree []Component) (err error) {
+	if len(opt.InPluralSequence) == 0 {
+		return fmt.Errorf("unexpected end of %s", ele.Name.Local)
+	}
+	opt.InPluralSequence = opt.InPluralSequence[:len(opt.InPluralSequence)-1]
+	_, err = popAs[*All](opt.All, "all")
+	return
+}
+
+// currentAll returns the all group containing the element being parsed, or
+// nil if the element is not a particle of an all group.
+func (opt *Options) currentAll() *All {
+	all, ok := opt.All.Peek().(*All)
+	if !ok {
+		return nil
+	}
+	if complexType, ok := opt.ComplexType.Peek().(*ComplexType); ok {
+		if complexType.All == all {
+			return all
+		}
+		return nil
+	}
+	if group, ok := opt.Group.Peek().(*Group); ok && opt.InGroup > 0 && group.All == all {
+		return all
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+// Copyright 2026 The xgen Authors. All rights reserved. Use of this source
+// code is governed by a BSD-style license that can be found in the LICENSE
+// file.
+//
+// Package xgen written in pure Go providing a set of functions that allow you
+// to parse XSD (XML schema files). This library needs Go version 1.10 or
+// later.
+
+package xgen
+
+import (
+	"encoding/xml"
+	"strconv"
+)
+
+// OnAny handles parsing event on the any start elements. The any element
+// enables the author to extend the XML document with elements not specified
+// by the schema.
+func (opt *Options) OnAny(ele xml.StartElement, protoTree []Component) (err error) {
+	wildcard := newWildcard(ele)
+	for _, attr := range ele.Attr {
+		if attr.Name.Local == "maxOccurs" {
+			var maxOccurs int
+			if maxOccurs, err = strconv.Atoi(attr.Value); attr.Value != "unbounded" && err != nil {
+				return
+			}
+			if attr.Value == "unbounded" || maxOccurs > 1 {
+				wildcard.Plural, err = true, nil
+			}
+		}
+		if attr.Name.Local == "minOccurs" {
+			var minOccurs int
+			if minOccurs, err = strconv.Atoi(attr.Value); err != nil {
+				return
+			}
+			if minOccurs == 0 {
+				wildcard.Optional = true
+			}
+		}
+	}
+	if len(opt.InPluralSequence) > 0 && opt.InPluralSequence[len(opt.InPluralSequence)-1] {
+		wildcard.Plural = true
+	}
+	if opt.Choice.Len() > 0 {
+		var choice *Choice
+		if choice, err = peekAs[*Choice](opt.Choice, "choice"); err != nil {
+			return
+		}
+		wildcard.Optional = true
+		wildcard.Plural = wildcard.Plural || choice.Plural
+	}
+	if opt.ComplexType.Len() > 0 {
+		var complexType *ComplexType
+		if complexType, err = peekAs[*ComplexType](opt.ComplexType, "complexType"); err != nil {
+			return
+		}
+		complexType.Any = append(complexType.Any, wildcard)
+		return
+	}
+	if opt.InGroup > 0 && opt.Group.Len() > 0 {
+		var group *Group
+		if group, err = peekAs[*Group](opt.Group, "group"); err != nil {
+			return
+		}
+		group.Any = append(group.Any, wildcard)
+	}
+	return
+}
+
+// newWildcard creates the wildcard by given any or anyAttribute element with
+// the default namespace and process contents constraints applied.
+func newWildcard(ele xml.StartElement) Wildcard {
+	wildcard := Wildcard{Namespace: "##any", ProcessContents: "strict"}
+	for _, attr := range ele.Attr {
+		if attr.Name.Local == "namespace" {
+			wildcard.Namespace = attr.Value
+		}
+		if attr.Name.Local == "processContents" {
+			wildcard.ProcessContents = attr.Value
+		}
+	}
+	return wildcard
+}
@@ -0,0 +1,35 @@
+// Copyright 2026 The xgen Authors. All rights reserved. Use of this source
+// code is governed by a BSD-style license that can be found in the LICENSE
+// file.
+//
+// Package xgen written in pure Go providing a set of functions that allow you
+// to parse XSD (XML schema files). This library needs Go version 1.10 or
+// later.
+
+package xgen
+
+import "encoding/xml"
+
+// OnAnyAttribute handles parsing event on the anyAttribute start elements.
+// The anyAttribute element enables the author to extend the XML document with
+// attributes not specified by the schema.
+func (opt *Options) OnAnyAttribute(ele xml.StartElement, protoTree []Component) (err error) {
+	wildcard := newWildcard(ele)
+	wildcard.Optional = true
+	if opt.AttributeGroup.Len() > 0 {
+		var attributeGroup *AttributeGroup
+		if attributeGroup, err = peekAs[*AttributeGroup](opt.AttributeGroup, "attributeGroup"); err != nil {
+			return
+		}
+		attributeGroup.AnyAttribute = &wildcard
+		return
+	}
+	if opt.ComplexType.Len() > 0 {
+		var complexType *ComplexType
+		if complexType, err = peekAs[*ComplexType](opt.ComplexType, "complexType"); err != nil {
+			return
+		}
+		complexType.AnyAttribute = &wildcard
+	}
+	return
+}
@@ -0,0 +1,27 @@
+// Copyright 2026 The xgen Authors. All rights reserved. Use of this source
+// code is governed by a BSD-style license that can be found in the LICENSE
+// file.
+//
+// Package xgen written in pure Go providing a set of functions that allow you
+// to parse XSD (XML schema files). This library needs Go version 1.10 or
+// later.
+
+package xgen
+
+import "encoding/xml"
+
+// OnAssert handles parsing event on the assert start elements. The assert
+// element of XSD 1.1 constrains the content of a complex type by an XPath
+// expression, which is compiled by the code generators checking it.
+func (opt *Options) OnAssert(ele xml.StartElement, protoTree []Component) (err error) {
+	var complexType *ComplexType
+	if complexType, err = peekAs[*ComplexType](opt.ComplexType, "complexType"); err != nil {
+		return
+	}
+	for _, attr := range ele.Attr {
+		if attr.Name.Local == "test" {
+			complexType.Assertions = append(complexType.Assertions, Assertion{Test: attr.Value})
+		}
+	}
+	return
+}
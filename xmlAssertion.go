@@ -0,0 +1,27 @@
+// Copyright 2026 The xgen Authors. All rights reserved. Use of this source
+// code is governed by a BSD-style license that can be found in the LICENSE
+// file.
+//
+// Package xgen written in pure Go providing a set of functions that allow you
+// to parse XSD (XML schema files). This library needs Go version 1.10 or
+// later.
+
+package xgen
+
+import "encoding/xml"
+
+// OnAssertion handles parsing event on the assertion start elements. The
+// assertion facet of XSD 1.1 constrains the value of a simple type, which is
+// referenced as $value in the XPath expression.
+func (opt *Options) OnAssertion(ele xml.StartElement, protoTree []Component) (err error) {
+	var st *SimpleType
+	if st, err = peekAs[*SimpleType](opt.SimpleType, "simpleType"); err != nil {
+		return
+	}
+	for _, attr := range ele.Attr {
+		if attr.Name.Local == "test" {
+			st.Restriction.Assertions = append(st.Restriction.Assertions, Assertion{Test: attr.Value})
+		}
+	}
+	return
+}
@@ -12,12 +12,14 @@ import "encoding/xml"
 
 // OnAttribute handles parsing event on the attribute start elements. All
 // attributes are declared as simple types.
-func (opt *Options) OnAttribute(ele xml.StartElement, protoTree []interface{}) (err error) {
+func (opt *Options) OnAttribute(ele xml.StartElement, protoTree []Component) (err error) {
 	attribute := Attribute{
 		Optional: true,
 	}
+	var ref, form string
 	for _, attr := range ele.Attr {
 		if attr.Name.Local == "ref" {
+			ref = attr.Value
 			attribute.Name = attr.Value
 			attribute.TypeRef = attr.Value
 			attribute.Type, err = opt.GetValueType(attr.Value, protoTree)
@@ -39,27 +41,44 @@ func (opt *Options) OnAttribute(ele xml.StartElement, protoTree []interface{}) (
 			if attr.Value == "required" {
 				attribute.Optional = false
 			}
+			if attr.Value == "prohibited" {
+				attribute.Prohibited = true
+			}
+		}
+		if attr.Name.Local == "form" {
+			form = attr.Value
 		}
 	}
+	attribute.Namespace = opt.declarationNamespace(ref, form, opt.attributeFormDefault, opt.ComplexType.Len() == 0 && opt.AttributeGroup.Len() == 0)
 	opt.Attribute.Push(&attribute)
 	return
 }
 
 // EndAttribute handles parsing event on the attribute end elements.
-func (opt *Options) EndAttribute(ele xml.EndElement, protoTree []interface{}) (err error) {
+func (opt *Options) EndAttribute(ele xml.EndElement, protoTree []Component) (err error) {
 	if opt.Attribute.Len() == 0 {
 		return
 	}
+	var attribute *Attribute
+	if attribute, err = popAs[*Attribute](opt.Attribute, "attribute"); err != nil {
+		return
+	}
 	if opt.AttributeGroup.Len() > 0 {
-		opt.AttributeGroup.Peek().(*AttributeGroup).Attributes = append(opt.AttributeGroup.Peek().(*AttributeGroup).Attributes, *opt.Attribute.Pop().(*Attribute))
+		var attributeGroup *AttributeGroup
+		if attributeGroup, err = peekAs[*AttributeGroup](opt.AttributeGroup, "attributeGroup"); err != nil {
+			return
+		}
+		attributeGroup.Attributes = append(attributeGroup.Attributes, *attribute)
 		return
 	}
 	if opt.ComplexType.Len() > 0 {
-		opt.ComplexType.Peek().(*ComplexType).Attributes = append(opt.ComplexType.Peek().(*ComplexType).Attributes, *opt.Attribute.Pop().(*Attribute))
+		var complexType *ComplexType
+		if complexType, err = peekAs[*ComplexType](opt.ComplexType, "complexType"); err != nil {
+			return
+		}
+		complexType.Attributes = append(complexType.Attributes, *attribute)
 		return
 	}
-	if opt.ComplexType.Len() == 0 {
-		opt.ProtoTree = append(opt.ProtoTree, opt.Attribute.Pop())
-	}
+	opt.ProtoTree = append(opt.ProtoTree, attribute)
 	return
 }
@@ -14,8 +14,8 @@ import "encoding/xml"
 // elements. The attributeGroup element is used to group a set of attribute
 // declarations so that they can be incorporated as a group into complex type
 // definitions.
-func (opt *Options) OnAttributeGroup(ele xml.StartElement, protoTree []interface{}) (err error) {
-	attributeGroup := AttributeGroup{}
+func (opt *Options) OnAttributeGroup(ele xml.StartElement, protoTree []Component) (err error) {
+	attributeGroup := AttributeGroup{Namespace: opt.targetNamespace}
 	for _, attr := range ele.Attr {
 		if attr.Name.Local == "name" {
 			attributeGroup.Name = attr.Value
@@ -35,17 +35,22 @@ func (opt *Options) OnAttributeGroup(ele xml.StartElement, protoTree []interface
 		return
 	}
 
-	if opt.ComplexType.Len() > 0 {
-		opt.ComplexType.Peek().(*ComplexType).AttributeGroup = append(opt.ComplexType.Peek().(*ComplexType).AttributeGroup, attributeGroup)
+	var complexType *ComplexType
+	if complexType, err = peekAs[*ComplexType](opt.ComplexType, "complexType"); err != nil {
 		return
 	}
+	complexType.AttributeGroup = append(complexType.AttributeGroup, attributeGroup)
 	return
 }
 
 // EndAttributeGroup handles parsing event on the attributeGroup end elements.
-func (opt *Options) EndAttributeGroup(ele xml.EndElement, protoTree []interface{}) (err error) {
+func (opt *Options) EndAttributeGroup(ele xml.EndElement, protoTree []Component) (err error) {
 	if opt.AttributeGroup.Len() > 0 {
-		opt.ProtoTree = append(opt.ProtoTree, opt.AttributeGroup.Pop())
+		var attributeGroup *AttributeGroup
+		if attributeGroup, err = popAs[*AttributeGroup](opt.AttributeGroup, "attributeGroup"); err != nil {
+			return
+		}
+		opt.ProtoTree = append(opt.ProtoTree, attributeGroup)
 		opt.CurrentEle = ""
 		opt.InAttributeGroup = false
 	}
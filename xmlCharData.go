@@ -13,46 +13,55 @@ import "strings"
 // OnCharData handles parsing event on the documentation start elements. The
 // documentation element specifies information to be read or used by users
 // within an annotation element.
-func (opt *Options) OnCharData(ele string, protoTree []interface{}) (err error) {
+func (opt *Options) OnCharData(ele string, protoTree []Component) (err error) {
 	if strings.TrimSpace(ele) == "" {
 		return
 	}
 	ele = strings.TrimSpace(ele)
-	if opt.InAttributeGroup {
-		if opt.AttributeGroup.Peek() != nil {
-			opt.AttributeGroup.Peek().(*AttributeGroup).Doc = ele
+	if opt.InAttributeGroup && opt.AttributeGroup.Len() > 0 {
+		var attributeGroup *AttributeGroup
+		if attributeGroup, err = peekAs[*AttributeGroup](opt.AttributeGroup, "attributeGroup"); err != nil {
 			return
 		}
+		attributeGroup.Doc = ele
+		return
 	}
-	if opt.InElement != "" {
-		if opt.Element.Peek() != nil {
-			opt.Element.Peek().(*Element).Doc = ele
+	if opt.InElement != "" && opt.Element.Len() > 0 {
+		var element *Element
+		if element, err = peekAs[*Element](opt.Element, "element"); err != nil {
 			return
 		}
+		element.Doc = ele
+		return
 	}
 	if opt.Attribute.Len() > 0 {
-		opt.Attribute.Peek().(*Attribute).Doc = ele
+		var attribute *Attribute
+		if attribute, err = peekAs[*Attribute](opt.Attribute, "attribute"); err != nil {
+			return
+		}
+		attribute.Doc = ele
 		return
 	}
 	switch opt.CurrentEle {
 	case "simpleType":
-		if opt.SimpleType.Peek() != nil {
-			opt.SimpleType.Peek().(*SimpleType).Doc = ele
-			return
+		if opt.SimpleType.Len() > 0 {
+			var st *SimpleType
+			if st, err = peekAs[*SimpleType](opt.SimpleType, "simpleType"); err != nil {
+				return
+			}
+			st.Doc = ele
 		}
 	case "complexType":
-		if opt.Attribute.Len() > 0 {
-			opt.Attribute.Peek().(*Attribute).Doc = ele
-			return
-		}
-		if opt.ComplexType.Peek() != nil {
-			l := len(opt.ComplexType.Peek().(*ComplexType).Attributes)
-			if l > 0 {
-				opt.ComplexType.Peek().(*ComplexType).Attributes[l-1].Doc = ele
+		if opt.ComplexType.Len() > 0 {
+			var complexType *ComplexType
+			if complexType, err = peekAs[*ComplexType](opt.ComplexType, "complexType"); err != nil {
 				return
 			}
-			opt.ComplexType.Peek().(*ComplexType).Doc = ele
-			return
+			if l := len(complexType.Attributes); l > 0 {
+				complexType.Attributes[l-1].Doc = ele
+				return
+			}
+			complexType.Doc = ele
 		}
 	default:
 	}
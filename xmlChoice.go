@@ -16,7 +16,7 @@ import (
 // OnChoice handles parsing event on the choice start elements. The
 // choice element defines that one and only one of the contained element can be present within
 // the contained element.
-func (opt *Options) OnChoice(ele xml.StartElement, protoTree []interface{}) (err error) {
+func (opt *Options) OnChoice(ele xml.StartElement, protoTree []Component) (err error) {
 	choice := Choice{}
 	for _, attr := range ele.Attr {
 		if attr.Name.Local == "maxOccurs" {
@@ -33,7 +33,11 @@ func (opt *Options) OnChoice(ele xml.StartElement, protoTree []interface{}) (err
 	}
 	// Handle a case of a parent choice having plurality that children should inherit
 	if opt.Choice.Len() > 0 {
-		choice.Plural = choice.Plural || opt.Choice.Peek().(*Choice).Plural
+		var parent *Choice
+		if parent, err = peekAs[*Choice](opt.Choice, "choice"); err != nil {
+			return
+		}
+		choice.Plural = choice.Plural || parent.Plural
 	}
 
 	opt.Choice.Push(&choice)
@@ -42,8 +46,7 @@ func (opt *Options) OnChoice(ele xml.StartElement, protoTree []interface{}) (err
 }
 
 // EndChoice handles parsing event on the choice end elements.
-func (opt *Options) EndChoice(ele xml.EndElement, protoTree []interface{}) (err error) {
-	opt.Choice.Pop()
-
+func (opt *Options) EndChoice(ele xml.EndElement, protoTree []Component) (err error) {
+	_, err = popAs[*Choice](opt.Choice, "choice")
 	return
 }
@@ -0,0 +1,28 @@
+// Copyright 2026 The xgen Authors. All rights reserved. Use of this source
+// code is governed by a BSD-style license that can be found in the LICENSE
+// file.
+//
+// Package xgen written in pure Go providing a set of functions that allow you
+// to parse XSD (XML schema files). This library needs Go version 1.10 or
+// later.
+
+package xgen
+
+import "encoding/xml"
+
+// OnComplexContent handles parsing event on the complexContent start
+// elements. The complexContent element defines extensions or restrictions on
+// a complex type that contains mixed content or elements only.
+func (opt *Options) OnComplexContent(ele xml.StartElement, protoTree []Component) (err error) {
+	var complexType *ComplexType
+	if complexType, err = peekAs[*ComplexType](opt.ComplexType, "complexType"); err != nil {
+		return
+	}
+	complexType.Content = "complexContent"
+	for _, attr := range ele.Attr {
+		if attr.Name.Local == "mixed" {
+			complexType.Mixed = attr.Value == "true" || attr.Value == "1"
+		}
+	}
+	return
+}
@@ -8,21 +8,32 @@
 
 package xgen
 
-import "encoding/xml"
+import (
+	"encoding/xml"
+	"fmt"
+)
 
 // OnComplexType handles parsing event on the complex start elements. A
 // complex element contains other elements and/or attributes.
-func (opt *Options) OnComplexType(ele xml.StartElement, protoTree []interface{}) (err error) {
+func (opt *Options) OnComplexType(ele xml.StartElement, protoTree []Component) (err error) {
 	if opt.ComplexType.Len() > 0 {
-		e := opt.Element.Pop().(*Element)
-		opt.ComplexType.Push(&ComplexType{
-			Doc:  e.Doc,
-			Name: e.Name,
-		})
+		var e *Element
+		if e, err = popAs[*Element](opt.Element, "element"); err != nil {
+			return
+		}
+		c := &ComplexType{
+			Doc:       e.Doc,
+			Name:      e.Name,
+			Namespace: opt.targetNamespace,
+		}
+		if scope := opt.scope(); scope != nil {
+			scope.complexType = c
+		}
+		opt.ComplexType.Push(c)
 	}
 
 	if opt.ComplexType.Len() == 0 {
-		c := ComplexType{}
+		c := ComplexType{Namespace: opt.targetNamespace}
 		opt.CurrentEle = opt.InElement
 		for _, attr := range ele.Attr {
 			if attr.Name.Local == "name" {
@@ -30,10 +41,17 @@ func (opt *Options) OnComplexType(ele xml.StartElement, protoTree []interface{})
 			}
 		}
 		if opt.Element.Len() > 0 {
-			e := opt.Element.Pop().(*Element)
+			var e *Element
+			if e, err = popAs[*Element](opt.Element, "element"); err != nil {
+				return
+			}
 			c.Doc = e.Doc
 			if c.Name == "" {
 				c.Name = e.Name
+				c.Global = opt.InGroup == 0
+				if scope := opt.scope(); scope != nil {
+					scope.complexType = &c
+				}
 			}
 		}
 		opt.ComplexType.Push(&c)
@@ -42,8 +60,15 @@ func (opt *Options) OnComplexType(ele xml.StartElement, protoTree []interface{})
 }
 
 // EndComplexType handles parsing event on the complex end elements.
-func (opt *Options) EndComplexType(ele xml.EndElement, protoTree []interface{}) (err error) {
-	opt.ProtoTree = append(opt.ProtoTree, opt.ComplexType.Pop())
+func (opt *Options) EndComplexType(ele xml.EndElement, protoTree []Component) (err error) {
+	var complexType *ComplexType
+	if complexType, err = popAs[*ComplexType](opt.ComplexType, "complexType"); err != nil {
+		return
+	}
+	if complexType.Name == "" {
+		return fmt.Errorf("missing the name of %s", ele.Name.Local)
+	}
+	opt.ProtoTree = append(opt.ProtoTree, complexType)
 	opt.CurrentEle = ""
 	return
 }
@@ -14,10 +14,12 @@ import (
 )
 
 // OnElement handles parsing event on the element start elements.
-func (opt *Options) OnElement(ele xml.StartElement, protoTree []interface{}) (err error) {
+func (opt *Options) OnElement(ele xml.StartElement, protoTree []Component) (err error) {
 	e := Element{}
+	var ref, form string
 	for _, attr := range ele.Attr {
 		if attr.Name.Local == "ref" {
+			ref = attr.Value
 			e.Name = attr.Value
 			e.TypeRef = attr.Value
 			e.Type, err = opt.GetValueType(attr.Value, protoTree)
@@ -50,6 +52,18 @@ func (opt *Options) OnElement(ele xml.StartElement, protoTree []interface{}) (er
 				e.Plural = true
 			}
 		}
+		if attr.Name.Local == "abstract" {
+			e.Abstract = attr.Value == "true" || attr.Value == "1"
+		}
+		if attr.Name.Local == "substitutionGroup" {
+			e.SubstitutionGroup = QName{Space: opt.parseNS(attr.Value), Local: trimNSPrefix(attr.Value)}
+		}
+		if attr.Name.Local == "nillable" {
+			e.Nillable = attr.Value == "true" || attr.Value == "1"
+		}
+		if attr.Name.Local == "form" {
+			form = attr.Value
+		}
 		if attr.Name.Local == "minOccurs" {
 			var minOccurs int
 			if minOccurs, err = strconv.Atoi(attr.Value); err != nil {
@@ -61,6 +75,8 @@ func (opt *Options) OnElement(ele xml.StartElement, protoTree []interface{}) (er
 		}
 	}
 
+	e.Namespace = opt.declarationNamespace(ref, form, opt.elementFormDefault, opt.ComplexType.Len() == 0 && opt.InGroup == 0)
+
 	if len(opt.InPluralSequence) > 0 && opt.InPluralSequence[len(opt.InPluralSequence)-1] {
 		e.Plural = true
 	}
@@ -74,14 +90,29 @@ func (opt *Options) OnElement(ele xml.StartElement, protoTree []interface{}) (er
 		alreadyPushedElement = true
 		opt.Element.Push(&e)
 	}
+	scope := &elementScope{element: &e}
+	opt.scopes = append(opt.scopes, scope)
 
 	if opt.Choice.Len() > 0 {
+		var choice *Choice
+		if choice, err = peekAs[*Choice](opt.Choice, "choice"); err != nil {
+			return
+		}
 		e.Optional = true
-		e.Plural = e.Plural || opt.Choice.Peek().(*Choice).Plural
+		e.Plural = e.Plural || choice.Plural
+	}
+
+	if all := opt.currentAll(); all != nil {
+		all.Elements = append(all.Elements, e.Name)
+		e.Optional = e.Optional || all.Optional
 	}
 
 	if opt.ComplexType.Len() > 0 {
-		element, i := findElement(&e, opt.ComplexType.Peek().(*ComplexType).Elements)
+		var complexType *ComplexType
+		if complexType, err = peekAs[*ComplexType](opt.ComplexType, "complexType"); err != nil {
+			return
+		}
+		element, i := findElement(&e, complexType.Elements)
 		// Handle a case where two elements with the same name and type are present in the same complex type
 		// This can happen with a Choice that includes a definition for a single value of a type along with
 		// an alternative that is an array of the same type with the same name. This tends to happen for backward
@@ -89,13 +120,14 @@ func (opt *Options) OnElement(ele xml.StartElement, protoTree []interface{}) (er
 		// In this situation, the version of the element that's preserved is the one with the highest plurality
 		// since generated code for an array of a type should be compatible to unmarshal/marshal arrays of a single
 		// element
+		scope.elements = &complexType.Elements
 		if element != nil && element.Type == e.Type {
 			element.Plural = element.Plural || e.Plural
-			opt.ComplexType.Peek().(*ComplexType).Elements[i] = *element
+			complexType.Elements[i] = *element
 			// Push a copy onto the element stack so inline restrictions can update type and be reflected later
 			opt.Element.Push(&e)
 		} else {
-			opt.ComplexType.Peek().(*ComplexType).Elements = append(opt.ComplexType.Peek().(*ComplexType).Elements, e)
+			complexType.Elements = append(complexType.Elements, e)
 			// Push a copy onto the element stack so inline restrictions can update type and be reflected later
 			opt.Element.Push(&e)
 		}
@@ -104,7 +136,12 @@ func (opt *Options) OnElement(ele xml.StartElement, protoTree []interface{}) (er
 
 	if opt.InGroup > 0 {
 		if opt.Group.Len() > 0 {
-			opt.Group.Peek().(*Group).Elements = append(opt.Group.Peek().(*Group).Elements, e)
+			var group *Group
+			if group, err = peekAs[*Group](opt.Group, "group"); err != nil {
+				return
+			}
+			group.Elements = append(group.Elements, e)
+			scope.elements = &group.Elements
 		}
 		return
 	}
@@ -116,20 +153,41 @@ func (opt *Options) OnElement(ele xml.StartElement, protoTree []interface{}) (er
 }
 
 // EndElement handles parsing event on the element end elements.
-func (opt *Options) EndElement(ele xml.EndElement, protoTree []interface{}) (err error) {
+func (opt *Options) EndElement(ele xml.EndElement, protoTree []Component) (err error) {
+	if len(opt.scopes) > 0 {
+		opt.scopes = opt.scopes[:len(opt.scopes)-1]
+	}
 	if opt.Element.Len() == 0 {
 		return
 	}
 
-	if opt.ComplexType.Len() > 0 {
-		opt.Element.Pop()
-	} else {
-		opt.ProtoTree = append(opt.ProtoTree, opt.Element.Pop())
+	var element *Element
+	if element, err = popAs[*Element](opt.Element, "element"); err != nil {
+		return
+	}
+	if opt.ComplexType.Len() == 0 {
+		opt.ProtoTree = append(opt.ProtoTree, element)
 	}
-
 	return
 }
 
+// elementScope is an element declaration being parsed, which declares the
+// identity constraints in it.
+type elementScope struct {
+	element     *Element     // the element on the element stack
+	elements    *[]Element   // the elements of the complex type or group holding a copy of the local element
+	complexType *ComplexType // the anonymous complex type of the element
+}
+
+// scope returns the innermost element declaration being parsed, or nil if
+// there is none.
+func (opt *Options) scope() *elementScope {
+	if len(opt.scopes) == 0 {
+		return nil
+	}
+	return opt.scopes[len(opt.scopes)-1]
+}
+
 func findElement(element *Element, elements []Element) (existing *Element, index int) {
 	for i, ele := range elements {
 		if element.Name == ele.Name {
@@ -11,12 +11,14 @@ package xgen
 import "encoding/xml"
 
 // OnEnumeration handles parsing event on the enumeration start elements.
-func (opt *Options) OnEnumeration(ele xml.StartElement, protoTree []interface{}) (err error) {
+func (opt *Options) OnEnumeration(ele xml.StartElement, protoTree []Component) (err error) {
+	var st *SimpleType
+	if st, err = peekAs[*SimpleType](opt.SimpleType, "simpleType"); err != nil {
+		return
+	}
 	for _, attr := range ele.Attr {
 		if attr.Name.Local == "value" {
-			if opt.SimpleType.Peek() != nil {
-				opt.SimpleType.Peek().(*SimpleType).Restriction.Enum = append(opt.SimpleType.Peek().(*SimpleType).Restriction.Enum, attr.Value)
-			}
+			st.Restriction.Enum = append(st.Restriction.Enum, attr.Value)
 		}
 	}
 	return nil
@@ -24,15 +26,30 @@ func (opt *Options) OnEnumeration(ele xml.StartElement, protoTree []interface{})
 
 // EndEnumeration handles parsing event on the enumeration end elements.
 // Enumeration defines a list of acceptable values.
-func (opt *Options) EndEnumeration(ele xml.EndElement, protoTree []interface{}) (err error) {
-	if opt.Attribute.Len() > 0 && opt.SimpleType.Peek() != nil {
-		if opt.Attribute.Peek().(*Attribute).Type, err = opt.GetValueType(opt.SimpleType.Peek().(*SimpleType).Base, opt.ProtoTree); err != nil {
+func (opt *Options) EndEnumeration(ele xml.EndElement, protoTree []Component) (err error) {
+	if opt.SimpleType.Len() == 0 {
+		return
+	}
+	var st *SimpleType
+	if st, err = peekAs[*SimpleType](opt.SimpleType, "simpleType"); err != nil {
+		return
+	}
+	if opt.Attribute.Len() > 0 {
+		var attribute *Attribute
+		if attribute, err = peekAs[*Attribute](opt.Attribute, "attribute"); err != nil {
+			return
+		}
+		if attribute.Type, err = opt.GetValueType(st.Base, opt.ProtoTree); err != nil {
 			return
 		}
 		opt.CurrentEle = ""
 	}
-	if opt.SimpleType.Len() > 0 && opt.Element.Len() > 0 {
-		if opt.Element.Peek().(*Element).Type, err = opt.GetValueType(opt.SimpleType.Peek().(*SimpleType).Base, opt.ProtoTree); err != nil {
+	if opt.Element.Len() > 0 {
+		var element *Element
+		if element, err = peekAs[*Element](opt.Element, "element"); err != nil {
+			return
+		}
+		if element.Type, err = opt.GetValueType(st.Base, opt.ProtoTree); err != nil {
 			return
 		}
 		opt.CurrentEle = ""
@@ -12,7 +12,7 @@ import "encoding/xml"
 
 // OnExtension handles parsing event on the extension start elements. The
 // extension element defines a base class for a complexType or simpleContent.
-func (opt *Options) OnExtension(ele xml.StartElement, protoTree []interface{}) (err error) {
+func (opt *Options) OnExtension(ele xml.StartElement, protoTree []Component) (err error) {
 	for _, attr := range ele.Attr {
 		if attr.Name.Local == "base" {
 			var valueType string
@@ -20,15 +20,19 @@ func (opt *Options) OnExtension(ele xml.StartElement, protoTree []interface{}) (
 			if err != nil {
 				return
 			}
-			if opt.ComplexType.Peek() != nil {
-				complexType := opt.ComplexType.Peek().(*ComplexType)
-				complexType.Base, err = opt.GetValueType(valueType, protoTree)
-				if err != nil {
-					return
-				}
-				if complexType.Name == "" {
-					complexType.Name = attr.Value
-				}
+			var complexType *ComplexType
+			if complexType, err = peekAs[*ComplexType](opt.ComplexType, "complexType"); err != nil {
+				return
+			}
+			if complexType.Content != "" && complexType.Derivation == "" {
+				complexType.Derivation = "extension"
+			}
+			complexType.Base, err = opt.GetValueType(valueType, protoTree)
+			if err != nil {
+				return
+			}
+			if complexType.Name == "" {
+				complexType.Name = attr.Value
 			}
 		}
 	}
@@ -36,10 +40,17 @@ func (opt *Options) OnExtension(ele xml.StartElement, protoTree []interface{}) (
 }
 
 // EndExtension handles parsing event on the extension end elements.
-func (opt *Options) EndExtension(ele xml.EndElement, protoTree []interface{}) (err error) {
-	if opt.Attribute.Len() > 0 && opt.SimpleType.Peek() != nil {
-		opt.Attribute.Peek().(*Attribute).Type, err = opt.GetValueType(opt.SimpleType.Pop().(*SimpleType).Base, opt.ProtoTree)
-		if err != nil {
+func (opt *Options) EndExtension(ele xml.EndElement, protoTree []Component) (err error) {
+	if opt.Attribute.Len() > 0 && opt.SimpleType.Len() > 0 {
+		var attribute *Attribute
+		if attribute, err = peekAs[*Attribute](opt.Attribute, "attribute"); err != nil {
+			return
+		}
+		var st *SimpleType
+		if st, err = popAs[*SimpleType](opt.SimpleType, "simpleType"); err != nil {
+			return
+		}
+		if attribute.Type, err = opt.GetValueType(st.Base, opt.ProtoTree); err != nil {
 			return
 		}
 		opt.CurrentEle = ""
@@ -14,9 +14,17 @@ import "encoding/xml"
 // Enumeration Defines a list of acceptable values. FractionDigits specifies
 // the maximum number of decimal places allowed. Must be equal to or greater
 // than zero.
-func (opt *Options) EndFractionDigits(ele xml.EndElement, protoTree []interface{}) (err error) {
+func (opt *Options) EndFractionDigits(ele xml.EndElement, protoTree []Component) (err error) {
 	if opt.SimpleType.Len() > 0 && opt.Element.Len() > 0 {
-		if opt.Element.Peek().(*Element).Type, err = opt.GetValueType(opt.SimpleType.Pop().(*SimpleType).Base, opt.ProtoTree); err != nil {
+		var element *Element
+		if element, err = peekAs[*Element](opt.Element, "element"); err != nil {
+			return
+		}
+		var st *SimpleType
+		if st, err = popAs[*SimpleType](opt.SimpleType, "simpleType"); err != nil {
+			return
+		}
+		if element.Type, err = opt.GetValueType(st.Base, opt.ProtoTree); err != nil {
 			return
 		}
 		opt.CurrentEle = ""
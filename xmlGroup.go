@@ -13,8 +13,8 @@ import "encoding/xml"
 // OnGroup handles parsing event on the group start elements. The group
 // element is used to define a group of elements to be used in complex type
 // definitions.
-func (opt *Options) OnGroup(ele xml.StartElement, protoTree []interface{}) (err error) {
-	group := Group{}
+func (opt *Options) OnGroup(ele xml.StartElement, protoTree []Component) (err error) {
+	group := Group{Namespace: opt.targetNamespace}
 	for _, attr := range ele.Attr {
 		if attr.Name.Local == "name" {
 			group.Name = attr.Value
@@ -33,7 +33,11 @@ func (opt *Options) OnGroup(ele xml.StartElement, protoTree []interface{}) (err
 		}
 	}
 	if opt.Choice.Len() > 0 {
-		group.Plural = group.Plural || opt.Choice.Peek().(*Choice).Plural
+		var choice *Choice
+		if choice, err = peekAs[*Choice](opt.Choice, "choice"); err != nil {
+			return
+		}
+		group.Plural = group.Plural || choice.Plural
 	}
 
 	if opt.ComplexType.Len() == 0 {
@@ -44,25 +48,34 @@ func (opt *Options) OnGroup(ele xml.StartElement, protoTree []interface{}) (err
 			return
 		}
 		if opt.InGroup > 0 {
+			var parent *Group
+			if parent, err = peekAs[*Group](opt.Group, "group"); err != nil {
+				return
+			}
 			opt.InGroup++
-			opt.Group.Peek().(*Group).Groups = append(opt.Group.Peek().(*Group).Groups, group)
+			parent.Groups = append(parent.Groups, group)
 			return
 		}
 
 	}
-	if opt.ComplexType.Len() > 0 {
-		if !inGroups(&group, opt.ComplexType.Peek().(*ComplexType).Groups) {
-			opt.ComplexType.Peek().(*ComplexType).Groups = append(opt.ComplexType.Peek().(*ComplexType).Groups, group)
-		}
+	var complexType *ComplexType
+	if complexType, err = peekAs[*ComplexType](opt.ComplexType, "complexType"); err != nil {
 		return
 	}
+	if !inGroups(&group, complexType.Groups) {
+		complexType.Groups = append(complexType.Groups, group)
+	}
 	return
 }
 
 // EndGroup handles parsing event on the group end elements.
-func (opt *Options) EndGroup(ele xml.EndElement, protoTree []interface{}) (err error) {
+func (opt *Options) EndGroup(ele xml.EndElement, protoTree []Component) (err error) {
 	if ele.Name.Local == opt.CurrentEle && opt.InGroup == 1 {
-		opt.ProtoTree = append(opt.ProtoTree, opt.Group.Pop())
+		var group *Group
+		if group, err = popAs[*Group](opt.Group, "group"); err != nil {
+			return
+		}
+		opt.ProtoTree = append(opt.ProtoTree, group)
 		opt.CurrentEle = ""
 		opt.InGroup--
 	}
@@ -13,13 +13,21 @@ import "encoding/xml"
 // OnInclude handles parsing event on the include start elements. The list
 // element defines a simple type element as a list of values of a specified
 // data type.
-func (opt *Options) OnInclude(ele xml.StartElement, protoTree []interface{}) (err error) {
+func (opt *Options) OnInclude(ele xml.StartElement, protoTree []Component) (err error) {
 	for _, ele := range ele.Attr {
 		if ele.Name.Local == "schemaLocation" {
-			if _, ok := opt.IncludeMap[ele.Value]; ok {
+			location := opt.absoluteLocation(ele.Value)
+			if _, ok := opt.IncludeMap[location]; ok {
 				continue
 			}
-			opt.IncludeMap[ele.Value] = true
+			opt.IncludeMap[location] = true
+			// Fetch the remote schema into the cache, so that it's available
+			// in the offline mode
+			if isValidURL(location) {
+				if _, err = opt.schemaFile(location, ""); err != nil {
+					return
+				}
+			}
 		}
 	}
 	return
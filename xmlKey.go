@@ -0,0 +1,62 @@
+// Copyright 2026 The xgen Authors. All rights reserved. Use of this source
+// code is governed by a BSD-style license that can be found in the LICENSE
+// file.
+//
+// Package xgen written in pure Go providing a set of functions that allow you
+// to parse XSD (XML schema files). This library needs Go version 1.10 or
+// later.
+
+package xgen
+
+import "encoding/xml"
+
+// OnKey handles parsing event on the key start elements. The key element
+// specifies that the values of the fields of the selected nodes must be
+// present and unique within the declaring element.
+func (opt *Options) OnKey(ele xml.StartElement, protoTree []Component) (err error) {
+	opt.onIdentityConstraint(ele, "key")
+	return
+}
+
+// EndKey handles parsing event on the key end elements.
+func (opt *Options) EndKey(ele xml.EndElement, protoTree []Component) (err error) {
+	opt.endIdentityConstraint()
+	return
+}
+
+// onIdentityConstraint starts the identity constraint of given category.
+func (opt *Options) onIdentityConstraint(ele xml.StartElement, category string) {
+	constraint := IdentityConstraint{Category: category}
+	for _, attr := range ele.Attr {
+		if attr.Name.Local == "name" {
+			constraint.Name = attr.Value
+		}
+		if attr.Name.Local == "refer" {
+			constraint.Refer = attr.Value
+		}
+	}
+	opt.IdentityConstraint = &constraint
+}
+
+// endIdentityConstraint records the identity constraint being parsed on the
+// enclosing element declaration, or on its anonymous complex type, which is
+// generated in place of the element.
+func (opt *Options) endIdentityConstraint() {
+	constraint, scope := opt.IdentityConstraint, opt.scope()
+	opt.IdentityConstraint = nil
+	if constraint == nil || scope == nil {
+		return
+	}
+	if scope.complexType != nil {
+		scope.complexType.IdentityConstraints = append(scope.complexType.IdentityConstraints, *constraint)
+		return
+	}
+	e := scope.element
+	e.IdentityConstraints = append(e.IdentityConstraints, *constraint)
+	// Local elements are also held by value in their complex type or group
+	if scope.elements != nil {
+		if _, i := findElement(e, *scope.elements); i >= 0 {
+			(*scope.elements)[i].IdentityConstraints = e.IdentityConstraints
+		}
+	}
+}
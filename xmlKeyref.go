@@ -0,0 +1,25 @@
+// Copyright 2026 The xgen Authors. All rights reserved. Use of this source
+// code is governed by a BSD-style license that can be found in the LICENSE
+// file.
+//
+// Package xgen written in pure Go providing a set of functions that allow you
+// to parse XSD (XML schema files). This library needs Go version 1.10 or
+// later.
+
+package xgen
+
+import "encoding/xml"
+
+// OnKeyref handles parsing event on the keyref start elements. The keyref
+// element specifies that the values of the fields of the selected nodes must
+// match the values of the referenced key or unique constraint.
+func (opt *Options) OnKeyref(ele xml.StartElement, protoTree []Component) (err error) {
+	opt.onIdentityConstraint(ele, "keyref")
+	return
+}
+
+// EndKeyref handles parsing event on the keyref end elements.
+func (opt *Options) EndKeyref(ele xml.EndElement, protoTree []Component) (err error) {
+	opt.endIdentityConstraint()
+	return
+}
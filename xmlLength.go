@@ -14,14 +14,16 @@ import (
 )
 
 // OnLength handles parsing event on the length start element.
-func (opt *Options) OnLength(ele xml.StartElement, protoTree []interface{}) (err error) {
+func (opt *Options) OnLength(ele xml.StartElement, protoTree []Component) (err error) {
+	var st *SimpleType
+	if st, err = peekAs[*SimpleType](opt.SimpleType, "simpleType"); err != nil {
+		return
+	}
 	for _, attr := range ele.Attr {
 		if attr.Name.Local == "value" {
-			if st, ok := opt.SimpleType.Peek().(*SimpleType); ok && st != nil {
-				if v, e := strconv.Atoi(attr.Value); e == nil {
-					st.Restriction.Length = v
-					st.Restriction.HasLength = true
-				}
+			if v, e := strconv.Atoi(attr.Value); e == nil {
+				st.Restriction.Length = v
+				st.Restriction.HasLength = true
 			}
 		}
 	}
@@ -31,6 +33,6 @@ func (opt *Options) OnLength(ele xml.StartElement, protoTree []interface{}) (err
 // EndLength handles parsing event on the length end elements. Length
 // specifies the exact number of characters or list items allowed. Must be
 // equal to or greater than zero.
-func (opt *Options) EndLength(ele xml.EndElement, protoTree []interface{}) (err error) {
+func (opt *Options) EndLength(ele xml.EndElement, protoTree []Component) (err error) {
 	return
 }
@@ -13,14 +13,15 @@ import "encoding/xml"
 // OnList handles parsing event on the list start elements. The list element
 // defines a simple type element as a list of values of a specified data
 // type.
-func (opt *Options) OnList(ele xml.StartElement, protoTree []interface{}) (err error) {
-	if opt.SimpleType.Peek() == nil {
+func (opt *Options) OnList(ele xml.StartElement, protoTree []Component) (err error) {
+	var st *SimpleType
+	if st, err = peekAs[*SimpleType](opt.SimpleType, "simpleType"); err != nil {
 		return
 	}
-	opt.SimpleType.Peek().(*SimpleType).List = true
+	st.List = true
 	for _, attr := range ele.Attr {
 		if attr.Name.Local == "itemType" {
-			if opt.SimpleType.Peek().(*SimpleType).Base, err = opt.GetValueType(attr.Value, protoTree); err != nil {
+			if st.Base, err = opt.GetValueType(attr.Value, protoTree); err != nil {
 				return
 			}
 		}
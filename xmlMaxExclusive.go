@@ -14,15 +14,17 @@ import (
 )
 
 // OnMaxExclusive handles parsing event on the maxExclusive start element.
-func (opt *Options) OnMaxExclusive(ele xml.StartElement, protoTree []interface{}) (err error) {
+func (opt *Options) OnMaxExclusive(ele xml.StartElement, protoTree []Component) (err error) {
+	var st *SimpleType
+	if st, err = peekAs[*SimpleType](opt.SimpleType, "simpleType"); err != nil {
+		return
+	}
 	for _, attr := range ele.Attr {
 		if attr.Name.Local == "value" {
-			if st, ok := opt.SimpleType.Peek().(*SimpleType); ok && st != nil {
-				if v, e := strconv.ParseFloat(attr.Value, 64); e == nil {
-					st.Restriction.Max = v
-					st.Restriction.HasMax = true
-					st.Restriction.MaxExclusive = true
-				}
+			if v, e := strconv.ParseFloat(attr.Value, 64); e == nil {
+				st.Restriction.Max = v
+				st.Restriction.HasMax = true
+				st.Restriction.MaxExclusive = true
 			}
 		}
 	}
@@ -32,7 +34,7 @@ func (opt *Options) OnMaxExclusive(ele xml.StartElement, protoTree []interface{}
 // EndMaxExclusive handles parsing event on the maxExclusive end elements.
 // MaxExclusive specifies the upper bounds for numeric values (the value must
 // be less than this value).
-func (opt *Options) EndMaxExclusive(ele xml.EndElement, protoTree []interface{}) (err error) {
+func (opt *Options) EndMaxExclusive(ele xml.EndElement, protoTree []Component) (err error) {
 	// Defer applying restrictions until EndRestriction
 	return
 }
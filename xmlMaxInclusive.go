@@ -14,15 +14,17 @@ import (
 )
 
 // OnMaxInclusive handles parsing event on the maxInclusive start element.
-func (opt *Options) OnMaxInclusive(ele xml.StartElement, protoTree []interface{}) (err error) {
+func (opt *Options) OnMaxInclusive(ele xml.StartElement, protoTree []Component) (err error) {
+	var st *SimpleType
+	if st, err = peekAs[*SimpleType](opt.SimpleType, "simpleType"); err != nil {
+		return
+	}
 	for _, attr := range ele.Attr {
 		if attr.Name.Local == "value" {
-			if st, ok := opt.SimpleType.Peek().(*SimpleType); ok && st != nil {
-				if v, e := strconv.ParseFloat(attr.Value, 64); e == nil {
-					st.Restriction.Max = v
-					st.Restriction.HasMax = true
-					st.Restriction.MaxExclusive = false
-				}
+			if v, e := strconv.ParseFloat(attr.Value, 64); e == nil {
+				st.Restriction.Max = v
+				st.Restriction.HasMax = true
+				st.Restriction.MaxExclusive = false
 			}
 		}
 	}
@@ -32,7 +34,7 @@ func (opt *Options) OnMaxInclusive(ele xml.StartElement, protoTree []interface{}
 // EndMaxInclusive handles parsing event on the maxInclusive end elements.
 // MaxInclusive specifies the upper bounds for numeric values (the value must
 // be less than or equal to this value).
-func (opt *Options) EndMaxInclusive(ele xml.EndElement, protoTree []interface{}) (err error) {
+func (opt *Options) EndMaxInclusive(ele xml.EndElement, protoTree []Component) (err error) {
 	// Defer applying restrictions until EndRestriction
 	return
 }
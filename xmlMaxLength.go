@@ -14,14 +14,16 @@ import (
 )
 
 // OnMaxLength handles parsing event on the maxLength start element.
-func (opt *Options) OnMaxLength(ele xml.StartElement, protoTree []interface{}) (err error) {
+func (opt *Options) OnMaxLength(ele xml.StartElement, protoTree []Component) (err error) {
+	var st *SimpleType
+	if st, err = peekAs[*SimpleType](opt.SimpleType, "simpleType"); err != nil {
+		return
+	}
 	for _, attr := range ele.Attr {
 		if attr.Name.Local == "value" {
-			if st, ok := opt.SimpleType.Peek().(*SimpleType); ok && st != nil {
-				if v, e := strconv.Atoi(attr.Value); e == nil {
-					st.Restriction.MaxLength = v
-					st.Restriction.HasMaxLength = true
-				}
+			if v, e := strconv.Atoi(attr.Value); e == nil {
+				st.Restriction.MaxLength = v
+				st.Restriction.HasMaxLength = true
 			}
 		}
 	}
@@ -31,6 +33,6 @@ func (opt *Options) OnMaxLength(ele xml.StartElement, protoTree []interface{}) (
 // EndMaxLength handles parsing event on the maxLength end elements. MaxLength
 // specifies the maximum number of characters or list items allowed. Must be
 // equal to or greater than zero.
-func (opt *Options) EndMaxLength(ele xml.EndElement, protoTree []interface{}) (err error) {
+func (opt *Options) EndMaxLength(ele xml.EndElement, protoTree []Component) (err error) {
 	return
 }
@@ -14,15 +14,17 @@ import (
 )
 
 // OnMinExclusive handles parsing event on the minExclusive start element.
-func (opt *Options) OnMinExclusive(ele xml.StartElement, protoTree []interface{}) (err error) {
+func (opt *Options) OnMinExclusive(ele xml.StartElement, protoTree []Component) (err error) {
+	var st *SimpleType
+	if st, err = peekAs[*SimpleType](opt.SimpleType, "simpleType"); err != nil {
+		return
+	}
 	for _, attr := range ele.Attr {
 		if attr.Name.Local == "value" {
-			if st, ok := opt.SimpleType.Peek().(*SimpleType); ok && st != nil {
-				if v, e := strconv.ParseFloat(attr.Value, 64); e == nil {
-					st.Restriction.Min = v
-					st.Restriction.HasMin = true
-					st.Restriction.MinExclusive = true
-				}
+			if v, e := strconv.ParseFloat(attr.Value, 64); e == nil {
+				st.Restriction.Min = v
+				st.Restriction.HasMin = true
+				st.Restriction.MinExclusive = true
 			}
 		}
 	}
@@ -32,7 +34,7 @@ func (opt *Options) OnMinExclusive(ele xml.StartElement, protoTree []interface{}
 // EndMinExclusive handles parsing event on the minExclusive end elements.
 // MinExclusive specifies the lower bounds for numeric values (the value must
 // be greater than this value).
-func (opt *Options) EndMinExclusive(ele xml.EndElement, protoTree []interface{}) (err error) {
+func (opt *Options) EndMinExclusive(ele xml.EndElement, protoTree []Component) (err error) {
 	// Defer applying restrictions until EndRestriction
 	return
 }
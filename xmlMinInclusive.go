@@ -14,15 +14,17 @@ import (
 )
 
 // OnMinInclusive handles parsing event on the minInclusive start element.
-func (opt *Options) OnMinInclusive(ele xml.StartElement, protoTree []interface{}) (err error) {
+func (opt *Options) OnMinInclusive(ele xml.StartElement, protoTree []Component) (err error) {
+	var st *SimpleType
+	if st, err = peekAs[*SimpleType](opt.SimpleType, "simpleType"); err != nil {
+		return
+	}
 	for _, attr := range ele.Attr {
 		if attr.Name.Local == "value" {
-			if st, ok := opt.SimpleType.Peek().(*SimpleType); ok && st != nil {
-				if v, e := strconv.ParseFloat(attr.Value, 64); e == nil {
-					st.Restriction.Min = v
-					st.Restriction.HasMin = true
-					st.Restriction.MinExclusive = false
-				}
+			if v, e := strconv.ParseFloat(attr.Value, 64); e == nil {
+				st.Restriction.Min = v
+				st.Restriction.HasMin = true
+				st.Restriction.MinExclusive = false
 			}
 		}
 	}
@@ -32,6 +34,6 @@ func (opt *Options) OnMinInclusive(ele xml.StartElement, protoTree []interface{}
 // EndMinInclusive handles parsing event on the minInclusive end elements.
 // MinInclusive specifies the lower bounds for numeric values (the value must
 // be greater than or equal to this value).
-func (opt *Options) EndMinInclusive(ele xml.EndElement, protoTree []interface{}) (err error) {
+func (opt *Options) EndMinInclusive(ele xml.EndElement, protoTree []Component) (err error) {
 	return
 }
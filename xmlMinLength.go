@@ -14,14 +14,16 @@ import (
 )
 
 // OnMinLength handles parsing event on the minLength start element.
-func (opt *Options) OnMinLength(ele xml.StartElement, protoTree []interface{}) (err error) {
+func (opt *Options) OnMinLength(ele xml.StartElement, protoTree []Component) (err error) {
+	var st *SimpleType
+	if st, err = peekAs[*SimpleType](opt.SimpleType, "simpleType"); err != nil {
+		return
+	}
 	for _, attr := range ele.Attr {
 		if attr.Name.Local == "value" {
-			if st, ok := opt.SimpleType.Peek().(*SimpleType); ok && st != nil {
-				if v, e := strconv.Atoi(attr.Value); e == nil {
-					st.Restriction.MinLength = v
-					st.Restriction.HasMinLength = true
-				}
+			if v, e := strconv.Atoi(attr.Value); e == nil {
+				st.Restriction.MinLength = v
+				st.Restriction.HasMinLength = true
 			}
 		}
 	}
@@ -31,6 +33,6 @@ func (opt *Options) OnMinLength(ele xml.StartElement, protoTree []interface{}) (
 // EndMinLength handles parsing event on the minLength end elements. MinLength
 // specifies the minimum number of characters or list items allowed. Must be
 // equal to or greater than zero.
-func (opt *Options) EndMinLength(ele xml.EndElement, protoTree []interface{}) (err error) {
+func (opt *Options) EndMinLength(ele xml.EndElement, protoTree []Component) (err error) {
 	return
 }
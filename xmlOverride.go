@@ -0,0 +1,25 @@
+// Copyright 2026 The xgen Authors. All rights reserved. Use of this source
+// code is governed by a BSD-style license that can be found in the LICENSE
+// file.
+//
+// Package xgen written in pure Go providing a set of functions that allow you
+// to parse XSD (XML schema files). This library needs Go version 1.10 or
+// later.
+
+package xgen
+
+import "encoding/xml"
+
+// OnOverride handles parsing event on the override start elements. The
+// override element includes the schema by given schemaLocation and replaces
+// the components declared in it by the components of the same kind and name
+// declared in the override element.
+func (opt *Options) OnOverride(ele xml.StartElement, protoTree []Component) (err error) {
+	return opt.startRedefinition(ele, true)
+}
+
+// EndOverride handles parsing event on the override end elements.
+func (opt *Options) EndOverride(ele xml.EndElement, protoTree []Component) (err error) {
+	opt.endRedefinition()
+	return
+}
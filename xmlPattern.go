@@ -13,12 +13,14 @@ import (
 )
 
 // OnPattern handles parsing event on the pattern start element.
-func (opt *Options) OnPattern(ele xml.StartElement, protoTree []interface{}) (err error) {
+func (opt *Options) OnPattern(ele xml.StartElement, protoTree []Component) (err error) {
+	var st *SimpleType
+	if st, err = peekAs[*SimpleType](opt.SimpleType, "simpleType"); err != nil {
+		return
+	}
 	for _, attr := range ele.Attr {
 		if attr.Name.Local == "value" {
-			if st, ok := opt.SimpleType.Peek().(*SimpleType); ok && st != nil {
-				st.Restriction.PatternStr = attr.Value
-			}
+			st.Restriction.PatternStr = attr.Value
 		}
 	}
 	return
@@ -26,7 +28,7 @@ func (opt *Options) OnPattern(ele xml.StartElement, protoTree []interface{}) (er
 
 // EndPattern handles parsing event on the pattern end elements. Pattern
 // defines the exact sequence of characters that are acceptable.
-func (opt *Options) EndPattern(ele xml.EndElement, protoTree []interface{}) (err error) {
+func (opt *Options) EndPattern(ele xml.EndElement, protoTree []Component) (err error) {
 	// Defer applying restrictions until EndRestriction
 	return
 }
@@ -0,0 +1,276 @@
+// Copyright 2026 The xgen Authors. All rights reserved. Use of this source
+// code is governed by a BSD-style license that can be found in the LICENSE
+// file.
+//
+// Package xgen written in pure Go providing a set of functions that allow you
+// to parse XSD (XML schema files). This library needs Go version 1.10 or
+// later.
+
+package xgen
+
+import "encoding/xml"
+
+// redefinition holds the state of a redefine or override element being
+// parsed: the components of the referenced schema have been added to the
+// proto tree in the range [start, end), and the components declared in the
+// element are added after them.
+type redefinition struct {
+	override   bool
+	start, end int
+}
+
+// OnRedefine handles parsing event on the redefine start elements. The
+// redefine element includes the schema by given schemaLocation and redefines
+// the simple and complex types, groups and attribute groups declared in it.
+// The redefinitions may refer to the original components by their own names.
+func (opt *Options) OnRedefine(ele xml.StartElement, protoTree []Component) (err error) {
+	return opt.startRedefinition(ele, false)
+}
+
+// EndRedefine handles parsing event on the redefine end elements.
+func (opt *Options) EndRedefine(ele xml.EndElement, protoTree []Component) (err error) {
+	opt.endRedefinition()
+	return
+}
+
+// startRedefinition adds the components of the schema referenced by the
+// redefine or override element to the proto tree.
+func (opt *Options) startRedefinition(ele xml.StartElement, override bool) (err error) {
+	var components []Component
+	for _, attr := range ele.Attr {
+		if attr.Name.Local == "schemaLocation" {
+			if components, err = opt.loadSchema(attr.Value); err != nil {
+				return
+			}
+		}
+	}
+	start := len(opt.ProtoTree)
+	opt.ProtoTree = append(opt.ProtoTree, components...)
+	opt.redefinition = &redefinition{override: override, start: start, end: len(opt.ProtoTree)}
+	return
+}
+
+// loadSchema parses the schema by given location relative to the schema
+// being parsed and returns its components.
+func (opt *Options) loadSchema(location string) ([]Component, error) {
+	file, err := opt.schemaFile(location, "")
+	if err != nil || file == "" {
+		return nil, err
+	}
+	parser := NewParser(&Options{
+		FilePath:            file,
+		OutputDir:           opt.OutputDir,
+		Extract:             true,
+		Lang:                opt.Lang,
+		IncludeMap:          opt.IncludeMap,
+		LocalNameNSMap:      opt.LocalNameNSMap,
+		NSSchemaLocationMap: opt.NSSchemaLocationMap,
+		ParseFileList:       opt.ParseFileList,
+		ParseFileMap:        opt.ParseFileMap,
+		ProtoTree:           make([]Component, 0),
+		RemoteSchema:        opt.RemoteSchema,
+		Fetcher:             opt.Fetcher,
+		Catalog:             opt.Catalog,
+		FS:                  opt.FS,
+		Strict:              opt.Strict,
+		TypeMappings:        opt.TypeMappings,
+		diagnostics:         opt.diagnostics,
+		symbols:             opt.symbols,
+		referenced:          true,
+		ctx:                 opt.ctx,
+	})
+	if err := parser.parse(); err != nil {
+		return nil, err
+	}
+	return parser.ProtoTree, nil
+}
+
+// endRedefinition replaces the components of the referenced schema by the
+// ones declared in the redefine or override element. A redefinition which
+// refers to the original component by its own name is merged with it, an
+// override replaces it. Overriding components without a counterpart in the
+// referenced schema are ignored.
+func (opt *Options) endRedefinition() {
+	r := opt.redefinition
+	if r == nil {
+		return
+	}
+	opt.redefinition = nil
+	// The components of the proto tree are replaced in place
+	defer opt.declarations.reset()
+	declared := opt.ProtoTree[r.end:]
+	opt.ProtoTree = opt.ProtoTree[:r.end:r.end]
+	selfReferences := map[string]bool{}
+	for _, component := range declared {
+		// A reference to an attribute group by its own name in a redefined
+		// attribute group is parsed as a separate attribute group
+		if ag, ok := component.(*AttributeGroup); ok && ag.Ref != "" && !r.override {
+			selfReferences[trimNSPrefix(ag.Name)] = true
+			continue
+		}
+		i := opt.findRedefined(component, r)
+		if i < 0 {
+			if !r.override {
+				opt.ProtoTree = append(opt.ProtoTree, component)
+			}
+			continue
+		}
+		if !r.override {
+			component = redefineComponent(component, opt.ProtoTree[i], selfReferences)
+		}
+		opt.ProtoTree[i] = component
+	}
+}
+
+// findRedefined returns the index of the component in the referenced schema
+// which has the same kind and name as the given component, or -1 if there is
+// no such component.
+func (opt *Options) findRedefined(component Component, r *redefinition) int {
+	kind, name := componentName(component)
+	for i := r.start; i < r.end; i++ {
+		if k, n := componentName(opt.ProtoTree[i]); k == kind && n == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// componentName returns the kind and the name of the schema component.
+func componentName(component Component) (kind, name string) {
+	switch v := component.(type) {
+	case *SimpleType:
+		return "simpleType", v.Name
+	case *ComplexType:
+		return "complexType", v.Name
+	case *Group:
+		return "group", v.Name
+	case *AttributeGroup:
+		return "attributeGroup", v.Name
+	case *Element:
+		return "element", v.Name
+	case *Attribute:
+		return "attribute", v.Name
+	}
+	return
+}
+
+// redefineComponent merges the redefinition with the original component it
+// refers to by its own name.
+func redefineComponent(component, original Component, selfReferences map[string]bool) Component {
+	switch v := component.(type) {
+	case *SimpleType:
+		// A simple type is always redefined by a restriction of itself
+		o := original.(*SimpleType)
+		redefined := *v
+		redefined.Base = o.Base
+		redefined.List, redefined.Union, redefined.MemberTypes = o.List, o.Union, o.MemberTypes
+		redefined.Restriction = narrowRestriction(o.Restriction, v.Restriction)
+		return &redefined
+	case *ComplexType:
+		return redefineComplexType(v, original.(*ComplexType))
+	case *Group:
+		return redefineGroup(v, original.(*Group))
+	case *AttributeGroup:
+		if !selfReferences[v.Name] {
+			return v
+		}
+		o := original.(*AttributeGroup)
+		redefined := *v
+		redefined.Attributes = mergeAttributes(o.Attributes, v.Attributes)
+		if redefined.AnyAttribute == nil {
+			redefined.AnyAttribute = o.AnyAttribute
+		}
+		return &redefined
+	}
+	return component
+}
+
+// redefineComplexType merges the complex type derived from the original
+// complex type of the same name with it.
+func redefineComplexType(v, o *ComplexType) *ComplexType {
+	if trimNSPrefix(v.Base) != o.Name {
+		return v
+	}
+	redefined := *v
+	redefined.Base, redefined.Content, redefined.Derivation = o.Base, o.Content, o.Derivation
+	redefined.Assertions = append(append([]Assertion{}, o.Assertions...), v.Assertions...)
+	if v.Derivation == "restriction" {
+		// The restriction declares the particles it keeps, the attributes
+		// are inherited unless prohibited
+		redefined.Attributes = mergeAttributes(o.Attributes, v.Attributes)
+		redefined.Restriction = narrowRestriction(o.Restriction, v.Restriction)
+		return &redefined
+	}
+	redefined.Restriction = o.Restriction
+	redefined.Elements = append(append([]Element{}, o.Elements...), v.Elements...)
+	redefined.Attributes = append(append([]Attribute{}, o.Attributes...), v.Attributes...)
+	redefined.Groups = append(append([]Group{}, o.Groups...), v.Groups...)
+	redefined.Choice = append(append([]Choice{}, o.Choice...), v.Choice...)
+	redefined.AttributeGroup = append(append([]AttributeGroup{}, o.AttributeGroup...), v.AttributeGroup...)
+	redefined.Any = append(append([]Wildcard{}, o.Any...), v.Any...)
+	if redefined.AnyAttribute == nil {
+		redefined.AnyAttribute = o.AnyAttribute
+	}
+	if redefined.All == nil {
+		redefined.All = o.All
+	}
+	redefined.Mixed = v.Mixed || o.Mixed
+	return &redefined
+}
+
+// redefineGroup replaces the reference to the original group of the same
+// name in the redefined group by the content of the original group.
+func redefineGroup(v, o *Group) *Group {
+	redefined := *v
+	redefined.Groups = nil
+	selfReference := false
+	for _, group := range v.Groups {
+		if trimNSPrefix(group.Name) == o.Name {
+			selfReference = true
+			continue
+		}
+		redefined.Groups = append(redefined.Groups, group)
+	}
+	if !selfReference {
+		return v
+	}
+	redefined.Elements = append(append([]Element{}, o.Elements...), v.Elements...)
+	redefined.Groups = append(append([]Group{}, o.Groups...), redefined.Groups...)
+	redefined.Any = append(append([]Wildcard{}, o.Any...), v.Any...)
+	return &redefined
+}
+
+// narrowRestriction returns the restriction with the facets of the derived
+// restriction applied on top of the facets of the base restriction.
+func narrowRestriction(base, derived Restriction) Restriction {
+	r := base
+	if derived.Doc != "" {
+		r.Doc = derived.Doc
+	}
+	if derived.Precision != 0 {
+		r.Precision = derived.Precision
+	}
+	if len(derived.Enum) > 0 {
+		r.Enum = derived.Enum
+	}
+	if derived.HasMin {
+		r.Min, r.HasMin, r.MinExclusive = derived.Min, true, derived.MinExclusive
+	}
+	if derived.HasMax {
+		r.Max, r.HasMax, r.MaxExclusive = derived.Max, true, derived.MaxExclusive
+	}
+	if derived.HasMinLength {
+		r.MinLength, r.HasMinLength = derived.MinLength, true
+	}
+	if derived.HasMaxLength {
+		r.MaxLength, r.HasMaxLength = derived.MaxLength, true
+	}
+	if derived.HasLength {
+		r.Length, r.HasLength = derived.Length, true
+	}
+	if derived.PatternStr != "" {
+		r.Pattern, r.PatternStr = derived.Pattern, derived.PatternStr
+	}
+	r.Assertions = append(append([]Assertion{}, base.Assertions...), derived.Assertions...)
+	return r
+}
@@ -13,7 +13,7 @@ import "encoding/xml"
 // OnRestriction handles parsing event on the restriction start elements. The
 // restriction element defines restrictions on a simpleType, simpleContent, or
 // complexContent definition.
-func (opt *Options) OnRestriction(ele xml.StartElement, protoTree []interface{}) (err error) {
+func (opt *Options) OnRestriction(ele xml.StartElement, protoTree []Component) (err error) {
 	for _, attr := range ele.Attr {
 		if attr.Name.Local == "base" {
 			var valueType string
@@ -21,9 +21,29 @@ func (opt *Options) OnRestriction(ele xml.StartElement, protoTree []interface{})
 			if err != nil {
 				return
 			}
-			if opt.SimpleType.Peek() != nil {
+			if opt.ComplexType.Len() > 0 {
+				var complexType *ComplexType
+				if complexType, err = peekAs[*ComplexType](opt.ComplexType, "complexType"); err != nil {
+					return
+				}
+				if complexType.Content != "" && complexType.Derivation == "" {
+					// The restriction derives the content of the current complexType
+					complexType.Derivation, complexType.Base = "restriction", valueType
+					if complexType.Content == "simpleContent" {
+						// Collect the facets on a simpleType until EndRestriction
+						opt.ContentRestriction = &SimpleType{Base: valueType}
+						opt.SimpleType.Push(opt.ContentRestriction)
+					}
+					continue
+				}
+			}
+			if opt.SimpleType.Len() > 0 {
+				var st *SimpleType
+				if st, err = peekAs[*SimpleType](opt.SimpleType, "simpleType"); err != nil {
+					return
+				}
 				// Record the base on the current simpleType; defer applying to element/attribute until EndRestriction
-				opt.SimpleType.Peek().(*SimpleType).Base = valueType
+				st.Base = valueType
 			}
 		}
 	}
@@ -31,14 +51,31 @@ func (opt *Options) OnRestriction(ele xml.StartElement, protoTree []interface{})
 }
 
 // EndRestriction handles parsing event on the restriction end elements.
-func (opt *Options) EndRestriction(ele xml.EndElement, protoTree []interface{}) (err error) {
-	if opt.SimpleType.Peek() == nil {
+func (opt *Options) EndRestriction(ele xml.EndElement, protoTree []Component) (err error) {
+	if opt.SimpleType.Len() == 0 {
+		return
+	}
+	var st *SimpleType
+	if st, err = peekAs[*SimpleType](opt.SimpleType, "simpleType"); err != nil {
+		return
+	}
+	if opt.ContentRestriction != nil && st == opt.ContentRestriction {
+		opt.SimpleType.Pop()
+		var complexType *ComplexType
+		if complexType, err = peekAs[*ComplexType](opt.ComplexType, "complexType"); err != nil {
+			return
+		}
+		complexType.Restriction = opt.ContentRestriction.Restriction
+		opt.ContentRestriction = nil
 		return
 	}
 	// Only apply and pop for inline restrictions within attribute/element
 	if opt.Attribute.Len() > 0 {
-		st := opt.SimpleType.Pop().(*SimpleType)
-		attr := opt.Attribute.Peek().(*Attribute)
+		var attr *Attribute
+		if attr, err = peekAs[*Attribute](opt.Attribute, "attribute"); err != nil {
+			return
+		}
+		opt.SimpleType.Pop()
 		attr.Type, err = opt.GetValueType(st.Base, opt.ProtoTree)
 		if err != nil {
 			return
@@ -48,16 +85,25 @@ func (opt *Options) EndRestriction(ele xml.EndElement, protoTree []interface{})
 		return
 	}
 	if opt.Element.Len() > 0 {
-		st := opt.SimpleType.Pop().(*SimpleType)
-		ele := opt.Element.Peek().(*Element)
+		var ele *Element
+		if ele, err = peekAs[*Element](opt.Element, "element"); err != nil {
+			return
+		}
+		opt.SimpleType.Pop()
 		ele.Type, err = opt.GetValueType(st.Base, opt.ProtoTree)
 		if err != nil {
 			return
 		}
 		ele.Restriction = st.Restriction
 		opt.CurrentEle = ""
-		if !opt.ComplexType.Empty() && len(opt.ComplexType.Peek().(*ComplexType).Elements) > 0 {
-			opt.ComplexType.Peek().(*ComplexType).Elements[len(opt.ComplexType.Peek().(*ComplexType).Elements)-1] = *ele
+		if opt.ComplexType.Len() > 0 {
+			var complexType *ComplexType
+			if complexType, err = peekAs[*ComplexType](opt.ComplexType, "complexType"); err != nil {
+				return
+			}
+			if l := len(complexType.Elements); l > 0 {
+				complexType.Elements[l-1] = *ele
+			}
 		}
 	}
 	// For named simpleType restrictions, keep the simpleType on stack; EndSimpleType will handle popping and persisting
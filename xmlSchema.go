@@ -12,7 +12,18 @@ import "encoding/xml"
 
 // OnSchema handles parsing event on the schema start elements. Schema is the
 // root element of every XML Schema.
-func (opt *Options) OnSchema(ele xml.StartElement, protoTree []interface{}) (err error) {
+func (opt *Options) OnSchema(ele xml.StartElement, protoTree []Component) (err error) {
 	opt.prepareLocalNameNSMap(ele)
+	opt.targetNamespace, opt.elementFormDefault, opt.attributeFormDefault = "", "", ""
+	for _, attr := range ele.Attr {
+		switch attr.Name.Local {
+		case "targetNamespace":
+			opt.targetNamespace = attr.Value
+		case "elementFormDefault":
+			opt.elementFormDefault = attr.Value
+		case "attributeFormDefault":
+			opt.attributeFormDefault = attr.Value
+		}
+	}
 	return
 }
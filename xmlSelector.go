@@ -0,0 +1,26 @@
+// Copyright 2026 The xgen Authors. All rights reserved. Use of this source
+// code is governed by a BSD-style license that can be found in the LICENSE
+// file.
+//
+// Package xgen written in pure Go providing a set of functions that allow you
+// to parse XSD (XML schema files). This library needs Go version 1.10 or
+// later.
+
+package xgen
+
+import "encoding/xml"
+
+// OnSelector handles parsing event on the selector start elements. The
+// selector element specifies an XPath expression that selects a set of nodes
+// for an identity constraint.
+func (opt *Options) OnSelector(ele xml.StartElement, protoTree []Component) (err error) {
+	if opt.IdentityConstraint == nil {
+		return
+	}
+	for _, attr := range ele.Attr {
+		if attr.Name.Local == "xpath" {
+			opt.IdentityConstraint.Selector = attr.Value
+		}
+	}
+	return
+}
@@ -10,12 +10,13 @@ package xgen
 
 import (
 	"encoding/xml"
+	"fmt"
 	"strconv"
 )
 
 // OnSequence evaluates wether the sequence element contains a maxOccurs attribute
 // (that in turn mandates plural inner elements) and saves that info on a stack
-func (opt *Options) OnSequence(ele xml.StartElement, protoTree []interface{}) (err error) {
+func (opt *Options) OnSequence(ele xml.StartElement, protoTree []Component) (err error) {
 	for _, attr := range ele.Attr {
 		if attr.Name.Local == "maxOccurs" {
 			if attr.Value == "unbounded" {
@@ -35,7 +36,10 @@ func (opt *Options) OnSequence(ele xml.StartElement, protoTree []interface{}) (e
 }
 
 // EndSequence removes an item from the stack mentioned above
-func (opt *Options) EndSequence(ele xml.EndElement, protoTree []interface{}) (err error) {
+func (opt *Options) EndSequence(ele xml.EndElement, protoTree []Component) (err error) {
+	if len(opt.InPluralSequence) == 0 {
+		return fmt.Errorf("unexpected end of %s", ele.Name.Local)
+	}
 	opt.InPluralSequence = opt.InPluralSequence[:len(opt.InPluralSequence)-1]
 	return
 }
@@ -13,9 +13,10 @@ import "encoding/xml"
 // OnSimpleType handles parsing event on the simpleType start elements. The
 // simpleType element defines a simple type and specifies the constraints and
 // information about the values of attributes or text-only elements.
-func (opt *Options) OnSimpleType(ele xml.StartElement, protoTree []interface{}) (err error) {
+func (opt *Options) OnSimpleType(ele xml.StartElement, protoTree []Component) (err error) {
 	// Start a new simple type scope when encountering a simpleType element.
-	opt.SimpleType.Push(&SimpleType{})
+	st := &SimpleType{Namespace: opt.targetNamespace}
+	opt.SimpleType.Push(st)
 	if opt.CurrentEle == "attributeGroup" {
 		// keep parsing, attributeGroup can contain nested simpleTypes
 	}
@@ -23,21 +24,27 @@ func (opt *Options) OnSimpleType(ele xml.StartElement, protoTree []interface{})
 	for _, attr := range ele.Attr {
 		if attr.Name.Local == "name" {
 			opt.CurrentEle = opt.InElement
-			opt.SimpleType.Peek().(*SimpleType).Name = attr.Value
+			st.Name = attr.Value
 		}
 	}
 	return
 }
 
 // EndSimpleType handles parsing event on the simpleType end elements.
-func (opt *Options) EndSimpleType(ele xml.EndElement, protoTree []interface{}) (err error) {
+func (opt *Options) EndSimpleType(ele xml.EndElement, protoTree []Component) (err error) {
 	if opt.SimpleType.Len() == 0 {
 		return
 	}
-	st := opt.SimpleType.Peek().(*SimpleType)
+	var st *SimpleType
+	if st, err = peekAs[*SimpleType](opt.SimpleType, "simpleType"); err != nil {
+		return
+	}
 	// If this is an anonymous simpleType defined inline for an attribute, assign its resolved base and restriction to the attribute.
 	if opt.Attribute.Len() > 0 && st.Name == "" {
-		attr := opt.Attribute.Peek().(*Attribute)
+		var attr *Attribute
+		if attr, err = peekAs[*Attribute](opt.Attribute, "attribute"); err != nil {
+			return
+		}
 		if vt, err2 := opt.GetValueType(st.Base, opt.ProtoTree); err2 == nil && vt != "" {
 			attr.Type = vt
 		} else {
@@ -49,7 +56,10 @@ func (opt *Options) EndSimpleType(ele xml.EndElement, protoTree []interface{}) (
 	}
 	// If this is an anonymous simpleType defined inline for an element, assign its resolved base and restriction to the element.
 	if opt.Element.Len() > 0 && st.Name == "" {
-		ele := opt.Element.Peek().(*Element)
+		var ele *Element
+		if ele, err = peekAs[*Element](opt.Element, "element"); err != nil {
+			return
+		}
 		if vt, err2 := opt.GetValueType(st.Base, opt.ProtoTree); err2 == nil && vt != "" {
 			ele.Type = vt
 		} else {
@@ -61,7 +71,8 @@ func (opt *Options) EndSimpleType(ele xml.EndElement, protoTree []interface{}) (
 	}
 	// Persist named simpleTypes (top-level or nested) regardless of other stacks; inline anonymous handled above
 	if st.Name != "" {
-		opt.ProtoTree = append(opt.ProtoTree, opt.SimpleType.Pop())
+		opt.SimpleType.Pop()
+		opt.ProtoTree = append(opt.ProtoTree, st)
 		opt.CurrentEle = ""
 		return
 	}
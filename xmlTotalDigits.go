@@ -13,7 +13,7 @@ import "encoding/xml"
 // EndTotalDigits handles parsing event on the totalDigits end elements.
 // TotalDigits specifies the exact number of digits allowed. Must be greater
 // than zero.
-func (opt *Options) EndTotalDigits(ele xml.EndElement, protoTree []interface{}) (err error) {
+func (opt *Options) EndTotalDigits(ele xml.EndElement, protoTree []Component) (err error) {
 	// Defer applying restrictions until EndRestriction
 	return
 }
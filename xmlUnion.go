@@ -16,18 +16,19 @@ import (
 // OnUnion handles parsing event on the union start elements. The union
 // element defines a simple type as a collection (union) of values from
 // specified simple data types.
-func (opt *Options) OnUnion(ele xml.StartElement, protoTree []interface{}) (err error) {
+func (opt *Options) OnUnion(ele xml.StartElement, protoTree []Component) (err error) {
 	opt.InUnion = true
-	if opt.SimpleType.Peek() == nil {
+	var st *SimpleType
+	if st, err = peekAs[*SimpleType](opt.SimpleType, "simpleType"); err != nil {
 		return
 	}
-	opt.SimpleType.Peek().(*SimpleType).Union = true
-	opt.SimpleType.Peek().(*SimpleType).MemberTypes = make(map[string]string)
+	st.Union = true
+	st.MemberTypes = make(map[string]string)
 	for _, attr := range ele.Attr {
 		if attr.Name.Local == "memberTypes" {
 			memberTypes := strings.Split(attr.Value, " ")
 			for _, memberType := range memberTypes {
-				opt.SimpleType.Peek().(*SimpleType).MemberTypes[trimNSPrefix(memberType)], err = opt.GetValueType(memberType, protoTree)
+				st.MemberTypes[trimNSPrefix(memberType)], err = opt.GetValueType(memberType, protoTree)
 				if err != nil {
 					return
 				}
@@ -39,7 +40,7 @@ func (opt *Options) OnUnion(ele xml.StartElement, protoTree []interface{}) (err
 }
 
 // EndUnion handles parsing event on the union end elements.
-func (opt *Options) EndUnion(ele xml.EndElement, protoTree []interface{}) (err error) {
+func (opt *Options) EndUnion(ele xml.EndElement, protoTree []Component) (err error) {
 	// Always clear the union context when the </union> tag is closed.
 	// Previously, this flag was only cleared when a SimpleType was still on the stack,
 	// which could leave InUnion stuck to true if the SimpleType got popped earlier
@@ -0,0 +1,25 @@
+// Copyright 2026 The xgen Authors. All rights reserved. Use of this source
+// code is governed by a BSD-style license that can be found in the LICENSE
+// file.
+//
+// Package xgen written in pure Go providing a set of functions that allow you
+// to parse XSD (XML schema files). This library needs Go version 1.10 or
+// later.
+
+package xgen
+
+import "encoding/xml"
+
+// OnUnique handles parsing event on the unique start elements. The unique
+// element specifies that the values of the fields of the selected nodes must
+// be unique within the declaring element, when they are present.
+func (opt *Options) OnUnique(ele xml.StartElement, protoTree []Component) (err error) {
+	opt.onIdentityConstraint(ele, "unique")
+	return
+}
+
+// EndUnique handles parsing event on the unique end elements.
+func (opt *Options) EndUnique(ele xml.EndElement, protoTree []Component) (err error) {
+	opt.endIdentityConstraint()
+	return
+}
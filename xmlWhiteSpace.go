@@ -13,9 +13,17 @@ import "encoding/xml"
 // EndWhiteSpace handles parsing event on the whiteSpace end elements.
 // WhiteSpace specifies how white space (line feeds, tabs, spaces, and
 // carriage returns) is handled.
-func (opt *Options) EndWhiteSpace(ele xml.EndElement, protoTree []interface{}) (err error) {
+func (opt *Options) EndWhiteSpace(ele xml.EndElement, protoTree []Component) (err error) {
 	if opt.SimpleType.Len() > 0 && opt.Element.Len() > 0 {
-		if opt.Element.Peek().(*Element).Type, err = opt.GetValueType(opt.SimpleType.Pop().(*SimpleType).Base, opt.ProtoTree); err != nil {
+		var element *Element
+		if element, err = peekAs[*Element](opt.Element, "element"); err != nil {
+			return
+		}
+		var st *SimpleType
+		if st, err = popAs[*SimpleType](opt.SimpleType, "simpleType"); err != nil {
+			return
+		}
+		if element.Type, err = opt.GetValueType(st.Base, opt.ProtoTree); err != nil {
 			return
 		}
 		opt.CurrentEle = ""
@@ -27,6 +27,30 @@ func TestGeneratedGo(t *testing.T) {
 			xmlFileName:     "base64.xml",
 			receivingStruct: &schema.TopLevel{},
 		},
+		{
+			xmlFileName:     "wildcard.xml",
+			receivingStruct: &schema.Envelope{},
+		},
+		{
+			xmlFileName:     "nillable.xml",
+			receivingStruct: &schema.Payment{},
+		},
+		{
+			xmlFileName:     "substitution.xml",
+			receivingStruct: &schema.Drawing{},
+		},
+		{
+			xmlFileName:     "derivation.xml",
+			receivingStruct: &schema.Listing{},
+		},
+		{
+			xmlFileName:     "all.xml",
+			receivingStruct: &schema.Contact{},
+		},
+		{
+			xmlFileName:     "identity.xml",
+			receivingStruct: &schema.PurchaseOrder{},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -110,3 +134,105 @@ func TestCodeGeneratorFileWithExtension(t *testing.T) {
 		})
 	}
 }
+
+func TestGeneratedGoNillable(t *testing.T) {
+	var payment schema.Payment
+	require.NoError(t, xml.Unmarshal([]byte(`<Payment xmlns="http://example.org/" xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance"><amount xsi:nil="true"/><tag>a</tag></Payment>`), &payment))
+	assert.True(t, payment.Amount.Nil)
+	assert.Nil(t, payment.Fee, "absent element must stay nil")
+	require.Len(t, payment.Tag, 1)
+	assert.Equal(t, schema.Nillable[string]{Value: "a"}, payment.Tag[0])
+
+	require.NoError(t, xml.Unmarshal([]byte(`<Payment xmlns="http://example.org/"><amount currency="EUR">2.5</amount><fee>0</fee></Payment>`), &payment))
+	assert.False(t, payment.Amount.Nil)
+	assert.Equal(t, 2.5, payment.Amount.Value.Value)
+	require.NotNil(t, payment.Fee)
+	assert.False(t, payment.Fee.Nil)
+}
+
+func TestGeneratedGoSubstitutionGroup(t *testing.T) {
+	var drawing schema.Drawing
+	require.NoError(t, xml.Unmarshal([]byte(`<Drawing xmlns="http://example.org/"><title>plan</title><Tile><side>1</side></Tile><Circle><radius>2</radius></Circle></Drawing>`), &drawing))
+	assert.Equal(t, "plan", drawing.Title)
+	require.Len(t, drawing.HereShape, 2)
+	require.IsType(t, &schema.TileElement{}, drawing.HereShape[0])
+	assert.Equal(t, 1.0, drawing.HereShape[0].(*schema.TileElement).Side)
+	require.IsType(t, &schema.CircleElement{}, drawing.HereShape[1])
+	assert.Equal(t, 2.0, drawing.HereShape[1].(*schema.CircleElement).Radius)
+}
+
+func TestGeneratedGoAll(t *testing.T) {
+	var contact schema.Contact
+	require.NoError(t, xml.Unmarshal([]byte(`<Contact xmlns="http://example.org/" id="7"><label>work</label><email>a@example.org</email><preferences><newsletter>true</newsletter></preferences><name>Ann</name><label>home</label></Contact>`), &contact))
+	assert.Equal(t, "Ann", contact.Name)
+	assert.Equal(t, "a@example.org", contact.Email)
+	assert.Equal(t, []string{"work", "home"}, contact.Label)
+	require.NotNil(t, contact.Preferences)
+	assert.Nil(t, contact.Preferences.Language)
+	assert.NoError(t, contact.Validate())
+	assert.NoError(t, contact.Preferences.Validate())
+
+	contact = schema.Contact{}
+	require.NoError(t, xml.Unmarshal([]byte(`<Contact xmlns="http://example.org/" id="7"><name>Ann</name><email>a@example.org</email><name>Bob</name></Contact>`), &contact))
+	assert.EqualError(t, contact.Validate(), "name must occur at most once")
+}
+
+func TestGeneratedGoIdentity(t *testing.T) {
+	var order schema.PurchaseOrder
+	input, err := ioutil.ReadFile(filepath.Join("xmlFixtures", "identity.xml"))
+	require.NoError(t, err)
+	require.NoError(t, xml.Unmarshal(input, &order))
+	assert.NoError(t, order.ValidateIdentity())
+
+	order = schema.PurchaseOrder{}
+	require.NoError(t, xml.Unmarshal([]byte(`<PurchaseOrder xmlns="http://example.org/"><products><product sku="A1"/><product sku="A1"/></products><line number="1"><product>A1</product></line><line number="1"><product>C3</product></line></PurchaseOrder>`), &order))
+	assert.EqualError(t, order.ValidateIdentity(), `/PurchaseOrder/products/product[2]: duplicate productKey value "A1", first used at /PurchaseOrder/products/product[1]
+/PurchaseOrder/line[2]: duplicate lineNumber value "1", first used at /PurchaseOrder/line[1]
+/PurchaseOrder/line[2]: keyref lineProduct value "C3" does not refer to a productKey value`)
+}
+
+func TestGeneratedGoAssert(t *testing.T) {
+	var shipment schema.Shipment
+	require.NoError(t, xml.Unmarshal([]byte(`<Shipment express="true"><item>a</item><carrier>ups</carrier><window from="1" to="3"/><discount capped="true">15</discount></Shipment>`), &shipment))
+	assert.NoError(t, shipment.Validate())
+	assert.NoError(t, shipment.Window.Validate())
+	assert.NoError(t, shipment.Discount.Validate())
+
+	shipment = schema.Shipment{}
+	require.NoError(t, xml.Unmarshal([]byte(`<Shipment express="true"><item>a</item></Shipment>`), &shipment))
+	assert.EqualError(t, shipment.Validate(), `Shipment must satisfy assertion "not(@express = true()) or exists(carrier)"`)
+
+	shipment = schema.Shipment{}
+	require.NoError(t, xml.Unmarshal([]byte(`<Shipment><item>a</item><window from="5" to="3"/><discount capped="true">60</discount></Shipment>`), &shipment))
+	assert.EqualError(t, shipment.Validate(), `Shipment must satisfy assertion "empty(discount) or discount <= 20 or count(item) >= 3"`)
+	assert.EqualError(t, shipment.Window.Validate(), `Window must satisfy assertion "empty(@from) or empty(@to) or @from <= @to"`)
+	assert.EqualError(t, shipment.Discount.Validate(), `Discount must satisfy assertion "not(@capped = true()) or . le 50"`)
+	assert.EqualError(t, schema.Percentage(101).Validate(), `Percentage must satisfy assertion "$value ge 0 and $value le 100"`)
+
+	var express schema.ExpressShipment
+	require.NoError(t, xml.Unmarshal([]byte(`<ExpressShipment><item>a</item><deadline>96</deadline></ExpressShipment>`), &express))
+	assert.EqualError(t, express.Validate(), `ExpressShipment must satisfy assertion "deadline < 72"`)
+}
+
+func TestGeneratedGoNamespace(t *testing.T) {
+	var order schema.Order
+	require.NoError(t, xml.Unmarshal([]byte(`<o:Order xmlns:o="urn:example:orders" id="o1" o:revision="2" xml:lang="en"><o:customer>Ann</o:customer><note>rush</note><o:entry o:quantity="3"><o:sku>A1</o:sku></o:entry></o:Order>`), &order))
+	assert.Equal(t, "Ann", order.Customer)
+	require.NotNil(t, order.Note)
+	assert.Equal(t, "rush", *order.Note)
+	require.NotNil(t, order.OrdRevision)
+	assert.Equal(t, 2, *order.OrdRevision)
+	require.NotNil(t, order.XmlLang)
+	assert.Equal(t, "en", *order.XmlLang)
+	require.Len(t, order.Entry, 1)
+	assert.Equal(t, "A1", order.Entry[0].Sku)
+	require.NotNil(t, order.Entry[0].Quantity)
+	assert.Equal(t, 3, *order.Entry[0].Quantity)
+
+	// The qualified elements and attributes in no namespace are not matched
+	order = schema.Order{}
+	require.NoError(t, xml.Unmarshal([]byte(`<Order xmlns="urn:example:orders" id="o1" revision="2"><customer xmlns="">Ann</customer></Order>`), &order))
+	assert.Empty(t, order.Customer)
+	assert.Nil(t, order.OrdRevision)
+	assert.Error(t, xml.Unmarshal([]byte(`<Order id="o1"/>`), &order))
+}
@@ -0,0 +1,422 @@
+// Copyright 2026 The xgen Authors. All rights reserved. Use of this source
+// code is governed by a BSD-style license that can be found in the LICENSE
+// file.
+//
+// Package xgen written in pure Go providing a set of functions that allow you
+// to parse XSD (XML schema files). This library needs Go version 1.10 or
+// later.
+
+package xgen
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// xpathExpr is a node of the syntax tree of an expression in the subset of
+// XPath 2.0 supported in assertions: relative location paths on the child and
+// attribute axes, string and numeric literals, the $value variable, general
+// and value comparisons, the and, or operators and the functions listed in
+// xpathFunctions.
+type xpathExpr interface{}
+
+// xpathBinary is a boolean operation or a comparison of two expressions. The
+// value comparison operators are held as their general comparison
+// counterparts.
+type xpathBinary struct {
+	Op          string // or, and, =, !=, <, <=, >, >=
+	Left, Right xpathExpr
+}
+
+// xpathCall is a call of one of the functions listed in xpathFunctions.
+type xpathCall struct {
+	Name string
+	Args []xpathExpr
+}
+
+// xpathPath is a relative location path, a path without any steps selects
+// the context node.
+type xpathPath struct {
+	Text  string
+	Steps []xpathStep
+}
+
+// xpathStep is a step of a location path on the child or attribute axis.
+type xpathStep struct {
+	Attribute bool
+	Name      string
+}
+
+// xpathString is a string literal.
+type xpathString struct {
+	Value string
+}
+
+// xpathNumber is a numeric literal.
+type xpathNumber struct {
+	Value float64
+}
+
+// xpathVariable is a reference to the $value variable.
+type xpathVariable struct {
+	Name string
+}
+
+// xpathFunctions maps the names of the supported functions to their number
+// of arguments.
+var xpathFunctions = map[string]int{
+	"count":  1,
+	"empty":  1,
+	"exists": 1,
+	"false":  0,
+	"not":    1,
+	"true":   0,
+}
+
+// xpathComparisons maps the comparison operators to the general comparison
+// operators.
+var xpathComparisons = map[string]string{
+	"=": "=", "!=": "!=", "<": "<", "<=": "<=", ">": ">", ">=": ">=",
+	"eq": "=", "ne": "!=", "lt": "<", "le": "<=", "gt": ">", "ge": ">=",
+}
+
+// xpathToken is a lexical token of an XPath expression.
+type xpathToken struct {
+	Kind string // name, string, number, symbol or end
+	Text string
+	Pos  int
+}
+
+// xpathParser is a recursive descent parser of an XPath expression.
+type xpathParser struct {
+	expr   string
+	tokens []xpathToken
+	pos    int
+}
+
+// compileXPath parses the XPath expression of an assertion, and returns an
+// error describing the first construct which is not supported.
+func compileXPath(expr string) (xpathExpr, error) {
+	tokens, err := tokenizeXPath(expr)
+	if err != nil {
+		return nil, err
+	}
+	p := &xpathParser{expr: expr, tokens: tokens}
+	e, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t.Kind != "end" {
+		return nil, p.errorf(t, "unexpected %q", t.Text)
+	}
+	return e, nil
+}
+
+// tokenizeXPath splits the XPath expression into tokens.
+func tokenizeXPath(expr string) (tokens []xpathToken, err error) {
+	runes := []rune(expr)
+	isNameStart := func(r rune) bool { return r == '_' || unicode.IsLetter(r) }
+	isNameChar := func(r rune) bool {
+		return isNameStart(r) || unicode.IsDigit(r) || r == '-' || r == '.'
+	}
+	for i := 0; i < len(runes); {
+		r, start := runes[i], len(string(runes[:i]))
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '\'' || r == '"':
+			var value strings.Builder
+			for i++; ; i++ {
+				if i == len(runes) {
+					return nil, fmt.Errorf("unterminated string literal at offset %d", start)
+				}
+				if runes[i] == r {
+					if i+1 < len(runes) && runes[i+1] == r {
+						i++
+					} else {
+						break
+					}
+				}
+				value.WriteRune(runes[i])
+			}
+			i++
+			tokens = append(tokens, xpathToken{Kind: "string", Text: value.String(), Pos: start})
+		case unicode.IsDigit(r) || r == '.' && i+1 < len(runes) && unicode.IsDigit(runes[i+1]):
+			j := i
+			for j < len(runes) && (unicode.IsDigit(runes[j]) || runes[j] == '.' || runes[j] == 'e' || runes[j] == 'E' ||
+				(runes[j] == '-' || runes[j] == '+') && (runes[j-1] == 'e' || runes[j-1] == 'E')) {
+				j++
+			}
+			tokens, i = append(tokens, xpathToken{Kind: "number", Text: string(runes[i:j]), Pos: start}), j
+		case isNameStart(r):
+			j := i
+			for j < len(runes) && (isNameChar(runes[j]) ||
+				runes[j] == ':' && j+1 < len(runes) && isNameStart(runes[j+1]) && !strings.Contains(string(runes[i:j]), ":")) {
+				j++
+			}
+			tokens, i = append(tokens, xpathToken{Kind: "name", Text: string(runes[i:j]), Pos: start}), j
+		default:
+			symbol := string(r)
+			if i+1 < len(runes) {
+				switch two := string(runes[i : i+2]); two {
+				case "//", "::", "!=", "<=", ">=", "..":
+					symbol = two
+				}
+			}
+			if !strings.Contains("/:()[],@$*=!<>.|+-", symbol[:1]) || symbol == "!" || symbol == ":" {
+				return nil, fmt.Errorf("unexpected %q at offset %d", symbol, start)
+			}
+			tokens, i = append(tokens, xpathToken{Kind: "symbol", Text: symbol, Pos: start}), i+len([]rune(symbol))
+		}
+	}
+	return append(tokens, xpathToken{Kind: "end", Pos: len(expr)}), nil
+}
+
+// errorf returns an error at the position of the token.
+func (p *xpathParser) errorf(t xpathToken, format string, args ...interface{}) error {
+	return fmt.Errorf("%s at offset %d", fmt.Sprintf(format, args...), t.Pos)
+}
+
+// peek returns the current token.
+func (p *xpathParser) peek() xpathToken {
+	return p.tokens[p.pos]
+}
+
+// next returns the current token and advances to the next one.
+func (p *xpathParser) next() xpathToken {
+	t := p.tokens[p.pos]
+	if t.Kind != "end" {
+		p.pos++
+	}
+	return t
+}
+
+// accept advances to the next token if the current token is the symbol.
+func (p *xpathParser) accept(symbol string) bool {
+	if t := p.peek(); t.Kind == "symbol" && t.Text == symbol {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+// parseOr parses an expression of the operands of the or operator.
+func (p *xpathParser) parseOr() (xpathExpr, error) {
+	left, err := p.parseAnd()
+	for err == nil && p.peek().Kind == "name" && p.peek().Text == "or" {
+		p.next()
+		var right xpathExpr
+		if right, err = p.parseAnd(); err == nil {
+			left = &xpathBinary{Op: "or", Left: left, Right: right}
+		}
+	}
+	return left, err
+}
+
+// parseAnd parses an expression of the operands of the and operator.
+func (p *xpathParser) parseAnd() (xpathExpr, error) {
+	left, err := p.parseComparison()
+	for err == nil && p.peek().Kind == "name" && p.peek().Text == "and" {
+		p.next()
+		var right xpathExpr
+		if right, err = p.parseComparison(); err == nil {
+			left = &xpathBinary{Op: "and", Left: left, Right: right}
+		}
+	}
+	return left, err
+}
+
+// comparison returns the general comparison operator of the current token,
+// or an empty string if the token is not a comparison operator.
+func (p *xpathParser) comparison() string {
+	if t := p.peek(); t.Kind == "symbol" || t.Kind == "name" {
+		return xpathComparisons[t.Text]
+	}
+	return ""
+}
+
+// parseComparison parses a comparison, or its operand if the operand is not
+// followed by a comparison operator.
+func (p *xpathParser) parseComparison() (xpathExpr, error) {
+	left, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+	op := p.comparison()
+	if op == "" {
+		return left, nil
+	}
+	p.next()
+	right, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+	if p.comparison() != "" {
+		return nil, p.errorf(p.peek(), "comparisons cannot be chained")
+	}
+	return &xpathBinary{Op: op, Left: left, Right: right}, nil
+}
+
+// parseOperand parses an operand of a comparison, which must not be an
+// arithmetic expression.
+func (p *xpathParser) parseOperand() (xpathExpr, error) {
+	e, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+	switch t := p.peek(); {
+	case t.Kind == "symbol" && (t.Text == "+" || t.Text == "-" || t.Text == "*"),
+		t.Kind == "name" && (t.Text == "div" || t.Text == "idiv" || t.Text == "mod"):
+		return nil, p.errorf(t, "arithmetic operators are not supported")
+	}
+	return e, nil
+}
+
+// parsePrimary parses a literal, a variable reference, a parenthesized
+// expression, a function call or a location path.
+func (p *xpathParser) parsePrimary() (xpathExpr, error) {
+	t := p.peek()
+	switch {
+	case t.Kind == "string":
+		p.next()
+		return &xpathString{Value: t.Text}, nil
+	case t.Kind == "number":
+		return p.parseNumber(false)
+	case t.Kind == "symbol" && t.Text == "-" && p.tokens[p.pos+1].Kind == "number":
+		p.next()
+		return p.parseNumber(true)
+	case t.Kind == "symbol" && (t.Text == "+" || t.Text == "-"):
+		return nil, p.errorf(t, "arithmetic operators are not supported")
+	case t.Kind == "symbol" && t.Text == "(":
+		p.next()
+		if p.accept(")") {
+			return nil, p.errorf(t, "empty sequences are not supported")
+		}
+		e, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if !p.accept(")") {
+			return nil, p.errorf(p.peek(), "missing )")
+		}
+		return e, nil
+	case t.Kind == "symbol" && t.Text == "$":
+		p.next()
+		name := p.next()
+		if name.Kind != "name" {
+			return nil, p.errorf(name, "missing variable name")
+		}
+		if name.Text != "value" {
+			return nil, p.errorf(name, "unknown variable $%s", name.Text)
+		}
+		return &xpathVariable{Name: name.Text}, nil
+	case t.Kind == "name" && p.tokens[p.pos+1].Kind == "symbol" && p.tokens[p.pos+1].Text == "(":
+		return p.parseCall()
+	case t.Kind == "symbol" && (t.Text == "/" || t.Text == "//"):
+		return nil, p.errorf(t, "absolute paths are not supported")
+	case t.Kind == "end":
+		return nil, p.errorf(t, "unexpected end of expression")
+	}
+	return p.parsePath()
+}
+
+// parseNumber parses a numeric literal.
+func (p *xpathParser) parseNumber(negative bool) (xpathExpr, error) {
+	t := p.next()
+	value, err := strconv.ParseFloat(t.Text, 64)
+	if err != nil {
+		return nil, p.errorf(t, "invalid number %q", t.Text)
+	}
+	if negative {
+		value = -value
+	}
+	return &xpathNumber{Value: value}, nil
+}
+
+// parseCall parses a call of one of the supported functions.
+func (p *xpathParser) parseCall() (xpathExpr, error) {
+	t := p.next()
+	name := strings.TrimPrefix(t.Text, "fn:")
+	arity, ok := xpathFunctions[name]
+	if !ok {
+		return nil, p.errorf(t, "unsupported function %s()", t.Text)
+	}
+	p.next()
+	call := &xpathCall{Name: name}
+	if !p.accept(")") {
+		for {
+			arg, err := p.parseOr()
+			if err != nil {
+				return nil, err
+			}
+			call.Args = append(call.Args, arg)
+			if p.accept(")") {
+				break
+			}
+			if !p.accept(",") {
+				return nil, p.errorf(p.peek(), "missing ) after the arguments of %s()", t.Text)
+			}
+		}
+	}
+	if len(call.Args) != arity {
+		return nil, p.errorf(t, "%s() takes %d arguments, got %d", t.Text, arity, len(call.Args))
+	}
+	return call, nil
+}
+
+// parsePath parses a relative location path.
+func (p *xpathParser) parsePath() (xpathExpr, error) {
+	start := p.peek().Pos
+	path := &xpathPath{}
+	for {
+		t := p.next()
+		switch {
+		case t.Kind == "symbol" && t.Text == ".":
+		case t.Kind == "symbol" && t.Text == "..":
+			return nil, p.errorf(t, "the parent axis is not supported")
+		case t.Kind == "symbol" && t.Text == "*":
+			return nil, p.errorf(t, "wildcards are not supported")
+		case t.Kind == "symbol" && t.Text == "@":
+			name := p.next()
+			if name.Kind != "name" {
+				return nil, p.errorf(name, "missing attribute name")
+			}
+			path.Steps = append(path.Steps, xpathStep{Attribute: true, Name: name.Text})
+		case t.Kind == "name" && p.accept("::"):
+			name := p.next()
+			if name.Kind != "name" {
+				return nil, p.errorf(name, "missing node name")
+			}
+			switch t.Text {
+			case "child":
+				path.Steps = append(path.Steps, xpathStep{Name: name.Text})
+			case "attribute":
+				path.Steps = append(path.Steps, xpathStep{Attribute: true, Name: name.Text})
+			default:
+				return nil, p.errorf(t, "the %s axis is not supported", t.Text)
+			}
+		case t.Kind == "name":
+			path.Steps = append(path.Steps, xpathStep{Name: t.Text})
+		default:
+			return nil, p.errorf(t, "unexpected %q", t.Text)
+		}
+		if n := p.peek(); n.Kind == "symbol" && n.Text == "[" {
+			return nil, p.errorf(n, "predicates are not supported")
+		}
+		if n := p.peek(); n.Kind == "symbol" && n.Text == "//" {
+			return nil, p.errorf(n, "the descendant axis is not supported")
+		}
+		if !p.accept("/") {
+			break
+		}
+	}
+	for i, step := range path.Steps {
+		if step.Attribute && i != len(path.Steps)-1 {
+			return nil, fmt.Errorf("attribute @%s has no children", step.Name)
+		}
+	}
+	path.Text = strings.TrimSpace(p.expr[start:p.peek().Pos])
+	return path, nil
+}